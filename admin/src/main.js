@@ -66,11 +66,58 @@ router.afterEach(() => {
   NProgress.done();
 });
 
+// 请求拦截器，未指定 Authorization 的请求自动携带 token
+axios.interceptors.request.use(function(config) {
+  const token = localStorage.getItem("token");
+  if (token && !config.headers.Authorization) {
+    config.headers.Authorization = `Bearer ${token}`;
+  }
+  return config;
+});
+
+// 使用刷新令牌换取新的访问令牌，同时过期的多个请求共用一次刷新
+let refreshing = null;
+function refreshToken() {
+  if (!refreshing) {
+    let param = new URLSearchParams();
+    param.append("refreshToken", localStorage.getItem("refreshToken"));
+    refreshing = axios
+      .post("/api/token/refresh", param)
+      .then(({ data }) => {
+        localStorage.setItem("refreshToken", data.refreshToken);
+        store.commit("setToken", data.token);
+        return data.token;
+      })
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+}
+
 // 响应拦截器
 axios.interceptors.response.use(
   function(response) {
     switch (response.data.code) {
       case 40001:
+        // 访问令牌过期时刷新后重试一次，刷新失败再跳转到登录页
+        if (localStorage.getItem("refreshToken") && !response.config.retried) {
+          return refreshToken()
+            .then(token => {
+              response.config.retried = true;
+              response.config.headers.Authorization = `Bearer ${token}`;
+              return axios(response.config);
+            })
+            .catch(() => {
+              localStorage.removeItem("refreshToken");
+              Vue.prototype.$message({
+                type: "error",
+                message: response.data.message
+              });
+              router.push({ path: "/login" });
+              return response;
+            });
+        }
         Vue.prototype.$message({
           type: "error",
           message: response.data.message
@@ -87,6 +134,13 @@ axios.interceptors.response.use(
     return response;
   },
   function(error) {
+    // 没有操作权限
+    if (error.response && error.response.status == 403) {
+      Vue.prototype.$message({
+        type: "error",
+        message: error.response.data.message || error.response.data.error
+      });
+    }
     return Promise.reject(error);
   }
 );
@@ -55,6 +55,7 @@ export default new Vuex.Store({
       state.userMenuList = [];
       state.token = ''; // 清空 token
       localStorage.removeItem('token'); // 同时清空 localStorage 中的 token
+      localStorage.removeItem('refreshToken');
     },
     updateAvatar(state, avatar) {
       state.avatar = avatar;
@@ -83,9 +83,44 @@ router.afterEach(() => {
   NProgress.done();
 });
 
+// 使用刷新令牌换取新的访问令牌，同时过期的多个请求共用一次刷新
+let refreshing = null;
+function refreshToken() {
+  if (!refreshing) {
+    let param = new URLSearchParams();
+    param.append("refreshToken", localStorage.getItem("refreshToken"));
+    refreshing = axios
+      .post("/api/token/refresh", param)
+      .then(({ data }) => {
+        localStorage.setItem("refreshToken", data.refreshToken);
+        store.commit("setToken", data.token);
+        return data.token;
+      })
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+}
+
 axios.interceptors.response.use(
     function(response) {
       switch (response.data.code) {
+        case 40001:
+          // 访问令牌过期时刷新后重试一次，刷新失败则退出登录
+          if (localStorage.getItem("refreshToken") && !response.config.retried) {
+            return refreshToken()
+              .then(token => {
+                response.config.retried = true;
+                response.config.headers.Authorization = `Bearer ${token}`;
+                return axios(response.config);
+              })
+              .catch(() => {
+                store.commit("logout");
+                return response;
+              });
+          }
+          break;
         case 50000:
           Vue.prototype.$toast({ type: "error", message: "系统异常" });
       }
@@ -65,6 +65,7 @@ export default new Vuex.Store({
       state.loginType = null;
       state.loginFlag = false; // 添加此行，确保登出时更新 loginFlag
       localStorage.removeItem('token'); // 清除 token
+      localStorage.removeItem('refreshToken');
     },
     saveLoginUrl(state, url) {
       state.loginUrl = url;
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/streadway/amqp"
@@ -18,96 +19,133 @@ import (
 	"goBolg/strategy"
 	context "goBolg/strategy/contxt"
 	"goBolg/strategy/strategyImpl"
+	"goBolg/utils"
 	"gorm.io/gorm"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // App 包含所有初始化后的实例
 type App struct {
-	Config      *config.AppConfig
-	Database    *gorm.DB
-	RedisClient *redis.Client
-	RabbitMQ    *amqp.Connection
-	Controllers *Controllers
+	Config        *config.AppConfig
+	Database      *gorm.DB
+	RedisClient   *redis.Client
+	RabbitMQ      *amqp.Connection
+	Controllers   *Controllers
+	Scheduler     service.ScheduleService
+	EmailConsumer *rabbitmq.EmailConsumer
 }
 
 // Controllers 包含所有控制器实例
 type Controllers struct {
-	ArticleController    *controller.ArticleController
-	BlogInfoController   *controller.BlogInfoController
-	CategoryController   *controller.CategoryController
-	CommentController    *controller.CommentController
-	FriendLinkController *controller.FriendLinkController
-	LogController        *controller.LogController
-	MenuController       *controller.MenuController
-	MessageController    *controller.MessageController
-	PageController       *controller.PageController
-	PhotoAlbumController *controller.PhotoAlbumController
-	PhotoController      *controller.PhotoController
-	ResourceController   *controller.ResourceController
-	RoleController       *controller.RoleController
-	TagController        *controller.TagController
-	TalkController       *controller.TalkController
-	UserInfoController   *controller.UserInfoController
-	UserAuthController   *controller.UserAuthController
-	UserAuthDao          dao.UserAuthDao
-	UserInfoDao          dao.UserInfoDao
-	RoleDao              dao.RoleDao
-	RedisService         service.RedisService
+	ArticleController       *controller.ArticleController
+	BlogInfoController      *controller.BlogInfoController
+	CategoryController      *controller.CategoryController
+	CommentController       *controller.CommentController
+	EmailQueueController    *controller.EmailQueueController
+	EmailTemplateController *controller.EmailTemplateController
+	FeedController          *controller.FeedController
+	FriendLinkController    *controller.FriendLinkController
+	LogController           *controller.LogController
+	MenuController          *controller.MenuController
+	MessageController       *controller.MessageController
+	NotificationController  *controller.NotificationController
+	PageController          *controller.PageController
+	PhotoAlbumController    *controller.PhotoAlbumController
+	PhotoController         *controller.PhotoController
+	ResourceController      *controller.ResourceController
+	RoleController          *controller.RoleController
+	SensitiveWordController *controller.SensitiveWordController
+	SeriesController        *controller.SeriesController
+	SitemapController       *controller.SitemapController
+	TagController           *controller.TagController
+	TalkController          *controller.TalkController
+	UserInfoController      *controller.UserInfoController
+	UserAuthController      *controller.UserAuthController
+	UserSessionController   *controller.UserSessionController
+	UserAuthDao             dao.UserAuthDao
+	UserInfoDao             dao.UserInfoDao
+	RoleDao                 dao.RoleDao
+	RedisService            service.RedisService
+	RateLimitService        service.RateLimitService
+	TokenService            service.TokenService
+	OnlineUserService       service.OnlineUserService
+	SecurityMetadataSource  *handler.FilterInvocationSecurityMetadataSourceImpl
 }
 
 // Initialize 初始化应用程序
 func Initialize() (*App, error) {
-	// 获取当前工作目录
-	cwd, err := os.Getwd()
+	appConfig, err := loadAppConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current working directory: %w", err)
+		return nil, err
 	}
 
-	// 构建配置文件路径
-	configPath := filepath.Join(cwd, "config/application.yaml")
-
-	// 检查配置文件是否存在
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("config file '%s' does not exist", configPath)
+	// 加载 JWT 密钥
+	if err := utils.InitJWT(jwtOptions(appConfig.JWT)); err != nil {
+		if errors.Is(err, utils.ErrJWTSecretEmpty) {
+			return nil, fmt.Errorf("failed to init jwt: %w, set jwt.keys[].secret in config/application.yaml or the %s environment variable", err, config.JWTSecretEnv)
+		}
+		return nil, fmt.Errorf("failed to init jwt: %w", err)
 	}
 
-	// 加载应用程序配置
-	appConfig, err := config.LoadConfig(configPath)
+	database, redisClient, rabbitMQ, err := connectServices(appConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load app config: %w", err)
+		return nil, err
 	}
 
-	// 连接数据库
-	database, err := common.ConnectDB(&appConfig.Database)
+	// 自动迁移数据库结构
+	err = database.AutoMigrate(&model.Tag{}, &model.Article{}, &model.Message{}, &model.ArticleTag{}, &model.ArticleRevision{}, &model.Series{}, &model.SeriesArticle{}, &model.SensitiveWord{}, &model.EmailTemplate{}, &model.Notification{}, &model.NotificationSetting{}, &model.CommentRevision{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		common.CloseDB(database)
+		common.CloseRedis(redisClient)
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	// 连接 Redis
-	redisClient, err := common.ConnectRedis(&appConfig.Redis)
-	if err != nil {
-		common.CloseDB(database)
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	// 说说表只补充定时发布字段，避免自动迁移修改已有字段
+	if !database.Migrator().HasColumn(&model.Talk{}, "PublishTime") {
+		if err := database.Migrator().AddColumn(&model.Talk{}, "PublishTime"); err != nil {
+			common.CloseDB(database)
+			common.CloseRedis(redisClient)
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
 	}
 
-	// 连接 RabbitMQ
-	rabbitMQ, err := common.ConnectRabbitMQ(&appConfig.RabbitMQ)
-	if err != nil {
+	// 评论表只补充新增字段，避免自动迁移修改已有字段
+	for _, column := range []string{"SpamScore", "EditTime", "CommentSource"} {
+		if database.Migrator().HasColumn(&model.Comment{}, column) {
+			continue
+		}
+		if err := database.Migrator().AddColumn(&model.Comment{}, column); err != nil {
+			common.CloseDB(database)
+			common.CloseRedis(redisClient)
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	// 补充新增后台接口的资源和授权，未配置资源的后台接口会被拒绝访问
+	if err := migrateAdminResources(database); err != nil {
 		common.CloseDB(database)
 		common.CloseRedis(redisClient)
-		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	// 自动迁移数据库结构
-	err = database.AutoMigrate(&model.Tag{}, &model.Article{}, &model.Message{}, &model.ArticleTag{})
-	if err != nil {
+	// 使用全文搜索时创建 ngram 全文索引
+	if appConfig.Search.Mode == "mysql-fulltext" {
+		if err := migrateArticleFullTextIndex(database); err != nil {
+			common.CloseDB(database)
+			common.CloseRedis(redisClient)
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	// 声明邮件队列，保证 Web 服务和单独部署的消费者无论谁先启动都能正常投递
+	if err := declareEmailQueue(rabbitMQ); err != nil {
 		common.CloseDB(database)
 		common.CloseRedis(redisClient)
-		return nil, fmt.Errorf("failed to migrate database: %w", err)
+		common.CloseRabbitMQ(rabbitMQ)
+		return nil, fmt.Errorf("failed to declare email queue: %w", err)
 	}
 
 	// 初始化 RabbitMQ 客户端
@@ -137,11 +175,25 @@ func Initialize() (*App, error) {
 	blogInfoService := Impl.NewBlogInfoService(userInfoDao, messageDao, uniqueViewDao, articleDao, categoryDao, tagDao, redisService, websiteConfigDao, pageService, database)
 	categoryService := Impl.NewCategoryService(categoryDao, articleDao)
 
+	// 初始化 SensitiveWordService
+	sensitiveWordService := Impl.NewSensitiveWordService(dao.NewSensitiveWordDao(database), redisService)
+
+	// 初始化 SpamService
+	spamService := Impl.NewSpamService(redisService, userInfoDao, appConfig.Spam)
+
+	// 初始化 NotificationService
+	rabbitSer := rabbitImpl.NewRabbitService(rabbitMQ)
+	notificationService := Impl.NewNotificationService(dao.NewNotificationDao(database), dao.NewNotificationSettingDao(database), userInfoDao, blogInfoService, rabbitSer, appConfig.Website.URL)
+
 	// 初始化 CommentService
 	commentDao := dao.NewCommentDao(database)
+	commentRevisionDao := dao.NewCommentRevisionDao(database)
 	talkDao := dao.NewTalkDao(database)
 	// 配置config的
-	commentService := Impl.NewCommentServiceImpl(commentDao, articleDao, talkDao, userInfoDao, redisService, rabbitMQClient, blogInfoService, appConfig.Website.URL)
+	commentService := Impl.NewCommentServiceImpl(commentDao, commentRevisionDao, articleDao, talkDao, userInfoDao, redisService, rabbitMQClient, blogInfoService, appConfig.Website.URL, appConfig.Comment, sensitiveWordService, spamService, notificationService)
+
+	//初始化 FeedService
+	feedService := Impl.NewFeedService(articleDao, categoryDao, tagDao, blogInfoService, redisService, appConfig.Website.URL, appConfig.Feed)
 
 	//初始化 FriendLinkService
 	friendLinkDao := dao.NewFriendLinkDao(database)
@@ -157,7 +209,7 @@ func Initialize() (*App, error) {
 	menuService := Impl.NewMenuService(menuDao, roleMenu)
 
 	//初始化MessageService
-	messageService := Impl.NewMessageService(messageDao, blogInfoService, &http.Request{})
+	messageService := Impl.NewMessageService(messageDao, blogInfoService, sensitiveWordService, spamService)
 
 	// 初始化上传策略上下文
 	uploadStrategyContext, err := context.NewUploadStrategyContext(appConfig)
@@ -179,7 +231,7 @@ func Initialize() (*App, error) {
 	// 初始化 ResourceService
 	resourceDao := dao.NewResourceDao(database)
 	roleResourceDao := dao.NewRoleResourceDao(database)
-	filter := handler.NewFilterInvocationSecurityMetadataSourceImpl(roleDao)
+	filter := handler.NewFilterInvocationSecurityMetadataSourceImpl(roleDao, redisService)
 	resourceService := Impl.NewResourceService(resourceDao, roleResourceDao, filter)
 
 	// 初始化 RoleService
@@ -187,62 +239,181 @@ func Initialize() (*App, error) {
 	roleMenuDao := dao.NewRoleMenuDao(database)
 	roleService := Impl.NewRoleServiceImpl(roleDao, userRoleDao, roleResourceDao, roleMenuDao, database, filter)
 
+	// 初始化 SeriesService
+	seriesService := Impl.NewSeriesService(dao.NewSeriesDao(database), articleDao)
+
+	// 初始化 SitemapService
+	sitemapService := Impl.NewSitemapService(dao.NewSitemapDao(database), redisService, appConfig.Website.URL, appConfig.Sitemap, appConfig.Robots)
+
 	//初始化 TagService
 	articleTagDao := dao.NewArticleTagDao(database)
 	tagService := Impl.NewTagServiceImpl(tagDao, articleTagDao, database)
 
 	//初始化 TalkService
-	talkService := Impl.NewTalkService(talkDao, commentDao, redisService)
+	talkService := Impl.NewTalkService(talkDao, commentDao, redisService, notificationService)
+
+	// 初始化定时发布任务
+	scheduleService := Impl.NewScheduleService(articleDao, talkDao, redisService, time.Duration(appConfig.Schedule.PublishInterval)*time.Second)
+
+	// 初始化 TokenService
+	tokenService := Impl.NewTokenService(redisService, userAuthDao, time.Duration(appConfig.JWT.RefreshExpireTime)*time.Second)
+	onlineUserService := Impl.NewOnlineUserService(redisService)
 
 	//初始化 UserInfoService
-	userInfoService := Impl.NewUserInfoService(userInfoDao, uploadStrategyContext, redisService)
+	userInfoService := Impl.NewUserInfoService(userInfoDao, userRoleDao, uploadStrategyContext, redisService, sensitiveWordService, tokenService)
 
 	//初始化 UserAuthService
-	rabbitSer := rabbitImpl.NewRabbitService(rabbitMQ)
 	emailService := rabbitService.NewEmailService(appConfig.Email)
 	log.Printf("Created EmailService with Host: %s, Port: %d", emailService.Host, emailService.Port)
-	userAuthService := Impl.NewUserAuthService(*emailService, redisService, rabbitSer, userInfoDao, userRoleDao, userAuthDao, blogInfoService)
+	emailTemplateService := Impl.NewEmailTemplateService(dao.NewEmailTemplateDao(database), blogInfoService, appConfig.Website.URL)
+	userAuthService := Impl.NewUserAuthService(*emailService, redisService, rabbitSer, userInfoDao, userRoleDao, userAuthDao, blogInfoService, emailTemplateService)
+
 	// 初始化控制器
 	controllers := &Controllers{
-		ArticleController:    NewArticleController(database, redisClient, blogInfoService, appConfig),
-		BlogInfoController:   NewBlogInfoController(blogInfoService),
-		CategoryController:   NewCategoryController(categoryService),
-		CommentController:    NewCommentController(commentService),
-		FriendLinkController: NewFriendLinkController(friendLinkService),
-		LogController:        NewLogController(operationLogService),
-		MenuController:       NewMenuController(menuService),
-		MessageController:    NewMessageController(messageService),
-		PageController:       NewPagesController(pageService),
-		PhotoAlbumController: NewPhotoAlbumController(uploadStrategyContext, photoAlbumService), // 初始化PhotoAlbumController
-		PhotoController:      NewPhotoController(photoService),
-		ResourceController:   NewResourceController(resourceService),
-		RoleController:       NewRoleController(roleService),
-		TagController:        NewTagController(tagService),
-		TalkController:       NewTalkController(talkService, uploadStrategyContext),
-		UserInfoController:   NewUserInfoController(userInfoService),
-		UserAuthController:   NewUserAuthController(userAuthService),
-		UserAuthDao:          userAuthDao,
-		UserInfoDao:          userInfoDao,
-		RoleDao:              roleDao,
-		RedisService:         redisService,
+		ArticleController:       NewArticleController(database, redisClient, blogInfoService, notificationService, seriesService, uploadStrategyContext, appConfig),
+		BlogInfoController:      NewBlogInfoController(blogInfoService),
+		CategoryController:      NewCategoryController(categoryService),
+		CommentController:       NewCommentController(commentService),
+		EmailQueueController:    NewEmailQueueController(Impl.NewEmailQueueService(rabbitMQ)),
+		EmailTemplateController: NewEmailTemplateController(emailTemplateService),
+		FeedController:          NewFeedController(feedService, appConfig.Feed.CacheTTL),
+		FriendLinkController:    NewFriendLinkController(friendLinkService),
+		LogController:           NewLogController(operationLogService),
+		MenuController:          NewMenuController(menuService),
+		MessageController:       NewMessageController(messageService),
+		NotificationController:  NewNotificationController(notificationService),
+		PageController:          NewPagesController(pageService),
+		PhotoAlbumController:    NewPhotoAlbumController(uploadStrategyContext, photoAlbumService), // 初始化PhotoAlbumController
+		PhotoController:         NewPhotoController(photoService),
+		ResourceController:      NewResourceController(resourceService),
+		RoleController:          NewRoleController(roleService),
+		SensitiveWordController: NewSensitiveWordController(sensitiveWordService),
+		SeriesController:        NewSeriesController(seriesService),
+		SitemapController:       NewSitemapController(sitemapService, appConfig.Sitemap.CacheTTL),
+		TagController:           NewTagController(tagService),
+		TalkController:          NewTalkController(talkService, uploadStrategyContext),
+		UserInfoController:      NewUserInfoController(userInfoService),
+		UserAuthController:      NewUserAuthController(userAuthService),
+		UserSessionController:   NewUserSessionController(tokenService, onlineUserService),
+		UserAuthDao:             userAuthDao,
+		UserInfoDao:             userInfoDao,
+		RoleDao:                 roleDao,
+		RedisService:            redisService,
+		RateLimitService:        Impl.NewRateLimitService(redisService, appConfig.RateLimit),
+		TokenService:            tokenService,
+		OnlineUserService:       onlineUserService,
+		SecurityMetadataSource:  filter,
 	}
 
 	// 创建 App 实例
 	app := &App{
-		Config:      appConfig,
-		Database:    database,
-		RedisClient: redisClient,
-		RabbitMQ:    rabbitMQ,
-		Controllers: controllers,
+		Config:        appConfig,
+		Database:      database,
+		RedisClient:   redisClient,
+		RabbitMQ:      rabbitMQ,
+		Controllers:   controllers,
+		Scheduler:     scheduleService,
+		EmailConsumer: rabbitmq.NewEmailConsumer(rabbitMQ, emailService, emailTemplateService, appConfig.EmailWorker),
 	}
+	scheduleService.Start()
+	sensitiveWordService.Start()
+	filter.Start()
 
 	return app, nil
 }
 
+// InitializeWorker 只初始化邮件消费者，worker 模式不执行数据库迁移，也不启动定时任务和配置订阅
+func InitializeWorker() (*App, error) {
+	appConfig, err := loadAppConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	database, redisClient, rabbitMQ, err := connectServices(appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// 声明邮件队列，保证 Web 服务和单独部署的消费者无论谁先启动都能正常投递
+	if err := declareEmailQueue(rabbitMQ); err != nil {
+		common.CloseDB(database)
+		common.CloseRedis(redisClient)
+		common.CloseRabbitMQ(rabbitMQ)
+		return nil, fmt.Errorf("failed to declare email queue: %w", err)
+	}
+
+	// 邮件模板渲染需要读取网站配置
+	redisService := Impl.NewRedisServiceImpl(redisClient)
+	articleDao := dao.NewArticleDao(database)
+	pageService := Impl.NewPageService(database, dao.NewPageDao(database), redisService)
+	blogInfoService := Impl.NewBlogInfoService(dao.NewUserInfoDao(database), dao.NewMessageDao(database), dao.NewUniqueViewDao(database), articleDao, dao.NewCategoryDao(database), dao.NewTagDao(database), redisService, dao.NewWebsiteConfigDao(database), pageService, database)
+	emailTemplateService := Impl.NewEmailTemplateService(dao.NewEmailTemplateDao(database), blogInfoService, appConfig.Website.URL)
+	emailService := rabbitService.NewEmailService(appConfig.Email)
+
+	return &App{
+		Config:        appConfig,
+		Database:      database,
+		RedisClient:   redisClient,
+		RabbitMQ:      rabbitMQ,
+		EmailConsumer: rabbitmq.NewEmailConsumer(rabbitMQ, emailService, emailTemplateService, appConfig.EmailWorker),
+	}, nil
+}
+
+// loadAppConfig 读取当前工作目录下的配置文件
+func loadAppConfig() (*config.AppConfig, error) {
+	// 获取当前工作目录
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current working directory: %w", err)
+	}
+
+	// 构建配置文件路径
+	configPath := filepath.Join(cwd, "config/application.yaml")
+
+	// 检查配置文件是否存在
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("config file '%s' does not exist", configPath)
+	}
+
+	// 加载应用程序配置
+	appConfig, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load app config: %w", err)
+	}
+	return appConfig, nil
+}
+
+// connectServices 连接数据库、Redis 和 RabbitMQ，任意一个失败时关闭已建立的连接
+func connectServices(appConfig *config.AppConfig) (*gorm.DB, *redis.Client, *amqp.Connection, error) {
+	// 连接数据库
+	database, err := common.ConnectDB(&appConfig.Database)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	// 连接 Redis
+	redisClient, err := common.ConnectRedis(&appConfig.Redis)
+	if err != nil {
+		common.CloseDB(database)
+		return nil, nil, nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	// 连接 RabbitMQ
+	rabbitMQ, err := common.ConnectRabbitMQ(&appConfig.RabbitMQ)
+	if err != nil {
+		common.CloseDB(database)
+		common.CloseRedis(redisClient)
+		return nil, nil, nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	return database, redisClient, rabbitMQ, nil
+}
+
 // NewArticleController 初始化文章控制器
-func NewArticleController(database *gorm.DB, redisClient *redis.Client, blogInfoService service.BlogInfoService, appConfig *config.AppConfig) *controller.ArticleController {
+func NewArticleController(database *gorm.DB, redisClient *redis.Client, blogInfoService service.BlogInfoService, notificationService service.NotificationService, seriesService service.SeriesService, uploadStrategyContext *context.UploadStrategyContext, appConfig *config.AppConfig) *controller.ArticleController {
 	articleDao := dao.NewArticleDao(database)
 	articleTagDao := dao.NewArticleTagDao(database)
+	articleRevisionDao := dao.NewArticleRevisionDao(database)
+	seriesDao := dao.NewSeriesDao(database)
 	categoryDao := dao.NewCategoryDao(database)
 	tagDao := dao.NewTagDao(database)
 	tagService := Impl.NewTagServiceImpl(tagDao, articleTagDao, database)
@@ -250,14 +421,69 @@ func NewArticleController(database *gorm.DB, redisClient *redis.Client, blogInfo
 
 	// 初始化搜索策略
 	searchStrategyMap := map[string]strategy.SearchStrategy{
-		"mysql": &strategyImpl.MySqlSearchStrategyImpl{ArticleDao: articleDao},
+		"mysql":          &strategyImpl.MySqlSearchStrategyImpl{ArticleDao: articleDao},
+		"bleve":          &strategyImpl.BleveSearchStrategyImpl{ArticleDao: articleDao, IndexPath: appConfig.Search.IndexPath},
+		"mysql-fulltext": &strategyImpl.MySqlFullTextSearchStrategyImpl{ArticleDao: articleDao},
 	}
 	searchStrategyContext := context.NewSearchStrategyContext(appConfig.Search.Mode, searchStrategyMap)
 
-	articleService := Impl.NewArticleServiceImpl(articleDao, articleTagDao, categoryDao, tagDao, tagService, redisService, blogInfoService, searchStrategyContext, database)
+	articleService := Impl.NewArticleServiceImpl(articleDao, articleTagDao, articleRevisionDao, appConfig.Article.RevisionLimit, seriesDao, seriesService, categoryDao, tagDao, tagService, redisService, blogInfoService, notificationService, searchStrategyContext, uploadStrategyContext, database)
 	return &controller.ArticleController{
+		Service:               articleService,
+		SearchStrategyContext: searchStrategyContext,
+	}
+}
 
-		Service: articleService,
+// migrateArticleFullTextIndex 为文章标题和内容创建 ngram 全文索引，InnoDB 每条语句只能创建一个全文索引
+func migrateArticleFullTextIndex(db *gorm.DB) error {
+	indexes := []struct {
+		name    string
+		columns string
+	}{
+		{"ft_article_title_content", "`article_title`, `article_content`"},
+		{"ft_article_title", "`article_title`"},
+	}
+	for _, index := range indexes {
+		if db.Migrator().HasIndex(&model.Article{}, index.name) {
+			continue
+		}
+		log.Printf("Creating full-text index %s, this may take a while", index.name)
+		sql := fmt.Sprintf("ALTER TABLE `tb_article` ADD FULLTEXT INDEX `%s`(%s) WITH PARSER ngram", index.name, index.columns)
+		if err := db.Exec(sql).Error; err != nil {
+			return fmt.Errorf("failed to create full-text index %s: %w", index.name, err)
+		}
+	}
+	return nil
+}
+
+// declareEmailQueue 使用临时通道声明邮件队列
+func declareEmailQueue(conn *amqp.Connection) error {
+	channel, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+	return rabbitmq.DeclareEmailQueue(channel)
+}
+
+// jwtOptions 将 JWT 配置转换为签发和验证使用的参数
+func jwtOptions(jwtConfig config.JWTConfig) utils.JWTOptions {
+	keys := make([]utils.JWTKey, 0, len(jwtConfig.Keys))
+	for _, key := range jwtConfig.Keys {
+		keys = append(keys, utils.JWTKey{
+			ID:             key.ID,
+			Algorithm:      key.Algorithm,
+			Secret:         key.Secret,
+			PrivateKeyFile: key.PrivateKey,
+			PublicKeyFile:  key.PublicKey,
+		})
+	}
+	return utils.JWTOptions{
+		Issuer:        jwtConfig.Issuer,
+		Audience:      jwtConfig.Audience,
+		TokenDuration: time.Duration(jwtConfig.ExpireTime) * time.Second,
+		SigningKeyID:  jwtConfig.SigningKey,
+		Keys:          keys,
 	}
 }
 
@@ -282,6 +508,14 @@ func NewCommentController(commentService service.CommentService) *controller.Com
 	}
 }
 
+// NewFeedController 初始化订阅源控制器
+func NewFeedController(feedService service.FeedService, cacheTTL int) *controller.FeedController {
+	return &controller.FeedController{
+		FeedService: feedService,
+		CacheTTL:    cacheTTL,
+	}
+}
+
 // NewFriendLinkController 初始化友链控制器
 func NewFriendLinkController(friendLinkService service.FriendLinkService) *controller.FriendLinkController {
 	return &controller.FriendLinkController{
@@ -303,6 +537,20 @@ func NewMenuController(menuService service.MenuService) *controller.MenuControll
 	}
 }
 
+// NewEmailQueueController 初始化邮件队列控制器
+func NewEmailQueueController(emailQueueService service.EmailQueueService) *controller.EmailQueueController {
+	return &controller.EmailQueueController{
+		EmailQueueService: emailQueueService,
+	}
+}
+
+// NewEmailTemplateController 初始化邮件模板控制器
+func NewEmailTemplateController(emailTemplateService service.EmailTemplateService) *controller.EmailTemplateController {
+	return &controller.EmailTemplateController{
+		EmailTemplateService: emailTemplateService,
+	}
+}
+
 // NewMessageController 初始化留言板控制器
 func NewMessageController(messageService service.MessageService) *controller.MessageController {
 	return &controller.MessageController{
@@ -310,6 +558,13 @@ func NewMessageController(messageService service.MessageService) *controller.Mes
 	}
 }
 
+// NewNotificationController 初始化通知控制器
+func NewNotificationController(notificationService service.NotificationService) *controller.NotificationController {
+	return &controller.NotificationController{
+		NotificationService: notificationService,
+	}
+}
+
 // 初始化页面控制器
 func NewPagesController(pageService service.PageService) *controller.PageController {
 	return &controller.PageController{
@@ -346,6 +601,28 @@ func NewRoleController(roleService service.RoleService) *controller.RoleControll
 	}
 }
 
+// NewSensitiveWordController 初始化敏感词控制器
+func NewSensitiveWordController(sensitiveWordService service.SensitiveWordService) *controller.SensitiveWordController {
+	return &controller.SensitiveWordController{
+		SensitiveWordService: sensitiveWordService,
+	}
+}
+
+// NewSeriesController 初始化系列控制器
+func NewSeriesController(seriesService service.SeriesService) *controller.SeriesController {
+	return &controller.SeriesController{
+		SeriesService: seriesService,
+	}
+}
+
+// NewSitemapController 初始化站点地图控制器
+func NewSitemapController(sitemapService service.SitemapService, cacheTTL int) *controller.SitemapController {
+	return &controller.SitemapController{
+		SitemapService: sitemapService,
+		CacheTTL:       cacheTTL,
+	}
+}
+
 // NewTagController 初始化标签控制器
 func NewTagController(tagService service.TagService) *controller.TagController {
 	return &controller.TagController{
@@ -364,19 +641,45 @@ func NewTalkController(talkService service.TalkService, uploadStrategyContext *c
 // NewUserInfoController 初始化用户信息控制器
 func NewUserInfoController(userInfoService service.UserInfoService) *controller.UserInfoController {
 	return &controller.UserInfoController{
-		userInfoService,
+		UserInfoService: userInfoService,
 	}
 }
 
 // NewUserAuthController 初始化用户认证控制器
 func NewUserAuthController(userAuthService service.UserAuthService) *controller.UserAuthController {
 	return &controller.UserAuthController{
-		userAuthService,
+		UserAuthService: userAuthService,
+	}
+}
+
+// NewUserSessionController 初始化用户登录会话控制器
+func NewUserSessionController(tokenService service.TokenService, onlineUserService service.OnlineUserService) *controller.UserSessionController {
+	return &controller.UserSessionController{
+		TokenService:      tokenService,
+		OnlineUserService: onlineUserService,
 	}
 }
 
 // Close 关闭应用程序
 func (app *App) Close() {
+	if app.Scheduler != nil {
+		app.Scheduler.Stop()
+	}
+	// 先等待正在发送的邮件完成再关闭连接
+	if app.EmailConsumer != nil {
+		app.EmailConsumer.Stop()
+	}
+	if app.Controllers != nil && app.Controllers.ArticleController != nil && app.Controllers.ArticleController.SearchStrategyContext != nil {
+		if err := app.Controllers.ArticleController.SearchStrategyContext.Close(); err != nil {
+			log.Printf("Error closing search index: %v", err)
+		}
+	}
+	if app.Controllers != nil && app.Controllers.SensitiveWordController != nil {
+		app.Controllers.SensitiveWordController.SensitiveWordService.Stop()
+	}
+	if app.Controllers != nil && app.Controllers.SecurityMetadataSource != nil {
+		app.Controllers.SecurityMetadataSource.Stop()
+	}
 	common.CloseDB(app.Database)
 	common.CloseRedis(app.RedisClient)
 	common.CloseRabbitMQ(app.RabbitMQ)
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"goBolg/model"
+	"gorm.io/gorm"
+)
+
+// adminResource 后台接口资源，新建时授权给 roleLabels 中的角色
+type adminResource struct {
+	name       string   // 资源名
+	url        string   // 接口路径
+	method     string   // 请求方式
+	module     string   // 所属模块名
+	roleLabels []string // 可以访问的角色标签
+}
+
+// adminResources 新增后台接口对应的资源，与 sql/blog.sql 中的初始数据保持一致
+var adminResources = []adminResource{
+	{"导入文章", "/admin/articles/import", "POST", "文章模块", []string{"admin"}},
+	{"导出文章", "/admin/articles/export", "POST", "文章模块", []string{"admin"}},
+	{"重建文章搜索索引", "/admin/articles/search/index", "POST", "文章模块", []string{"admin"}},
+	{"取消文章定时发布", "/admin/articles/*/schedule", "DELETE", "文章模块", []string{"admin"}},
+	{"查看文章历史版本", "/admin/articles/*/revisions", "GET", "文章模块", []string{"admin", "test"}},
+	{"对比文章历史版本", "/admin/articles/*/revisions/diff", "GET", "文章模块", []string{"admin", "test"}},
+	{"恢复文章历史版本", "/admin/articles/*/revisions/*/restore", "POST", "文章模块", []string{"admin"}},
+	{"查看后台系列列表", "/admin/series", "GET", "系列模块", []string{"admin", "test"}},
+	{"根据id查看后台系列", "/admin/series/*", "GET", "系列模块", []string{"admin", "test"}},
+	{"保存或修改系列", "/admin/series", "POST", "系列模块", []string{"admin"}},
+	{"删除系列", "/admin/series", "DELETE", "系列模块", []string{"admin"}},
+	{"查看敏感词列表", "/admin/sensitive-words", "GET", "敏感词模块", []string{"admin", "test"}},
+	{"保存或修改敏感词", "/admin/sensitive-words", "POST", "敏感词模块", []string{"admin"}},
+	{"删除敏感词", "/admin/sensitive-words", "DELETE", "敏感词模块", []string{"admin"}},
+	{"导入敏感词", "/admin/sensitive-words/import", "POST", "敏感词模块", []string{"admin"}},
+	{"查看邮件模板列表", "/admin/email-templates", "GET", "邮件模块", []string{"admin", "test"}},
+	{"保存邮件模板", "/admin/email-templates", "POST", "邮件模块", []string{"admin"}},
+	{"删除邮件模板", "/admin/email-templates/*", "DELETE", "邮件模块", []string{"admin"}},
+	{"查看发送失败的邮件", "/admin/emails/dead-letters", "GET", "邮件模块", []string{"admin", "test"}},
+	{"重新发送失败的邮件", "/admin/emails/dead-letters/retry", "POST", "邮件模块", []string{"admin"}},
+	{"清空发送失败的邮件", "/admin/emails/dead-letters", "DELETE", "邮件模块", []string{"admin"}},
+	{"取消说说定时发布", "/admin/talks/*/schedule", "DELETE", "说说模块", []string{"admin"}},
+	{"强制用户下线", "/admin/users/*/sessions", "DELETE", "用户信息模块", []string{"admin"}},
+}
+
+// migrateAdminResources 补充缺少的后台接口资源，按路径和请求方式判断是否已存在，
+// 只为本次新建的资源授权，管理员之后调整的授权不会被覆盖
+func migrateAdminResources(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var roles []model.Role
+		if err := tx.Select("id, role_label").Find(&roles).Error; err != nil {
+			return fmt.Errorf("failed to list roles: %w", err)
+		}
+		roleIds := make(map[string]int, len(roles))
+		for _, role := range roles {
+			roleIds[role.RoleLabel] = role.ID
+		}
+
+		moduleIds := make(map[string]uint)
+		for _, item := range adminResources {
+			var count int64
+			if err := tx.Model(&model.Resource{}).
+				Where("url = ? AND request_method = ?", item.url, item.method).
+				Count(&count).Error; err != nil {
+				return fmt.Errorf("failed to check resource %s %s: %w", item.method, item.url, err)
+			}
+			if count > 0 {
+				continue
+			}
+
+			moduleId, ok := moduleIds[item.module]
+			if !ok {
+				id, err := ensureResourceModule(tx, item.module)
+				if err != nil {
+					return err
+				}
+				moduleId = id
+				moduleIds[item.module] = id
+			}
+			resource := model.Resource{
+				ResourceName:  item.name,
+				URL:           item.url,
+				RequestMethod: item.method,
+				ParentID:      &moduleId,
+			}
+			if err := tx.Create(&resource).Error; err != nil {
+				return fmt.Errorf("failed to create resource %s %s: %w", item.method, item.url, err)
+			}
+			for _, label := range item.roleLabels {
+				roleId, ok := roleIds[label]
+				if !ok {
+					continue
+				}
+				if err := tx.Create(&model.RoleResource{RoleID: roleId, ResourceID: int(resource.ID)}).Error; err != nil {
+					return fmt.Errorf("failed to grant resource %s %s: %w", item.method, item.url, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// ensureResourceModule 按名称查询资源模块，不存在时创建
+func ensureResourceModule(tx *gorm.DB, name string) (uint, error) {
+	var module model.Resource
+	err := tx.Select("id").Where("resource_name = ? AND parent_id IS NULL", name).First(&module).Error
+	if err == nil {
+		return module.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("failed to get resource module %s: %w", name, err)
+	}
+	// 模块没有接口路径和请求方式，与初始数据一样保存为 NULL
+	module = model.Resource{ResourceName: name}
+	if err := tx.Omit("url", "request_method", "parent_id").Create(&module).Error; err != nil {
+		return 0, fmt.Errorf("failed to create resource module %s: %w", name, err)
+	}
+	return module.ID, nil
+}
@@ -22,4 +22,6 @@ func NewWebSecurityConfig(authHandler handler.AuthHandler) *WebSecurityConfig {
 func (w *WebSecurityConfig) Configure(router *gin.Engine) {
 	router.POST("/login", w.AuthHandler.Login)
 	router.GET("/logout", w.AuthHandler.Logout)
+	router.POST("/logout", w.AuthHandler.Logout)
+	router.POST("/token/refresh", w.AuthHandler.RefreshToken)
 }
@@ -24,7 +24,8 @@ type DatabaseConfig struct {
 
 // ServerConfig 服务器配置结构体
 type ServerConfig struct {
-	Port int `yaml:"port"`
+	Port           int      `yaml:"port"`
+	TrustedProxies []string `yaml:"trustedProxies"` // 信任的反向代理地址或网段，只有来自这些地址的请求才读取 X-Forwarded-For
 }
 
 // RedisConfig Redis 配置结构体
@@ -60,7 +61,8 @@ type OssConfig struct {
 }
 
 type SearchConfig struct {
-	Mode string `yaml:"mode"`
+	Mode      string `yaml:"mode"`
+	IndexPath string `yaml:"indexPath"` // bleve 索引目录
 }
 
 type MailConfig struct {
@@ -71,16 +73,127 @@ type MailConfig struct {
 	From     string `yaml:"from"`
 }
 
+// ArticleConfig 文章配置结构体
+type ArticleConfig struct {
+	RevisionLimit int `yaml:"revisionLimit"` // 每篇文章保留的历史版本数量
+}
+
+// ScheduleConfig 定时任务配置结构体
+type ScheduleConfig struct {
+	PublishInterval int `yaml:"publishInterval"` // 检查定时发布的间隔，单位秒
+}
+
+// FeedConfig 订阅源配置结构体
+type FeedConfig struct {
+	Size          int    `yaml:"size"`          // 订阅源中的文章数量
+	Mode          string `yaml:"mode"`          // full 输出全文，excerpt 输出摘要
+	ExcerptLength int    `yaml:"excerptLength"` // 摘要长度
+	CacheTTL      int    `yaml:"cacheTtl"`      // 缓存时间，单位秒
+}
+
+// SitemapConfig 站点地图配置结构体
+type SitemapConfig struct {
+	PageSize     int      `yaml:"pageSize"`     // 单个站点地图的最大地址数，超过后拆分为站点地图索引
+	CacheTTL     int      `yaml:"cacheTtl"`     // 缓存时间，单位秒
+	ExcludePages []string `yaml:"excludePages"` // 不收录的页面标签
+}
+
+// RobotsRule robots.txt 中针对某个爬虫的规则
+type RobotsRule struct {
+	UserAgent string   `yaml:"userAgent"`
+	Allow     []string `yaml:"allow"`
+	Disallow  []string `yaml:"disallow"`
+}
+
+// RobotsConfig robots.txt 配置结构体
+type RobotsConfig struct {
+	Rules []RobotsRule `yaml:"rules"`
+}
+
+// CommentConfig 评论配置结构体
+type CommentConfig struct {
+	TreeDepth    int `yaml:"treeDepth"`    // 评论树默认展开的回复层级
+	MaxTreeDepth int `yaml:"maxTreeDepth"` // 请求中允许展开的最大回复层级
+	ReplySize    int `yaml:"replySize"`    // 每个分支默认加载的回复数量
+	EditWindow   int `yaml:"editWindow"`   // 发表后允许作者编辑的时间，单位秒，不大于 0 时不允许编辑
+}
+
+// SpamConfig 垃圾内容评分配置结构体
+type SpamConfig struct {
+	ReviewScore     int `yaml:"reviewScore"`     // 达到该分数时转为人工审核
+	RejectScore     int `yaml:"rejectScore"`     // 达到该分数时拒绝提交
+	MaxLinks        int `yaml:"maxLinks"`        // 不计分的链接数量
+	RateWindow      int `yaml:"rateWindow"`      // 统计发布频率的时间窗口，单位秒
+	RateLimit       int `yaml:"rateLimit"`       // 时间窗口内不计分的发布次数
+	RepeatWindow    int `yaml:"repeatWindow"`    // 判断重复内容的时间窗口，单位秒
+	NewAccountHours int `yaml:"newAccountHours"` // 注册时间少于该小时数的账号视为新账号
+	BayesMinSamples int `yaml:"bayesMinSamples"` // 垃圾和正常样本都达到该数量后才启用分类器
+}
+
+// RateLimitRule 单个路由的限流规则
+type RateLimitRule struct {
+	Method string `yaml:"method"` // 请求方法
+	Path   string `yaml:"path"`   // 路由，与注册时的路径一致
+	Limit  int    `yaml:"limit"`  // 时间窗口内允许的请求次数
+	Window int    `yaml:"window"` // 滑动时间窗口，单位秒
+	KeyBy  string `yaml:"keyBy"`  // 限流维度 user 按用户，游客按 IP；ip 按 IP；both 同时按用户和 IP
+}
+
+// RateLimitConfig 限流配置结构体
+type RateLimitConfig struct {
+	Rules []RateLimitRule `yaml:"rules"`
+}
+
+// EmailWorkerConfig 邮件队列消费配置结构体
+type EmailWorkerConfig struct {
+	Enabled     bool `yaml:"enabled"`     // 是否在 Web 服务进程内消费邮件队列
+	Concurrency int  `yaml:"concurrency"` // 同时发送的邮件数量
+	MaxRetries  int  `yaml:"maxRetries"`  // 最大重试次数，超过后移入死信队列
+	RetryDelay  int  `yaml:"retryDelay"`  // 第一次重试的等待时间，之后每次翻倍，单位秒
+}
+
+// JWTKeyConfig JWT 密钥配置结构体
+type JWTKeyConfig struct {
+	ID         string `yaml:"id"`         // 密钥标识，写入 token 的 kid 头
+	Algorithm  string `yaml:"algorithm"`  // 签名算法 HS256、RS256 或 EdDSA
+	Secret     string `yaml:"secret"`     // HS256 使用的密钥，至少 32 字节
+	PrivateKey string `yaml:"privateKey"` // RS256、EdDSA 的 PEM 私钥文件路径，只用于验证的旧密钥可以不配置
+	PublicKey  string `yaml:"publicKey"`  // RS256、EdDSA 的 PEM 公钥文件路径，未配置时从私钥中获取
+}
+
+// JWTSecretEnv 覆盖签发密钥 secret 的环境变量，避免把密钥写入配置文件
+const JWTSecretEnv = "JWT_SECRET"
+
+// JWTConfig JWT 配置结构体
+type JWTConfig struct {
+	Issuer            string         `yaml:"issuer"`            // 签发者
+	Audience          string         `yaml:"audience"`          // 接收方
+	ExpireTime        int            `yaml:"expireTime"`        // 访问令牌有效期，单位秒
+	RefreshExpireTime int            `yaml:"refreshExpireTime"` // 刷新令牌有效期，单位秒，每次刷新后重新计算
+	SigningKey        string         `yaml:"signingKey"`        // 签发新 token 使用的密钥 id，未配置时使用第一个密钥
+	Keys              []JWTKeyConfig `yaml:"keys"`              // 签名和验证密钥，轮换时保留旧密钥直到已签发的 token 过期
+}
+
 // AppConfig 应用程序配置结构体
 type AppConfig struct {
-	Database DatabaseConfig `yaml:"database"`
-	Server   ServerConfig   `yaml:"server"`
-	Redis    RedisConfig    `yaml:"redis"`
-	RabbitMQ RabbitMQConfig `yaml:"rabbitmq"`
-	Website  WebsiteConfig  `yaml:"website"`
-	Upload   *UploadConfig  `yaml:"upload"`
-	Search   SearchConfig   `yaml:"search"`
-	Email    MailConfig     `yaml:"email"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Server      ServerConfig      `yaml:"server"`
+	Redis       RedisConfig       `yaml:"redis"`
+	RabbitMQ    RabbitMQConfig    `yaml:"rabbitmq"`
+	Website     WebsiteConfig     `yaml:"website"`
+	Upload      *UploadConfig     `yaml:"upload"`
+	Search      SearchConfig      `yaml:"search"`
+	Email       MailConfig        `yaml:"email"`
+	Article     ArticleConfig     `yaml:"article"`
+	Schedule    ScheduleConfig    `yaml:"schedule"`
+	Feed        FeedConfig        `yaml:"feed"`
+	Sitemap     SitemapConfig     `yaml:"sitemap"`
+	Robots      RobotsConfig      `yaml:"robots"`
+	Comment     CommentConfig     `yaml:"comment"`
+	Spam        SpamConfig        `yaml:"spam"`
+	RateLimit   RateLimitConfig   `yaml:"rateLimit"`
+	EmailWorker EmailWorkerConfig `yaml:"emailWorker"`
+	JWT         JWTConfig         `yaml:"jwt"`
 }
 
 // LoadConfig 从 YAML 文件加载配置
@@ -97,6 +210,21 @@ func LoadConfig(filename string) (*AppConfig, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode config file: %w", err)
 	}
+	config.JWT.applySecretEnv()
 
 	return &config, nil
 }
+
+// applySecretEnv 设置了 JWT_SECRET 环境变量时用它替换签发密钥的 secret
+func (c *JWTConfig) applySecretEnv() {
+	secret := os.Getenv(JWTSecretEnv)
+	if secret == "" {
+		return
+	}
+	for i := range c.Keys {
+		if c.Keys[i].ID == c.SigningKey || c.SigningKey == "" {
+			c.Keys[i].Secret = secret
+			return
+		}
+	}
+}
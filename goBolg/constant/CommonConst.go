@@ -28,6 +28,9 @@ const (
 	// 默认用户昵称
 	DefaultNickname = "用户"
 
+	// 默认文章分类
+	DefaultCategoryName = "默认分类"
+
 	// 浏览文章集合
 	ArticleSet = "articleSet"
 
@@ -49,6 +52,21 @@ const (
 	// 默认的配置id
 	DefaultConfigID = 1
 
+	// 订阅源输出全文
+	FeedModeFull = "full"
+
+	// 订阅源输出摘要
+	FeedModeExcerpt = "excerpt"
+
+	// 按用户限流，游客按 IP
+	RateLimitKeyUser = "user"
+
+	// 按 IP 限流
+	RateLimitKeyIP = "ip"
+
+	// 同时按用户和 IP 限流
+	RateLimitKeyBoth = "both"
+
 	//
 	UserContextKey ContextKey = "user"
 )
@@ -0,0 +1,27 @@
+package constants
+
+const (
+	// 邮件交换机
+	EmailExchange = "EMAIL_EXCHANGE"
+
+	// 邮件队列
+	EmailQueue = "EMAIL_QUEUE"
+
+	// 邮件重试交换机，路由键为重试等待的毫秒数
+	EmailRetryExchange = "EMAIL_RETRY_EXCHANGE"
+
+	// 邮件重试队列，后接重试等待的毫秒数
+	EmailRetryQueue = "EMAIL_RETRY_QUEUE."
+
+	// 邮件死信交换机
+	EmailDeadExchange = "EMAIL_DEAD_EXCHANGE"
+
+	// 邮件死信队列
+	EmailDeadQueue = "EMAIL_DEAD_QUEUE"
+
+	// 邮件已重试的次数
+	EmailRetryCountHeader = "x-retry-count"
+
+	// 邮件最后一次发送失败的原因
+	EmailErrorHeader = "x-last-error"
+)
@@ -48,4 +48,55 @@ const (
 
 	// 访客
 	UniqueVisitor = "unique_visitor"
+
+	// 订阅源缓存
+	FeedCache = "feed:"
+
+	// 站点地图缓存
+	SitemapCache = "sitemap:"
+
+	// 敏感词变更通知频道
+	SensitiveWordChannel = "sensitive_word:reload"
+
+	// 资源角色变更通知频道
+	ResourceRoleChannel = "resource_role:reload"
+
+	// 垃圾内容分类器的词频，后接分类
+	SpamBayesToken = "spam:bayes:token:"
+
+	// 垃圾内容分类器各分类的样本数量
+	SpamBayesSampleCount = "spam:bayes:sample_count"
+
+	// 已训练的样本及其分类
+	SpamBayesTrained = "spam:bayes:trained"
+
+	// 近期提交的内容摘要
+	SpamContentDigest = "spam:content:"
+
+	// 用户发布频率
+	SpamUserRate = "spam:rate:user:"
+
+	// IP 发布频率
+	SpamIPRate = "spam:rate:ip:"
+
+	// 接口限流的请求记录
+	RateLimit = "rate_limit:"
+
+	// 登录会话及其刷新令牌摘要，后接会话 id
+	TokenSession = "token:session:"
+
+	// 用户的登录会话集合，后接用户 id
+	TokenUserSessions = "token:user_sessions:"
+
+	// 已注销的登录会话，后接会话 id
+	TokenRevokedSession = "token:revoked:"
+
+	// 在线用户，后接会话 id
+	OnlineUser = "online_user:"
+
+	// 在线信息最近一次记录的标记，存在时不再重复记录，后接会话 id
+	OnlineUserRefresh = "online_user_refresh:"
+
+	// 在线用户会话索引，按最近活跃时间排序
+	OnlineUserIndex = "online_user_index"
 )
@@ -16,7 +16,7 @@ import (
 type ArticleController struct {
 	Service               service.ArticleService
 	UploadStrategyContext *contxt.UploadStrategyContext
-	//SearchStrategyContext *context.SearchStrategyContext
+	SearchStrategyContext *contxt.SearchStrategyContext
 }
 
 // ListArchives 查看文章归档
@@ -435,3 +435,214 @@ func (ac *ArticleController) SaveArticleImages(c *gin.Context) {
 	log.Printf("File uploaded successfully to: %s", fileUrl)
 	c.JSON(http.StatusOK, vo.OkWithData(fileUrl))
 }
+
+// ImportArticles 导入 Hexo 文章
+// @Summary 导入文章
+// @Description 导入 Hexo 文章，支持多个 md 文件或 source/_posts 目录的 zip 压缩包
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "md 文件或 zip 压缩包"
+// @Success 200 {object} vo.Result{data=[]dto.ArticleImportDTO}
+// @Security BearerAuth
+// @Router /admin/articles/import [post]
+func (controller *ArticleController) ImportArticles(c *gin.Context) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		log.Printf("Error parsing multipart form: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Failed to get file"))
+		return
+	}
+
+	fileList := form.File["file"]
+	if len(fileList) == 0 {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("File list cannot be empty"))
+		return
+	}
+
+	resultList, err := controller.Service.ImportArticles(c.Request.Context(), fileList)
+	if err != nil {
+		log.Printf("Error importing articles: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to import articles"))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.OkWithData(resultList))
+}
+
+// ExportArticles 导出文章
+// @Summary 导出文章
+// @Description 导出 Hexo 格式的文章 zip 压缩包
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param articleIdList body []int true "文章 ID 列表"
+// @Success 200 {object} vo.Result{data=[]string}
+// @Security BearerAuth
+// @Router /admin/articles/export [post]
+func (controller *ArticleController) ExportArticles(c *gin.Context) {
+	var articleIdList []int
+	if err := c.ShouldBindJSON(&articleIdList); err != nil {
+		log.Printf("Error binding JSON: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request payload"))
+		return
+	}
+
+	if len(articleIdList) == 0 {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Article ID list cannot be empty"))
+		return
+	}
+
+	urlList, err := controller.Service.ExportArticles(c.Request.Context(), articleIdList)
+	if err != nil {
+		log.Printf("Error exporting articles: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to export articles"))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.OkWithData(urlList))
+}
+
+// CancelArticleSchedule 取消文章定时发布
+// @Summary 取消文章定时发布
+// @Description 取消尚未到期的定时发布，文章保留为草稿
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param articleId path int true "文章ID"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/articles/{articleId}/schedule [delete]
+func (controller *ArticleController) CancelArticleSchedule(c *gin.Context) {
+	articleId, err := strconv.Atoi(c.Param("articleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid article ID"))
+		return
+	}
+
+	if err := controller.Service.CancelArticleSchedule(c.Request.Context(), articleId); err != nil {
+		log.Printf("Error canceling article schedule: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// ListArticleRevisions 查看文章历史版本
+// @Summary 查看文章历史版本
+// @Description 按时间倒序查看文章的历史版本
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param articleId path int true "文章ID"
+// @Success 200 {object} vo.Result{data=[]dto.ArticleRevisionDTO}
+// @Security BearerAuth
+// @Router /admin/articles/{articleId}/revisions [get]
+func (controller *ArticleController) ListArticleRevisions(c *gin.Context) {
+	articleId, err := strconv.Atoi(c.Param("articleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid article ID"))
+		return
+	}
+
+	revisionList, err := controller.Service.ListArticleRevisions(c.Request.Context(), articleId)
+	if err != nil {
+		log.Printf("Error listing article revisions: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to list article revisions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.OkWithData(revisionList))
+}
+
+// GetArticleRevisionDiff 比较文章历史版本
+// @Summary 比较文章历史版本
+// @Description 逐行比较文章的两个历史版本
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param articleId path int true "文章ID"
+// @Param oldRevisionId query int true "旧版本ID"
+// @Param newRevisionId query int true "新版本ID"
+// @Success 200 {object} vo.Result{data=dto.ArticleRevisionDiffDTO}
+// @Security BearerAuth
+// @Router /admin/articles/{articleId}/revisions/diff [get]
+func (controller *ArticleController) GetArticleRevisionDiff(c *gin.Context) {
+	articleId, err := strconv.Atoi(c.Param("articleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid article ID"))
+		return
+	}
+	oldRevisionId, err := strconv.Atoi(c.Query("oldRevisionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid old revision ID"))
+		return
+	}
+	newRevisionId, err := strconv.Atoi(c.Query("newRevisionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid new revision ID"))
+		return
+	}
+
+	diff, err := controller.Service.GetArticleRevisionDiff(c.Request.Context(), articleId, oldRevisionId, newRevisionId)
+	if err != nil {
+		log.Printf("Error comparing article revisions: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to compare article revisions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.OkWithData(diff))
+}
+
+// RestoreArticleRevision 恢复文章历史版本
+// @Summary 恢复文章历史版本
+// @Description 将文章恢复到指定历史版本，恢复后生成新的版本
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param articleId path int true "文章ID"
+// @Param revisionId path int true "版本ID"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/articles/{articleId}/revisions/{revisionId}/restore [post]
+func (controller *ArticleController) RestoreArticleRevision(c *gin.Context) {
+	articleId, err := strconv.Atoi(c.Param("articleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid article ID"))
+		return
+	}
+	revisionId, err := strconv.Atoi(c.Param("revisionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid revision ID"))
+		return
+	}
+
+	if err := controller.Service.RestoreArticleRevision(c.Request.Context(), articleId, revisionId); err != nil {
+		log.Printf("Error restoring article revision: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to restore article revision"))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// RebuildSearchIndex 重建文章搜索索引
+// @Summary 重建文章搜索索引
+// @Description 删除现有搜索索引并从数据库重新导入全部文章，仅在使用需要索引的搜索模式时可用
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} vo.Response{data=int}
+// @Security BearerAuth
+// @Router /admin/articles/search/index [post]
+func (controller *ArticleController) RebuildSearchIndex(c *gin.Context) {
+	count, err := controller.Service.RebuildSearchIndex(c.Request.Context())
+	if err != nil {
+		log.Printf("Error rebuilding search index: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to rebuild search index"))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.OkWithData(count))
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"goBolg/exception"
 	"goBolg/service"
 	"goBolg/utils"
 	"goBolg/vo"
@@ -45,6 +46,10 @@ func (h *CommentController) ListComments(c *gin.Context) {
 
 	comments, total, err := h.CommentService.ListComments(c.Request.Context(), commentVO, current, size)
 	if err != nil {
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve comments"))
 		return
 	}
@@ -59,14 +64,60 @@ func (h *CommentController) ListComments(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// ListCommentTree 处理游标分页查询评论树的HTTP请求
+// @Summary 查询评论树
+// @Description 按游标分页查询顶层评论，每条评论下按层级展开回复，每个分支返回加载更多回复的游标
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param topicId query int false "评论主题ID"
+// @Param type query int true "评论类型"
+// @Param cursor query string false "上一页返回的游标"
+// @Param size query int false "每页数量"
+// @Param depth query int false "展开的回复层级"
+// @Success 200 {object} vo.Response{data=dto.CommentTreePageDTO}
+// @Router /comments/tree [get]
+func (h *CommentController) ListCommentTree(c *gin.Context) {
+	topicID, _ := strconv.Atoi(c.Query("topicId"))
+	commentType, _ := strconv.Atoi(c.Query("type"))
+	size, _ := strconv.Atoi(c.Query("size"))
+	depth, _ := strconv.Atoi(c.Query("depth"))
+	cursor, err := utils.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid cursor"))
+		return
+	}
+
+	commentVO := vo.CommentVO{
+		TopicID: topicID,
+		Type:    commentType,
+	}
+
+	page, err := h.CommentService.ListCommentTree(c.Request.Context(), commentVO, cursor, size, depth)
+	if err != nil {
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		log.Printf("Failed to retrieve comment tree: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve comments"))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.OkWithData(page))
+}
+
 // ListRepliesByCommentId 处理查询评论下回复的HTTP请求
 // @Summary 查询评论下的回复
-// @Description 获取指定评论的回复列表
+// @Description 按游标分页查询指定评论的直接回复，每条回复下按层级展开
 // @Tags comments
 // @Accept json
 // @Produce json
 // @Param commentId path int true "评论ID"
-// @Success 200 {object} vo.Response{data=[]dto.ReplyDTO}
+// @Param cursor query string false "上一页返回的游标"
+// @Param size query int false "每页数量"
+// @Param depth query int false "展开的回复层级，直接回复为第 1 层"
+// @Success 200 {object} vo.Response{data=dto.CommentTreePageDTO}
 // @Router /comments/{commentId}/replies [get]
 func (h *CommentController) ListRepliesByCommentId(c *gin.Context) {
 	commentId, err := strconv.Atoi(c.Param("commentId"))
@@ -74,24 +125,29 @@ func (h *CommentController) ListRepliesByCommentId(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid comment ID"))
 		return
 	}
+	size, _ := strconv.Atoi(c.Query("size"))
+	depth, _ := strconv.Atoi(c.Query("depth"))
+	cursor, err := utils.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid cursor"))
+		return
+	}
 
 	// Log the comment ID
 	log.Printf("Fetching replies for comment ID: %d", commentId)
 
-	replies, err := h.CommentService.ListRepliesByCommentId(c.Request.Context(), commentId)
+	page, err := h.CommentService.ListRepliesByCommentId(c.Request.Context(), commentId, cursor, size, depth)
 	if err != nil {
 		log.Printf("Failed to retrieve replies for comment ID: %d, error: %v", commentId, err)
 		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve replies"))
 		return
 	}
-
-	if len(replies) == 0 {
-		log.Printf("No replies found for comment ID: %d", commentId)
+	if page == nil {
+		c.JSON(http.StatusNotFound, vo.FailWithMessage("Comment not found"))
+		return
 	}
 
-	log.Printf("Replies fetched for comment ID %d: %v", commentId, replies)
-	result := vo.OkWithData(replies)
-	c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusOK, vo.OkWithData(page))
 }
 
 // SaveComment 添加评论
@@ -126,6 +182,10 @@ func (h *CommentController) SaveComment(c *gin.Context) {
 	savedComment, err := h.CommentService.SaveComment(c.Request.Context(), commentVO)
 	if err != nil {
 		log.Printf("Failed to save comment: %v", err)
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to save comment"))
 		return
 	}
@@ -145,6 +205,76 @@ func (h *CommentController) SaveComment(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// UpdateComment 编辑自己的评论
+// @Summary 编辑评论
+// @Description 作者在发表后的可编辑时间内修改评论，修改前的内容保存为编辑历史，网站开启评论审核时重新进入审核
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param commentId path int true "评论ID"
+// @Param comment body vo.CommentEditVO true "评论内容"
+// @Success 200 {object} vo.Response{data=dto.CommentDTO}
+// @Security BearerAuth
+// @Router /comments/{commentId} [put]
+func (h *CommentController) UpdateComment(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid comment ID"))
+		return
+	}
+	var commentEditVO vo.CommentEditVO
+	if err := c.ShouldBindJSON(&commentEditVO); err != nil {
+		log.Printf("Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request payload"))
+		return
+	}
+	if err := vo.ValidateCommentEditVO(commentEditVO); err != nil {
+		log.Printf("Validation failed: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Validation failed"))
+		return
+	}
+
+	comment, err := h.CommentService.UpdateComment(c.Request.Context(), commentID, commentEditVO)
+	if err != nil {
+		log.Printf("Failed to update comment %d: %v", commentID, err)
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to update comment"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(comment))
+}
+
+// RemoveOwnComment 删除自己的评论
+// @Summary 删除自己的评论
+// @Description 作者随时可以删除自己的评论，删除后不再在前台展示，管理员仍可在后台查看
+// @Tags comments
+// @Produce json
+// @Param commentId path int true "评论ID"
+// @Success 200 {object} vo.Response
+// @Security BearerAuth
+// @Router /comments/{commentId} [delete]
+func (h *CommentController) RemoveOwnComment(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid comment ID"))
+		return
+	}
+
+	if err := h.CommentService.RemoveOwnComment(c.Request.Context(), commentID); err != nil {
+		log.Printf("Failed to remove comment %d: %v", commentID, err)
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to remove comment"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
 // SaveCommentLike 处理评论点赞的HTTP请求
 // @Summary Save comment like
 // @Description Like a comment
@@ -201,7 +331,7 @@ func (h *CommentController) UpdateCommentsReview(c *gin.Context) {
 
 // ListCommentBackDTO 处理查询后台评论的HTTP请求
 // @Summary List back comments
-// @Description Get all back comments
+// @Description Get all back comments, type 为评论类型 1.文章 2.友链 3.说说
 // @Tags comments
 // @Accept json
 // @Produce json
@@ -216,9 +346,15 @@ func (h *CommentController) ListCommentBackDTO(c *gin.Context) {
 		return
 	}
 
-	// 设置默认分页参数
-	ctx := c.Request.Context()
-	ctx = utils.SetCurrentPage(ctx, &utils.Page{Current: 1, Size: 10})
+	// 设置分页参数，未传入时使用默认值
+	page := &utils.Page{Current: 1, Size: 10}
+	if condition.Current != nil && *condition.Current > 0 {
+		page.Current = *condition.Current
+	}
+	if condition.Size != nil && *condition.Size > 0 {
+		page.Size = *condition.Size
+	}
+	ctx := utils.SetCurrentPage(c.Request.Context(), page)
 
 	// 调用服务层方法获取评论数据
 	pageResult, err := h.CommentService.ListCommentBackDTO(ctx, condition)
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/service"
+	"goBolg/vo"
+	"log"
+	"net/http"
+)
+
+// EmailQueueController 邮件队列控制器
+type EmailQueueController struct {
+	EmailQueueService service.EmailQueueService
+}
+
+// ListDeadLetters 查看发送失败的邮件
+// @Summary 查看发送失败的邮件
+// @Description 分页查看超过重试次数或无法解析而移入死信队列的邮件
+// @Tags admin
+// @Produce json
+// @Param page query int false "当前页码"
+// @Param size query int false "每页数量"
+// @Success 200 {object} vo.Response{data=vo.PageResult{recordList=[]dto.EmailDeadLetterDTO}}
+// @Security BearerAuth
+// @Router /admin/emails/dead-letters [get]
+func (controller *EmailQueueController) ListDeadLetters(c *gin.Context) {
+	result, err := controller.EmailQueueService.ListDeadLetters(c.Request.Context())
+	if err != nil {
+		log.Printf("Error listing email dead letters: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve email dead letters"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(result))
+}
+
+// RetryDeadLetters 重新发送失败的邮件
+// @Summary 重新发送失败的邮件
+// @Description 将死信队列中的邮件重新投递到邮件队列，重试次数重新计算，返回投递的数量
+// @Tags admin
+// @Produce json
+// @Success 200 {object} vo.Response{data=int}
+// @Security BearerAuth
+// @Router /admin/emails/dead-letters/retry [post]
+func (controller *EmailQueueController) RetryDeadLetters(c *gin.Context) {
+	count, err := controller.EmailQueueService.RetryDeadLetters(c.Request.Context())
+	if err != nil {
+		log.Printf("Error retrying email dead letters: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retry email dead letters"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(count))
+}
+
+// PurgeDeadLetters 清空失败的邮件
+// @Summary 清空失败的邮件
+// @Description 删除死信队列中的全部邮件，返回删除的数量
+// @Tags admin
+// @Produce json
+// @Success 200 {object} vo.Response{data=int}
+// @Security BearerAuth
+// @Router /admin/emails/dead-letters [delete]
+func (controller *EmailQueueController) PurgeDeadLetters(c *gin.Context) {
+	count, err := controller.EmailQueueService.PurgeDeadLetters(c.Request.Context())
+	if err != nil {
+		log.Printf("Error purging email dead letters: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to purge email dead letters"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(count))
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/exception"
+	"goBolg/service"
+	"goBolg/vo"
+	"log"
+	"net/http"
+)
+
+// EmailTemplateController 邮件模板控制器
+type EmailTemplateController struct {
+	EmailTemplateService service.EmailTemplateService
+}
+
+// ListEmailTemplates 查看邮件模板列表
+// @Summary 查看邮件模板列表
+// @Description 获取全部邮件模板，存在自定义模板时返回自定义模板
+// @Tags admin
+// @Produce json
+// @Success 200 {object} vo.Response{data=[]dto.EmailTemplateDTO}
+// @Security BearerAuth
+// @Router /admin/email-templates [get]
+func (controller *EmailTemplateController) ListEmailTemplates(c *gin.Context) {
+	emailTemplates, err := controller.EmailTemplateService.ListEmailTemplates(c.Request.Context())
+	if err != nil {
+		log.Printf("Error listing email templates: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve email templates"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(emailTemplates))
+}
+
+// SaveEmailTemplate 保存自定义邮件模板
+// @Summary 保存自定义邮件模板
+// @Description 使用自定义模板覆盖内置模板，模板中通过 .Site 访问站点信息，通过 .Data 访问模板变量
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param emailTemplate body vo.EmailTemplateVO true "EmailTemplateVO"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/email-templates [post]
+func (controller *EmailTemplateController) SaveEmailTemplate(c *gin.Context) {
+	var emailTemplateVO vo.EmailTemplateVO
+	if err := c.ShouldBindJSON(&emailTemplateVO); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+	if err := vo.ValidateEmailTemplateVO(emailTemplateVO); err != nil {
+		log.Printf("Validation failed: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Validation failed"))
+		return
+	}
+
+	if err := controller.EmailTemplateService.SaveEmailTemplate(c.Request.Context(), emailTemplateVO); err != nil {
+		log.Printf("Error saving email template: %v", err)
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to save email template"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// DeleteEmailTemplate 恢复内置邮件模板
+// @Summary 恢复内置邮件模板
+// @Description 删除自定义模板，恢复使用内置模板
+// @Tags admin
+// @Produce json
+// @Param name path string true "模板名称"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/email-templates/{name} [delete]
+func (controller *EmailTemplateController) DeleteEmailTemplate(c *gin.Context) {
+	if err := controller.EmailTemplateService.DeleteEmailTemplate(c.Request.Context(), c.Param("name")); err != nil {
+		log.Printf("Error deleting email template: %v", err)
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to delete email template"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
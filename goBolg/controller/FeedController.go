@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/enums"
+	"goBolg/service"
+	"goBolg/vo"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeedController 订阅源控制器
+type FeedController struct {
+	FeedService service.FeedService
+	CacheTTL    int // 客户端缓存时间，单位秒
+}
+
+// RSS 获取 RSS 2.0 订阅源
+// @Summary RSS 订阅源
+// @Description 获取全站、分类或标签的 RSS 2.0 订阅源，mode 可选 full 或 excerpt
+// @Tags feeds
+// @Produce xml
+// @Param categoryId path int false "分类ID"
+// @Param tagId path int false "标签ID"
+// @Param mode query string false "full 或 excerpt"
+// @Success 200 {string} string
+// @Router /rss.xml [get]
+func (controller *FeedController) RSS(c *gin.Context) {
+	controller.writeFeed(c, enums.RSS)
+}
+
+// Atom 获取 Atom 订阅源
+// @Summary Atom 订阅源
+// @Description 获取全站、分类或标签的 Atom 订阅源，mode 可选 full 或 excerpt
+// @Tags feeds
+// @Produce xml
+// @Param categoryId path int false "分类ID"
+// @Param tagId path int false "标签ID"
+// @Param mode query string false "full 或 excerpt"
+// @Success 200 {string} string
+// @Router /atom.xml [get]
+func (controller *FeedController) Atom(c *gin.Context) {
+	controller.writeFeed(c, enums.ATOM)
+}
+
+// JSONFeed 获取 JSON Feed 订阅源
+// @Summary JSON Feed 订阅源
+// @Description 获取全站、分类或标签的 JSON Feed 订阅源，mode 可选 full 或 excerpt
+// @Tags feeds
+// @Produce json
+// @Param categoryId path int false "分类ID"
+// @Param tagId path int false "标签ID"
+// @Param mode query string false "full 或 excerpt"
+// @Success 200 {string} string
+// @Router /feed.json [get]
+func (controller *FeedController) JSONFeed(c *gin.Context) {
+	controller.writeFeed(c, enums.JSON_FEED)
+}
+
+// writeFeed 输出订阅源，内容未变化时返回 304
+func (controller *FeedController) writeFeed(c *gin.Context, feedType enums.FeedTypeEnum) {
+	var condition vo.ConditionVO
+	if categoryIdStr := c.Param("categoryId"); categoryIdStr != "" {
+		categoryId, err := strconv.Atoi(categoryIdStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid category ID"))
+			return
+		}
+		condition.CategoryID = &categoryId
+	}
+	if tagIdStr := c.Param("tagId"); tagIdStr != "" {
+		tagId, err := strconv.Atoi(tagIdStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid tag ID"))
+			return
+		}
+		condition.TagID = &tagId
+	}
+
+	feed, err := controller.FeedService.GetFeed(c.Request.Context(), feedType, condition, c.Query("mode"))
+	if err != nil {
+		log.Printf("Error building %s feed: %v", feedType.Type, err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to build feed"))
+		return
+	}
+	if feed == nil {
+		c.JSON(http.StatusNotFound, vo.FailWithMessage("Feed not found"))
+		return
+	}
+
+	c.Header("ETag", feed.ETag)
+	c.Header("Last-Modified", feed.LastModified.Format(http.TimeFormat))
+	if controller.CacheTTL > 0 {
+		c.Header("Cache-Control", "public, max-age="+strconv.Itoa(controller.CacheTTL))
+	}
+	if notModified(c, feed.ETag, feed.LastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, feed.ContentType, []byte(feed.Content))
+}
+
+// notModified 根据 If-None-Match 和 If-Modified-Since 判断内容是否变化，优先使用 ETag
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if ifModifiedSince := c.GetHeader("If-Modified-Since"); ifModifiedSince != "" {
+		if since, err := http.ParseTime(ifModifiedSince); err == nil {
+			return !lastModified.After(since)
+		}
+	}
+	return false
+}
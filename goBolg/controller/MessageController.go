@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"goBolg/exception"
 	"goBolg/service"
 	"goBolg/vo"
 	"net/http"
@@ -21,6 +22,10 @@ func (h *MessageController) SaveMessage(c *gin.Context) {
 	}
 
 	if err := h.MessageService.SaveMessage(c.Request.Context(), messageVO); err != nil {
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to save message"))
 		return
 	}
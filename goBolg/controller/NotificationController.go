@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/exception"
+	"goBolg/service"
+	"goBolg/vo"
+	"log"
+	"net/http"
+)
+
+// NotificationController 通知控制器
+type NotificationController struct {
+	NotificationService service.NotificationService
+}
+
+// ListNotifications 查看当前用户的通知
+// @Summary 查看当前用户的通知
+// @Description 分页获取当前用户的通知，type 为通知类型 1.评论回复 2.评论点赞 3.文章点赞 4.说说点赞 5.评论审核结果
+// @Tags notification
+// @Produce json
+// @Param page query int false "页码"
+// @Param size query int false "每页数量"
+// @Param type query int false "通知类型"
+// @Param isRead query int false "是否已读"
+// @Success 200 {object} vo.Response{data=vo.PageResult{recordList=[]dto.NotificationDTO}}
+// @Security BearerAuth
+// @Router /notifications [get]
+func (controller *NotificationController) ListNotifications(c *gin.Context) {
+	var condition vo.ConditionVO
+	if err := c.ShouldBindQuery(&condition); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid query parameters"))
+		return
+	}
+
+	result, err := controller.NotificationService.ListNotifications(c.Request.Context(), condition)
+	if err != nil {
+		log.Printf("Error listing notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve notifications"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(result))
+}
+
+// CountUnreadNotifications 查看未读通知数量
+// @Summary 查看未读通知数量
+// @Description 获取当前用户的未读通知数量
+// @Tags notification
+// @Produce json
+// @Success 200 {object} vo.Response{data=int}
+// @Security BearerAuth
+// @Router /notifications/unread-count [get]
+func (controller *NotificationController) CountUnreadNotifications(c *gin.Context) {
+	count, err := controller.NotificationService.CountUnreadNotifications(c.Request.Context())
+	if err != nil {
+		log.Printf("Error counting unread notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to count unread notifications"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(count))
+}
+
+// ReadNotifications 标记通知为已读
+// @Summary 标记通知为已读
+// @Description 将当前用户的指定通知标记为已读
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param ids body []int true "通知ID列表"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /notifications/read [put]
+func (controller *NotificationController) ReadNotifications(c *gin.Context) {
+	var notificationIdList []int
+	if err := c.ShouldBindJSON(&notificationIdList); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+
+	if err := controller.NotificationService.ReadNotifications(c.Request.Context(), notificationIdList); err != nil {
+		log.Printf("Error reading notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to read notifications"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// ReadAllNotifications 标记全部通知为已读
+// @Summary 标记全部通知为已读
+// @Description 将当前用户的全部通知标记为已读
+// @Tags notification
+// @Produce json
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /notifications/read-all [put]
+func (controller *NotificationController) ReadAllNotifications(c *gin.Context) {
+	if err := controller.NotificationService.ReadAllNotifications(c.Request.Context()); err != nil {
+		log.Printf("Error reading all notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to read notifications"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// ListNotificationSettings 查看通知设置
+// @Summary 查看通知设置
+// @Description 获取当前用户每类通知的接收方式，未设置时返回默认接收方式
+// @Tags notification
+// @Produce json
+// @Success 200 {object} vo.Response{data=[]dto.NotificationSettingDTO}
+// @Security BearerAuth
+// @Router /users/notification-settings [get]
+func (controller *NotificationController) ListNotificationSettings(c *gin.Context) {
+	settings, err := controller.NotificationService.ListNotificationSettings(c.Request.Context())
+	if err != nil {
+		log.Printf("Error listing notification settings: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve notification settings"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(settings))
+}
+
+// SaveNotificationSettings 保存通知设置
+// @Summary 保存通知设置
+// @Description 设置每类通知是否发送邮件和站内通知，邮件只在网站开启邮箱通知时发送
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param settings body []vo.NotificationSettingVO true "通知设置列表"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /users/notification-settings [put]
+func (controller *NotificationController) SaveNotificationSettings(c *gin.Context) {
+	var notificationSettingVOList []vo.NotificationSettingVO
+	if err := c.ShouldBindJSON(&notificationSettingVOList); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+	for _, notificationSettingVO := range notificationSettingVOList {
+		if err := vo.ValidateNotificationSettingVO(notificationSettingVO); err != nil {
+			log.Printf("Validation failed: %v", err)
+			c.JSON(http.StatusBadRequest, vo.FailWithMessage("Validation failed"))
+			return
+		}
+	}
+
+	if err := controller.NotificationService.SaveNotificationSettings(c.Request.Context(), notificationSettingVOList); err != nil {
+		log.Printf("Error saving notification settings: %v", err)
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to save notification settings"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/enums"
+	"goBolg/exception"
+	"goBolg/service"
+	"goBolg/vo"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// SensitiveWordController 敏感词控制器
+type SensitiveWordController struct {
+	SensitiveWordService service.SensitiveWordService
+}
+
+// ListBackSensitiveWords 查看后台敏感词列表
+// @Summary 查看后台敏感词列表
+// @Description 分页获取敏感词，type 为处理方式 1.替换 2.拒绝 3.审核
+// @Tags admin
+// @Produce json
+// @Param keywords query string false "Keywords"
+// @Param type query int false "处理方式"
+// @Success 200 {object} vo.Response{data=vo.PageResult{recordList=[]dto.SensitiveWordDTO}}
+// @Security BearerAuth
+// @Router /admin/sensitive-words [get]
+func (controller *SensitiveWordController) ListBackSensitiveWords(c *gin.Context) {
+	var condition vo.ConditionVO
+	if err := c.ShouldBindQuery(&condition); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid query parameters"))
+		return
+	}
+
+	result, err := controller.SensitiveWordService.ListBackSensitiveWords(c.Request.Context(), condition)
+	if err != nil {
+		log.Printf("Error listing sensitive words: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve sensitive words"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(result))
+}
+
+// SaveOrUpdateSensitiveWord 保存或更新敏感词
+// @Summary 保存或更新敏感词
+// @Description 保存或更新敏感词，所有实例的词库会重新加载
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param sensitiveWord body vo.SensitiveWordVO true "SensitiveWordVO"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/sensitive-words [post]
+func (controller *SensitiveWordController) SaveOrUpdateSensitiveWord(c *gin.Context) {
+	var sensitiveWordVO vo.SensitiveWordVO
+	if err := c.ShouldBindJSON(&sensitiveWordVO); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+	if err := vo.ValidateSensitiveWordVO(sensitiveWordVO); err != nil {
+		log.Printf("Validation failed: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Validation failed"))
+		return
+	}
+
+	if err := controller.SensitiveWordService.SaveOrUpdateSensitiveWord(c.Request.Context(), sensitiveWordVO); err != nil {
+		log.Printf("Error saving sensitive word: %v", err)
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to save sensitive word"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// DeleteSensitiveWords 删除敏感词
+// @Summary 删除敏感词
+// @Description 批量删除敏感词，所有实例的词库会重新加载
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param ids body []int true "敏感词ID列表"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/sensitive-words [delete]
+func (controller *SensitiveWordController) DeleteSensitiveWords(c *gin.Context) {
+	var sensitiveWordIdList []int
+	if err := c.ShouldBindJSON(&sensitiveWordIdList); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+
+	if err := controller.SensitiveWordService.DeleteSensitiveWords(c.Request.Context(), sensitiveWordIdList); err != nil {
+		log.Printf("Error deleting sensitive words: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to delete sensitive words"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// ImportSensitiveWords 导入敏感词
+// @Summary 导入敏感词
+// @Description 上传文本文件批量导入敏感词，每行一个，已存在的敏感词更新为指定的处理方式
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "敏感词文件"
+// @Param action formData int false "处理方式，默认替换"
+// @Success 200 {object} vo.Response{data=dto.SensitiveWordImportDTO}
+// @Security BearerAuth
+// @Router /admin/sensitive-words/import [post]
+func (controller *SensitiveWordController) ImportSensitiveWords(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid file"))
+		return
+	}
+	action := enums.MASK.Action
+	if value := c.PostForm("action"); value != "" {
+		if action, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid action"))
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("Error opening sensitive word file: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid file"))
+		return
+	}
+	defer file.Close()
+
+	result, err := controller.SensitiveWordService.ImportSensitiveWords(c.Request.Context(), file, action)
+	if err != nil {
+		log.Printf("Error importing sensitive words: %v", err)
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to import sensitive words"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(result))
+}
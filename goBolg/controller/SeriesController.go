@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/service"
+	"goBolg/vo"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// SeriesController 文章系列控制器
+type SeriesController struct {
+	SeriesService service.SeriesService
+}
+
+// ListSeries 查看系列列表
+// @Summary 查看系列列表
+// @Description 获取所有系列及其中按顺序排列的公开文章
+// @Tags series
+// @Produce json
+// @Success 200 {object} vo.Response{data=[]dto.SeriesDTO}
+// @Router /series [get]
+func (controller *SeriesController) ListSeries(c *gin.Context) {
+	seriesList, err := controller.SeriesService.ListSeries(c.Request.Context())
+	if err != nil {
+		log.Printf("Error listing series: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve series"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(seriesList))
+}
+
+// ListBackSeries 查看后台系列列表
+// @Summary 查看后台系列列表
+// @Description 分页获取后台系列列表
+// @Tags admin
+// @Produce json
+// @Param keywords query string false "Keywords"
+// @Success 200 {object} vo.Response{data=vo.PageResult{recordList=[]dto.SeriesBackDTO}}
+// @Security BearerAuth
+// @Router /admin/series [get]
+func (controller *SeriesController) ListBackSeries(c *gin.Context) {
+	var condition vo.ConditionVO
+	if err := c.ShouldBindQuery(&condition); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid query parameters"))
+		return
+	}
+
+	result, err := controller.SeriesService.ListBackSeries(c.Request.Context(), condition)
+	if err != nil {
+		log.Printf("Error listing back series: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve series"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(result))
+}
+
+// GetBackSeriesById 查看后台系列详情
+// @Summary 查看后台系列详情
+// @Description 根据系列ID获取系列及其中的全部文章
+// @Tags admin
+// @Produce json
+// @Param seriesId path int true "系列ID"
+// @Success 200 {object} vo.Response{data=dto.SeriesBackDTO}
+// @Security BearerAuth
+// @Router /admin/series/{seriesId} [get]
+func (controller *SeriesController) GetBackSeriesById(c *gin.Context) {
+	seriesId, err := strconv.Atoi(c.Param("seriesId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid series ID"))
+		return
+	}
+
+	series, err := controller.SeriesService.GetBackSeriesById(c.Request.Context(), seriesId)
+	if err != nil {
+		log.Printf("Error getting series %d: %v", seriesId, err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve series"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(series))
+}
+
+// SaveOrUpdateSeries 保存或更新系列
+// @Summary 保存或更新系列
+// @Description 保存或更新系列信息，articleIdList 的顺序即文章在系列中的顺序
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param series body vo.SeriesVO true "SeriesVO"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/series [post]
+func (controller *SeriesController) SaveOrUpdateSeries(c *gin.Context) {
+	var seriesVO vo.SeriesVO
+	if err := c.ShouldBindJSON(&seriesVO); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+	if err := vo.ValidateSeriesVO(seriesVO); err != nil {
+		log.Printf("Validation failed: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Validation failed"))
+		return
+	}
+
+	if err := controller.SeriesService.SaveOrUpdateSeries(c.Request.Context(), seriesVO); err != nil {
+		log.Printf("Error saving series: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to save series"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// DeleteSeries 删除系列
+// @Summary 删除系列
+// @Description 删除系列，系列中的文章不受影响
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param ids body []int true "系列ID列表"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/series [delete]
+func (controller *SeriesController) DeleteSeries(c *gin.Context) {
+	var seriesIdList []int
+	if err := c.ShouldBindJSON(&seriesIdList); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+
+	if err := controller.SeriesService.DeleteSeries(c.Request.Context(), seriesIdList); err != nil {
+		log.Printf("Error deleting series: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to delete series"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
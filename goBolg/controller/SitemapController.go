@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/dto"
+	"goBolg/service"
+	"goBolg/vo"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// sitemapContentType 站点地图的内容类型
+const sitemapContentType = "application/xml; charset=utf-8"
+
+// SitemapController 站点地图控制器
+type SitemapController struct {
+	SitemapService service.SitemapService
+	CacheTTL       int // 客户端缓存时间，单位秒
+}
+
+// Sitemap 获取站点地图
+// @Summary 站点地图
+// @Description 获取站点地图，收录公开的文章、分类、标签、说说、相册和页面，地址较多时返回站点地图索引
+// @Tags sitemap
+// @Produce xml
+// @Success 200 {string} string
+// @Router /sitemap.xml [get]
+func (controller *SitemapController) Sitemap(c *gin.Context) {
+	sitemap, err := controller.SitemapService.GetSitemap(c.Request.Context())
+	controller.writeSitemap(c, sitemap, err)
+}
+
+// SitemapPage 获取站点地图索引中的子站点地图
+// @Summary 子站点地图
+// @Description 获取站点地图索引中的第 page 个子站点地图
+// @Tags sitemap
+// @Produce xml
+// @Param page path string true "页码，如 1.xml"
+// @Success 200 {string} string
+// @Router /sitemaps/{page} [get]
+func (controller *SitemapController) SitemapPage(c *gin.Context) {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid sitemap page"))
+		return
+	}
+	sitemap, err := controller.SitemapService.GetSitemapPage(c.Request.Context(), page)
+	controller.writeSitemap(c, sitemap, err)
+}
+
+// Robots 获取 robots.txt
+// @Summary robots.txt
+// @Description 获取按配置生成的 robots.txt，包含站点地图地址
+// @Tags sitemap
+// @Produce plain
+// @Success 200 {string} string
+// @Router /robots.txt [get]
+func (controller *SitemapController) Robots(c *gin.Context) {
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(controller.SitemapService.GetRobots()))
+}
+
+// writeSitemap 输出站点地图，内容未变化时返回 304
+func (controller *SitemapController) writeSitemap(c *gin.Context, sitemap *dto.SitemapDTO, err error) {
+	if err != nil {
+		log.Printf("Error building sitemap: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to build sitemap"))
+		return
+	}
+	if sitemap == nil {
+		c.JSON(http.StatusNotFound, vo.FailWithMessage("Sitemap not found"))
+		return
+	}
+
+	c.Header("ETag", sitemap.ETag)
+	c.Header("Last-Modified", sitemap.LastModified.Format(http.TimeFormat))
+	if controller.CacheTTL > 0 {
+		c.Header("Cache-Control", "public, max-age="+strconv.Itoa(controller.CacheTTL))
+	}
+	if notModified(c, sitemap.ETag, sitemap.LastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, sitemapContentType, []byte(sitemap.Content))
+}
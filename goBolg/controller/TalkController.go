@@ -110,3 +110,58 @@ func (c *TalkController) SaveTalkImages(ctx *gin.Context) {
 	log.Printf("File uploaded successfully: %s", fileUrl)
 	ctx.JSON(http.StatusOK, gin.H{"fileUrl": fileUrl})
 }
+
+// ListBackTalks 查看后台说说
+func (tc *TalkController) ListBackTalks(c *gin.Context) {
+	var condition vo.ConditionVO
+	if err := c.ShouldBindQuery(&condition); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid query parameters"))
+		return
+	}
+
+	pageResult, err := tc.TalkService.ListBackTalks(c.Request.Context(), condition)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.FailWithData(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.OkWithData(pageResult))
+}
+
+// SaveOrUpdateTalk 保存或修改说说，publishTime 在未来时定时发布
+func (tc *TalkController) SaveOrUpdateTalk(c *gin.Context) {
+	var talkVO vo.TalkVO
+	if err := c.ShouldBindJSON(&talkVO); err != nil {
+		log.Printf("Error binding JSON: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request payload"))
+		return
+	}
+	if err := vo.ValidateTalkVO(talkVO); err != nil {
+		log.Printf("Validation failed: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Validation failed"))
+		return
+	}
+
+	if err := tc.TalkService.SaveOrUpdateTalk(c.Request.Context(), talkVO); err != nil {
+		c.JSON(http.StatusInternalServerError, vo.FailWithData(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// CancelTalkSchedule 取消说说定时发布
+func (tc *TalkController) CancelTalkSchedule(c *gin.Context) {
+	talkId, err := strconv.Atoi(c.Param("talkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid talk ID"))
+		return
+	}
+
+	if err := tc.TalkService.CancelTalkSchedule(c.Request.Context(), talkId); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithData(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Ok())
+}
@@ -86,3 +86,31 @@ func (controller *UserAuthController) ListUserAreas(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"flag": true, "data": userAreas})
 }
+
+// ListUsers 查看后台用户列表
+// @Summary 查看后台用户列表
+// @Description 分页获取后台用户列表，可按登录方式、昵称关键字和注册时间筛选
+// @Tags admin
+// @Produce json
+// @Param loginType query string false "登录方式"
+// @Param keywords query string false "昵称关键字"
+// @Param startTime query string false "注册时间起始，RFC3339 格式"
+// @Param endTime query string false "注册时间截止，RFC3339 格式"
+// @Success 200 {object} vo.Response{data=vo.PageResult{recordList=[]dto.UserBackDTO}}
+// @Security BearerAuth
+// @Router /admin/users [get]
+func (controller *UserAuthController) ListUsers(c *gin.Context) {
+	var condition vo.ConditionVO
+	if err := c.ShouldBindQuery(&condition); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid query parameters"))
+		return
+	}
+
+	result, err := controller.UserAuthService.ListUserBacks(c.Request.Context(), condition)
+	if err != nil {
+		log.Printf("Error listing users: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve users"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(result))
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"github.com/gin-gonic/gin"
+	"goBolg/exception"
 	"goBolg/service"
 	"goBolg/utils"
 	"goBolg/vo"
@@ -25,6 +26,10 @@ func (c *UserInfoController) UpdateUserInfo(ctx *gin.Context) {
 
 	// 更新用户信息
 	if err := c.UserInfoService.UpdateUserInfo(ctx.Request.Context(), &userInfoVO); err != nil {
+		if bizErr, ok := err.(*exception.BizError); ok {
+			ctx.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user info: " + err.Error()})
 		return
 	}
@@ -81,3 +86,67 @@ func (controller *UserInfoController) SaveUserEmail(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "success"})
 }
+
+// UpdateUserDisable 修改用户禁用状态
+// @Summary 修改用户禁用状态
+// @Description 禁用或启用用户，禁用后该用户无法登录，已登录的会话立即失效
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user body vo.UserDisableVO true "UserDisableVO"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/users/disable [put]
+func (controller *UserInfoController) UpdateUserDisable(c *gin.Context) {
+	var userDisableVO vo.UserDisableVO
+	if err := c.ShouldBindJSON(&userDisableVO); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+	if err := vo.ValidateUserDisableVO(userDisableVO); err != nil {
+		log.Printf("Validation failed: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Validation failed"))
+		return
+	}
+
+	if err := controller.UserInfoService.UpdateUserDisable(c.Request.Context(), userDisableVO); err != nil {
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusBadRequest, vo.FailWithCodeAndMessage(bizErr.Code, bizErr.Message))
+			return
+		}
+		log.Printf("Error updating user disable: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to update user disable"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// UpdateUserRole 修改用户角色
+// @Summary 修改用户角色
+// @Description 修改用户昵称和角色，roleIdList 为用户的全部角色
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user body vo.UserRoleVO true "UserRoleVO"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/users/role [put]
+func (controller *UserInfoController) UpdateUserRole(c *gin.Context) {
+	var userRoleVO vo.UserRoleVO
+	if err := c.ShouldBindJSON(&userRoleVO); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid request parameters"))
+		return
+	}
+	if err := vo.ValidateUserRoleVO(userRoleVO); err != nil {
+		log.Printf("Validation failed: %v", err)
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Validation failed"))
+		return
+	}
+
+	if err := controller.UserInfoService.UpdateUserRole(c.Request.Context(), userRoleVO); err != nil {
+		log.Printf("Error updating user role: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to update user role"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/service"
+	"goBolg/utils"
+	"goBolg/vo"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// UserSessionController 用户登录会话控制器
+type UserSessionController struct {
+	TokenService      service.TokenService
+	OnlineUserService service.OnlineUserService
+}
+
+// RemoveOwnSessions 退出全部设备
+// @Summary 退出全部设备
+// @Description 注销当前用户的全部登录会话，所有设备上的访问令牌和刷新令牌立即失效
+// @Tags user
+// @Produce json
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /users/sessions [delete]
+func (controller *UserSessionController) RemoveOwnSessions(c *gin.Context) {
+	user, ok := utils.GetLoginUser(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, vo.FailWithMessage("Failed to get login user"))
+		return
+	}
+
+	if err := controller.TokenService.RemoveUserSessions(c.Request.Context(), user.ID); err != nil {
+		log.Printf("Error removing user sessions: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to remove user sessions"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// RemoveUserSessions 强制用户下线
+// @Summary 强制用户下线
+// @Description 注销指定用户全部账号的登录会话，已签发的令牌立即失效，该用户需要重新登录
+// @Tags admin
+// @Produce json
+// @Param userInfoId path int true "用户信息id"
+// @Success 200 {object} vo.Result
+// @Security BearerAuth
+// @Router /admin/users/{userInfoId}/sessions [delete]
+func (controller *UserSessionController) RemoveUserSessions(c *gin.Context) {
+	userInfoId, err := strconv.Atoi(c.Param("userInfoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid user ID"))
+		return
+	}
+
+	if err := controller.TokenService.RemoveUserInfoSessions(c.Request.Context(), userInfoId); err != nil {
+		log.Printf("Error removing user sessions: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to remove user sessions"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.Ok())
+}
+
+// ListOnlineUsers 查看在线用户
+// @Summary 查看在线用户
+// @Description 分页获取最近活跃的登录会话，可按昵称搜索
+// @Tags admin
+// @Produce json
+// @Param keywords query string false "昵称关键字"
+// @Success 200 {object} vo.Response{data=vo.PageResult{recordList=[]dto.UserOnlineDTO}}
+// @Security BearerAuth
+// @Router /admin/users/online [get]
+func (controller *UserSessionController) ListOnlineUsers(c *gin.Context) {
+	var condition vo.ConditionVO
+	if err := c.ShouldBindQuery(&condition); err != nil {
+		c.JSON(http.StatusBadRequest, vo.FailWithMessage("Invalid query parameters"))
+		return
+	}
+
+	result, err := controller.OnlineUserService.ListOnlineUsers(c.Request.Context(), condition)
+	if err != nil {
+		log.Printf("Error listing online users: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.FailWithMessage("Failed to retrieve online users"))
+		return
+	}
+	c.JSON(http.StatusOK, vo.OkWithData(result))
+}
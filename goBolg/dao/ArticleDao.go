@@ -61,6 +61,30 @@ type ArticleDao interface {
 
 	GetArticleCountByCategoryIDs(ctx context.Context, categoryIDList []int) (int64, error)
 
+	// 根据id列表查询文章
+	ListArticlesByIds(ctx context.Context, articleIdList []int) ([]model.Article, error)
+
+	// 根据标题查询文章id
+	GetArticleIdByTitle(ctx context.Context, articleTitle string) (int, error)
+
+	// 修改文章的创建时间和更新时间
+	UpdateArticleTime(ctx context.Context, articleId int, createTime time.Time, updateTime *time.Time) error
+
+	// 查询订阅源中的文章
+	ListFeedArticles(ctx context.Context, condition vo.ConditionVO, size int) ([]dto.ArticleFeedDTO, error)
+
+	// 发布已到期的定时文章
+	PublishDueArticles(ctx context.Context, now time.Time) (int64, error)
+
+	// 取消文章的定时发布
+	CancelArticleSchedule(ctx context.Context, articleId int, now time.Time) (int64, error)
+
+	// 按id顺序分批查询未删除的文章，用于重建搜索索引
+	ListIndexArticles(ctx context.Context, lastId int, size int) ([]model.Article, error)
+
+	// 筛选出已发布的公开文章id，保持原有顺序
+	FilterPublicArticleIds(ctx context.Context, articleIdList []int) ([]int, error)
+
 	GetDb() *gorm.DB
 }
 
@@ -78,6 +102,8 @@ func (r *articleDao) ListArchives(offset, limit int) ([]dto.ArchiveDTO, error) {
 
 	result := r.db.Table("tb_article").
 		Select("id, article_title, create_time").
+		Where("is_delete = ? AND status = ?", 0, enums.PUBLIC.Status).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
 		Offset(offset).
 		Limit(limit).
 		Order("create_time DESC").
@@ -95,7 +121,10 @@ func (r *articleDao) ListArchives(offset, limit int) ([]dto.ArchiveDTO, error) {
 func (r *articleDao) CountArchives() (int, error) {
 	var count int64
 
-	result := r.db.Table("tb_article").Count(&count)
+	result := r.db.Table("tb_article").
+		Where("is_delete = ? AND status = ?", 0, enums.PUBLIC.Status).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
+		Count(&count)
 	if result.Error != nil {
 		log.Printf("Error executing count query: %v", result.Error)
 		return 0, result.Error
@@ -127,6 +156,7 @@ func (dao *articleDao) ListArticles(offset int, size int) ([]dto.ArticleHomeDTO,
     WHERE
         a.is_delete = 0
         AND a.status = 1
+        AND (a.publish_time IS NULL OR a.publish_time <= NOW())
     GROUP BY
         a.id, a.article_cover, a.article_title, a.article_content, a.create_time, a.type, a.is_top, a.category_id, c.category_name
     ORDER BY
@@ -234,7 +264,8 @@ func (dao *articleDao) GetArticleById(ctx context.Context, articleId int) (dto.A
 		JOIN tb_tag t ON t.id = atg.tag_id
 		WHERE a.id = ?
 		AND a.is_delete = 0
-		AND a.status = 1`
+		AND a.status = 1
+		AND (a.publish_time IS NULL OR a.publish_time <= NOW())`
 	err := dao.db.Raw(query, articleId).Scan(&article).Error
 	if err != nil {
 		return dto.ArticleDTO{}, err
@@ -266,6 +297,7 @@ func (dao *articleDao) ListArticleBacks(ctx context.Context, current, size int,
 			a.is_delete,
 			a.status,
 			a.create_time,
+			a.publish_time,
 			c.category_name,
 			t.id AS tag_id,
 			t.tag_name
@@ -279,6 +311,7 @@ func (dao *articleDao) ListArticleBacks(ctx context.Context, current, size int,
 				is_delete,
 				status,
 				create_time,
+				publish_time,
 				category_id
 			FROM tb_article
 			WHERE is_delete = ?`
@@ -410,6 +443,8 @@ func (dao *articleDao) ListRecommendArticles(ctx context.Context, articleId int)
 		) t2
 		JOIN tb_article a ON t2.article_id = a.id
 		WHERE a.is_delete = 0
+		AND a.status = 1
+		AND (a.publish_time IS NULL OR a.publish_time <= NOW())
 		ORDER BY is_top DESC, a.id DESC
 		LIMIT 6`
 
@@ -460,6 +495,7 @@ func (dao *articleDao) GetPreviousArticle(ctx context.Context, articleId int) (m
 		Select("id, article_title, article_cover").
 		Where("is_delete = ?", 0).
 		Where("status = ?", enums.PUBLIC.Status).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
 		Where("id < ?", articleId).
 		Order("id DESC").
 		Limit(1).
@@ -479,6 +515,7 @@ func (dao *articleDao) GetNextArticle(ctx context.Context, articleId int) (model
 		Select("id, article_title, article_cover").
 		Where("is_delete = ?", 0).
 		Where("status = ?", enums.PUBLIC.Status).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
 		Where("id > ?", articleId).
 		Order("id ASC").
 		Limit(1).
@@ -519,6 +556,7 @@ func (dao *articleDao) ListArticlesByCondition(ctx context.Context, current int,
     WHERE
         a.is_delete = 0
         AND a.status = 1
+        AND (a.publish_time IS NULL OR a.publish_time <= NOW())
         AND (? IS NULL OR a.category_id = ?)
         AND (? IS NULL OR t.id = ?)
     ORDER BY a.create_time DESC
@@ -606,7 +644,7 @@ func (dao *articleDao) UpdateArticle(ctx context.Context, article *model.Article
 
 	// 更新文章内容，不覆盖 create_time 等其他字段
 	return dao.db.WithContext(ctx).Model(article).
-		Select("article_title", "article_content", "article_cover", "category_id", "type", "status", "is_top", "is_delete", "update_time").
+		Select("article_title", "article_content", "article_cover", "category_id", "type", "status", "is_top", "is_delete", "update_time", "publish_time").
 		Where("id = ?", article.ID).
 		Updates(article).Error
 }
@@ -660,6 +698,158 @@ func (dao *articleDao) GetArticleCountByCategoryIDs(ctx context.Context, categor
 	return count, err
 }
 
+// ListArticlesByIds 根据id列表查询文章
+func (dao *articleDao) ListArticlesByIds(ctx context.Context, articleIdList []int) ([]model.Article, error) {
+	var articles []model.Article
+	err := dao.db.WithContext(ctx).
+		Where("id IN ?", articleIdList).
+		Order("id ASC").
+		Find(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// GetArticleIdByTitle 根据标题查询未删除的文章id，不存在时返回 0
+func (dao *articleDao) GetArticleIdByTitle(ctx context.Context, articleTitle string) (int, error) {
+	var article model.Article
+	result := dao.db.WithContext(ctx).
+		Select("id").
+		Where("article_title = ? AND is_delete = ?", articleTitle, 0).
+		Order("id DESC").
+		Limit(1).
+		Find(&article)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return article.ID, nil
+}
+
+// UpdateArticleTime 修改文章时间，跳过钩子以免覆盖传入的时间，updateTime 为空时不修改更新时间
+func (dao *articleDao) UpdateArticleTime(ctx context.Context, articleId int, createTime time.Time, updateTime *time.Time) error {
+	columns := map[string]interface{}{
+		"create_time": createTime,
+	}
+	if updateTime != nil {
+		columns["update_time"] = *updateTime
+	}
+	return dao.db.WithContext(ctx).Model(&model.Article{}).
+		Where("id = ?", articleId).
+		UpdateColumns(columns).Error
+}
+
+// ListFeedArticles 按发表时间倒序查询已发布的公开文章，可按分类或标签筛选
+func (dao *articleDao) ListFeedArticles(ctx context.Context, condition vo.ConditionVO, size int) ([]dto.ArticleFeedDTO, error) {
+	var articles []dto.ArticleFeedDTO
+	query := dao.db.WithContext(ctx).Table("tb_article a").
+		Select("a.id, a.article_title, a.article_content, a.article_cover, c.category_name, a.create_time, a.update_time").
+		Joins("LEFT JOIN tb_category c ON a.category_id = c.id").
+		Where("a.is_delete = ? AND a.status = ?", 0, enums.PUBLIC.Status).
+		Where("a.publish_time IS NULL OR a.publish_time <= ?", time.Now())
+	if condition.CategoryID != nil {
+		query = query.Where("a.category_id = ?", *condition.CategoryID)
+	}
+	if condition.TagID != nil {
+		query = query.Where("a.id IN (?)", dao.db.Table("tb_article_tag").
+			Select("article_id").
+			Where("tag_id = ? AND deleted_at IS NULL", *condition.TagID))
+	}
+	if err := query.Order("a.create_time DESC").Limit(size).Scan(&articles).Error; err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return articles, nil
+	}
+
+	// 一次查出所有文章的标签
+	articleIdList := make([]int, 0, len(articles))
+	for _, article := range articles {
+		articleIdList = append(articleIdList, article.ID)
+	}
+	var articleTags []struct {
+		ArticleID int
+		TagName   string
+	}
+	err := dao.db.WithContext(ctx).Table("tb_article_tag atg").
+		Select("atg.article_id, t.tag_name").
+		Joins("JOIN tb_tag t ON t.id = atg.tag_id").
+		Where("atg.article_id IN ? AND atg.deleted_at IS NULL", articleIdList).
+		Order("t.id").
+		Scan(&articleTags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	tagNameMap := make(map[int][]string)
+	for _, articleTag := range articleTags {
+		tagNameMap[articleTag.ArticleID] = append(tagNameMap[articleTag.ArticleID], articleTag.TagName)
+	}
+	for i := range articles {
+		articles[i].TagNameList = tagNameMap[articles[i].ID]
+	}
+	return articles, nil
+}
+
+// PublishDueArticles 将发布时间已到的草稿文章改为公开，返回发布的数量
+func (dao *articleDao) PublishDueArticles(ctx context.Context, now time.Time) (int64, error) {
+	result := dao.db.WithContext(ctx).Model(&model.Article{}).
+		Where("status = ? AND publish_time IS NOT NULL AND publish_time <= ?", enums.DRAFT.Status, now).
+		UpdateColumns(map[string]interface{}{
+			"status":      enums.PUBLIC.Status,
+			"update_time": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CancelArticleSchedule 清除尚未到期的定时发布时间，文章保持草稿状态，返回修改的数量
+func (dao *articleDao) CancelArticleSchedule(ctx context.Context, articleId int, now time.Time) (int64, error) {
+	result := dao.db.WithContext(ctx).Model(&model.Article{}).
+		Where("id = ? AND status = ? AND publish_time > ?", articleId, enums.DRAFT.Status, now).
+		UpdateColumn("publish_time", nil)
+	return result.RowsAffected, result.Error
+}
+
 func (dao *articleDao) GetDb() *gorm.DB {
 	return dao.db
 }
+
+// ListIndexArticles 查询id大于 lastId 的未删除文章
+func (dao *articleDao) ListIndexArticles(ctx context.Context, lastId int, size int) ([]model.Article, error) {
+	var articles []model.Article
+	err := dao.db.WithContext(ctx).
+		Select("id, article_title, article_content").
+		Where("is_delete = ? AND id > ?", 0, lastId).
+		Order("id ASC").
+		Limit(size).
+		Find(&articles).Error
+	return articles, err
+}
+
+// FilterPublicArticleIds 筛选出未删除、公开且发布时间已到的文章id
+func (dao *articleDao) FilterPublicArticleIds(ctx context.Context, articleIdList []int) ([]int, error) {
+	if len(articleIdList) == 0 {
+		return []int{}, nil
+	}
+	var publicIds []int
+	err := dao.db.WithContext(ctx).Table("tb_article").
+		Where("id IN ?", articleIdList).
+		Where("is_delete = ? AND status = ?", 0, enums.PUBLIC.Status).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
+		Pluck("id", &publicIds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	publicSet := make(map[int]bool, len(publicIds))
+	for _, id := range publicIds {
+		publicSet[id] = true
+	}
+	result := make([]int, 0, len(publicIds))
+	for _, id := range articleIdList {
+		if publicSet[id] {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
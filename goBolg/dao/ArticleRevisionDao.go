@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"goBolg/model"
+	"gorm.io/gorm"
+)
+
+// ArticleRevisionDao 文章历史版本 DAO 接口
+type ArticleRevisionDao interface {
+	// 保存历史版本
+	Insert(ctx context.Context, revision *model.ArticleRevision) error
+
+	// 查询文章的历史版本，不包含文章内容
+	ListByArticleId(ctx context.Context, articleId int) ([]model.ArticleRevision, error)
+
+	// 根据id查询历史版本
+	GetById(ctx context.Context, revisionId int) (*model.ArticleRevision, error)
+
+	// 统计文章的历史版本数量
+	CountByArticleId(ctx context.Context, articleId int) (int64, error)
+
+	// 只保留最新的 keep 个历史版本
+	DeleteOutdated(ctx context.Context, articleId int, keep int) error
+
+	// 根据文章id列表删除历史版本
+	DeleteByArticleIds(ctx context.Context, articleIdList []int) error
+}
+
+type articleRevisionDao struct {
+	db *gorm.DB
+}
+
+// NewArticleRevisionDao 创建新的 ArticleRevisionDao 实例
+func NewArticleRevisionDao(db *gorm.DB) ArticleRevisionDao {
+	return &articleRevisionDao{db: db}
+}
+
+// Insert 保存历史版本
+func (dao *articleRevisionDao) Insert(ctx context.Context, revision *model.ArticleRevision) error {
+	return dao.db.WithContext(ctx).Create(revision).Error
+}
+
+// ListByArticleId 按时间倒序查询文章的历史版本
+func (dao *articleRevisionDao) ListByArticleId(ctx context.Context, articleId int) ([]model.ArticleRevision, error) {
+	var revisions []model.ArticleRevision
+	err := dao.db.WithContext(ctx).
+		Omit("article_content").
+		Where("article_id = ?", articleId).
+		Order("id DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetById 根据id查询历史版本，不存在时返回 nil
+func (dao *articleRevisionDao) GetById(ctx context.Context, revisionId int) (*model.ArticleRevision, error) {
+	var revision model.ArticleRevision
+	err := dao.db.WithContext(ctx).Where("id = ?", revisionId).First(&revision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// CountByArticleId 统计文章的历史版本数量
+func (dao *articleRevisionDao) CountByArticleId(ctx context.Context, articleId int) (int64, error) {
+	var count int64
+	err := dao.db.WithContext(ctx).Model(&model.ArticleRevision{}).Where("article_id = ?", articleId).Count(&count).Error
+	return count, err
+}
+
+// DeleteOutdated 删除超出保留数量的旧版本
+func (dao *articleRevisionDao) DeleteOutdated(ctx context.Context, articleId int, keep int) error {
+	var revision model.ArticleRevision
+	result := dao.db.WithContext(ctx).
+		Select("id").
+		Where("article_id = ?", articleId).
+		Order("id DESC").
+		Offset(keep - 1).
+		Limit(1).
+		Find(&revision)
+	if result.Error != nil || revision.ID == 0 {
+		return result.Error
+	}
+	return dao.db.WithContext(ctx).
+		Where("article_id = ? AND id < ?", articleId, revision.ID).
+		Delete(&model.ArticleRevision{}).Error
+}
+
+// DeleteByArticleIds 根据文章id列表删除历史版本
+func (dao *articleRevisionDao) DeleteByArticleIds(ctx context.Context, articleIdList []int) error {
+	if len(articleIdList) == 0 {
+		return nil
+	}
+	return dao.db.WithContext(ctx).Where("article_id IN ?", articleIdList).Delete(&model.ArticleRevision{}).Error
+}
@@ -25,6 +25,9 @@ type ArticleTagDao interface {
 	ListByArticleId(ctx context.Context, articleId int) ([]model.ArticleTag, error)
 
 	UpdateTimestamp(ctx context.Context, articleId int, tagId int, timestamp time.Time) error
+
+	// 物理删除文章中不在标签列表内的关联
+	DeleteByArticleIdNotInTagIds(ctx context.Context, articleId int, tagIdList []int) error
 }
 
 type articleTagDao struct {
@@ -117,3 +120,12 @@ func (dao *articleTagDao) UpdateTimestamp(ctx context.Context, articleId int, ta
 		Where("article_id = ? AND tag_id = ?", articleId, tagId).
 		Update("updated_at", updateTime).Error
 }
+
+// DeleteByArticleIdNotInTagIds 物理删除文章中不在标签列表内的关联，标签列表为空时删除全部关联
+func (dao *articleTagDao) DeleteByArticleIdNotInTagIds(ctx context.Context, articleId int, tagIdList []int) error {
+	query := dao.db.WithContext(ctx).Unscoped().Where("article_id = ?", articleId)
+	if len(tagIdList) > 0 {
+		query = query.Where("tag_id NOT IN ?", tagIdList)
+	}
+	return query.Delete(&model.ArticleTag{}).Error
+}
@@ -2,11 +2,15 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"goBolg/dto"
+	"goBolg/enums"
 	"goBolg/model"
+	"goBolg/utils"
 	"goBolg/vo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"log"
 )
 
@@ -14,7 +18,11 @@ import (
 type CommentDao interface {
 	ListComments(ctx context.Context, current int, size int, commentVO vo.CommentVO) ([]dto.CommentDTO, error)
 	ListReplies(ctx context.Context, commentIdList []int) ([]dto.ReplyDTO, error)
-	ListRepliesByCommentId(ctx context.Context, current int, size int, commentId int) ([]dto.ReplyDTO, error)
+	ListTopCommentTreeNodes(ctx context.Context, commentVO vo.CommentVO, cursor *utils.Cursor, size int) ([]dto.CommentTreeDTO, error)
+	ListCommentTreeLinks(ctx context.Context, rootIdList []int, maxLevel int) ([]dto.CommentTreeLinkDTO, error)
+	ListCommentTreeNodesByIds(ctx context.Context, commentIdList []int) ([]dto.CommentTreeDTO, error)
+	GetCommentParentId(ctx context.Context, commentId int) (int, error)
+	CountReviewedComments(ctx context.Context, commentVO vo.CommentVO) (int, error)
 	ListReplyCountByCommentId(ctx context.Context, commentIdList []int) ([]dto.ReplyCountDTO, error)
 	ListCommentCountByTopicIds(ctx context.Context, topicIdList []int) ([]dto.CommentCountDTO, error)
 	ListCommentBackDTO(ctx context.Context, current int, size int, condition vo.ConditionVO) ([]dto.CommentBackDTO, error)
@@ -22,6 +30,10 @@ type CommentDao interface {
 	Insert(ctx context.Context, comment model.Comment) (int, error)
 	UpdateBatchByID(ctx context.Context, comments []model.Comment) error
 	RemoveByIds(ctx context.Context, ids []int) error
+	ListCommentsByIds(ctx context.Context, ids []int) ([]model.Comment, error)
+	GetById(ctx context.Context, commentId int) (*model.Comment, error)
+	UpdateContentWithRevision(ctx context.Context, comment model.Comment) error
+	UpdateDeleteById(ctx context.Context, commentId int, isDelete int) error
 
 	GetCommentByID(ctx context.Context, commentID int) (dto.CommentDTO, error)
 }
@@ -48,21 +60,30 @@ func (dao *commentDao) ListComments(ctx context.Context, current int, size int,
             c.user_id,
             c.id,
             c.comment_content,
-            c.create_time
+            c.create_time,
+            c.edit_time IS NOT NULL AS is_edited
         FROM
             tb_comment c
             JOIN tb_user_info u ON c.user_id = u.id
         WHERE
             c.is_review = 1
+            AND c.is_delete = 0
             AND c.parent_id IS NULL
-            AND (c.topic_id = ? OR ? IS NULL)
-            AND c.type = ?
+            AND c.type = ?`
+	params := []interface{}{commentVO.Type}
+	if hasCommentTopic(commentVO.Type) {
+		query += `
+            AND c.topic_id = ?`
+		params = append(params, commentVO.TopicID)
+	}
+	query += `
         ORDER BY
             c.create_time DESC -- 这里改为按创建时间降序排列
         LIMIT ?, ?`
+	params = append(params, offset, size)
 
 	// 强制查询主库，如果使用了读写分离
-	err := dao.db.WithContext(ctx).Raw(query, commentVO.TopicID, commentVO.TopicID, commentVO.Type, offset, size).Scan(&comments).Error
+	err := dao.db.WithContext(ctx).Raw(query, params...).Scan(&comments).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -91,13 +112,15 @@ func (dao *commentDao) ListReplies(ctx context.Context, commentIdList []int) ([]
 			c.id,
 			c.parent_id,
 			c.comment_content,
-			c.create_time
+			c.create_time,
+			c.edit_time IS NOT NULL AS is_edited
 		FROM
 			tb_comment c
 			JOIN tb_user_info u ON c.user_id = u.id
 			JOIN tb_user_info r ON c.reply_user_id = r.id
 		WHERE
 			c.is_review = 1
+			AND c.is_delete = 0
 			AND c.parent_id IN ?`
 
 	err := dao.db.WithContext(ctx).Raw(query, commentIdList).Scan(&replies).Error
@@ -108,41 +131,146 @@ func (dao *commentDao) ListReplies(ctx context.Context, commentIdList []int) ([]
 	return replies, nil
 }
 
-// ListRepliesByCommentId 查询某条评论下的回复
-func (dao *commentDao) ListRepliesByCommentId(ctx context.Context, current int, size int, commentId int) ([]dto.ReplyDTO, error) {
-	var replies []dto.ReplyDTO
+// commentTreeNodeColumns 评论树节点需要的字段，被回复用户可能为空
+const commentTreeNodeColumns = `
+	c.id,
+	IFNULL(c.parent_id, 0) AS parent_id,
+	c.user_id,
+	u.nickname,
+	u.avatar,
+	u.web_site,
+	IFNULL(c.reply_user_id, 0) AS reply_user_id,
+	IFNULL(r.nickname, '') AS reply_nickname,
+	IFNULL(r.web_site, '') AS reply_web_site,
+	c.comment_content,
+	c.create_time,
+	c.edit_time IS NOT NULL AS is_edited`
+
+// commentTreeNodeQuery 查询已审核且未删除的评论树节点
+func (dao *commentDao) commentTreeNodeQuery(ctx context.Context) *gorm.DB {
+	return dao.db.WithContext(ctx).Table("tb_comment c").
+		Select(commentTreeNodeColumns).
+		Joins("JOIN tb_user_info u ON c.user_id = u.id").
+		Joins("LEFT JOIN tb_user_info r ON c.reply_user_id = r.id").
+		Where("c.is_review = ? AND c.is_delete = ?", 1, 0)
+}
+
+// ListTopCommentTreeNodes 按创建时间倒序查询游标之后的顶层评论
+func (dao *commentDao) ListTopCommentTreeNodes(ctx context.Context, commentVO vo.CommentVO, cursor *utils.Cursor, size int) ([]dto.CommentTreeDTO, error) {
+	var nodes []dto.CommentTreeDTO
+	query := dao.commentTreeNodeQuery(ctx).
+		Where("c.parent_id IS NULL AND c.type = ?", commentVO.Type)
+	if hasCommentTopic(commentVO.Type) {
+		query = query.Where("c.topic_id = ?", commentVO.TopicID)
+	}
+	if cursor != nil {
+		query = query.Where("c.create_time < ? OR (c.create_time = ? AND c.id < ?)", cursor.CreateTime, cursor.CreateTime, cursor.ID)
+	}
+
+	err := query.Order("c.create_time DESC, c.id DESC").Limit(size).Scan(&nodes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top comments: %w", err)
+	}
+	return nodes, nil
+}
+
+// ListCommentTreeLinks 递归查询顶层评论及其下已审核且未删除的全部回复，只返回组装评论树需要的字段，
+// 最多向下查询 maxLevel 层，防止异常数据形成环
+func (dao *commentDao) ListCommentTreeLinks(ctx context.Context, rootIdList []int, maxLevel int) ([]dto.CommentTreeLinkDTO, error) {
+	var links []dto.CommentTreeLinkDTO
+	if len(rootIdList) == 0 {
+		return links, nil
+	}
 
-	offset := (current - 1) * size
 	query := `
+		WITH RECURSIVE t AS (
+			SELECT
+				c.id,
+				c.parent_id,
+				c.id AS root_id,
+				c.user_id,
+				c.reply_user_id,
+				c.create_time,
+				0 AS level
+			FROM
+				tb_comment c
+			WHERE
+				c.id IN ?
+				AND c.is_review = 1
+				AND c.is_delete = 0
+			UNION ALL
+			SELECT
+				c.id,
+				c.parent_id,
+				t.root_id,
+				c.user_id,
+				c.reply_user_id,
+				c.create_time,
+				t.level + 1
+			FROM
+				tb_comment c
+				JOIN t ON c.parent_id = t.id
+			WHERE
+				c.is_review = 1
+				AND c.is_delete = 0
+				AND t.level < ?
+		)
 		SELECT
-			c.user_id,
-			u.nickname,
-			u.avatar,
-			u.web_site,
-			c.reply_user_id,
-			r.nickname AS reply_nickname,
-			r.web_site AS reply_web_site,
-			c.id,
-			c.parent_id,
-			c.comment_content,
-			c.create_time
+			id,
+			IFNULL(parent_id, 0) AS parent_id,
+			root_id,
+			user_id,
+			IFNULL(reply_user_id, 0) AS reply_user_id,
+			create_time
 		FROM
-			tb_comment c
-			JOIN tb_user_info u ON c.user_id = u.id
-			JOIN tb_user_info r ON c.reply_user_id = r.id
-		WHERE
-			c.is_review = 1
-			AND c.parent_id = ?
-		ORDER BY
-			c.create_time ASC
-		LIMIT ?, ?`
+			t`
 
-	err := dao.db.WithContext(ctx).Raw(query, commentId, offset, size).Scan(&replies).Error
+	err := dao.db.WithContext(ctx).Raw(query, rootIdList, maxLevel).Scan(&links).Error
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to list comment tree links: %w", err)
 	}
+	return links, nil
+}
 
-	return replies, nil
+// ListCommentTreeNodesByIds 根据评论id集合查询已审核的评论节点
+func (dao *commentDao) ListCommentTreeNodesByIds(ctx context.Context, commentIdList []int) ([]dto.CommentTreeDTO, error) {
+	var nodes []dto.CommentTreeDTO
+	if len(commentIdList) == 0 {
+		return nodes, nil
+	}
+
+	err := dao.commentTreeNodeQuery(ctx).Where("c.id IN ?", commentIdList).Scan(&nodes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replies: %w", err)
+	}
+	return nodes, nil
+}
+
+// GetCommentParentId 查询评论的父评论id，顶层评论返回 0
+func (dao *commentDao) GetCommentParentId(ctx context.Context, commentId int) (int, error) {
+	var comment model.Comment
+	err := dao.db.WithContext(ctx).Select("id", "parent_id").Where("id = ?", commentId).First(&comment).Error
+	if err != nil {
+		return 0, err
+	}
+	if comment.ParentID == nil {
+		return 0, nil
+	}
+	return *comment.ParentID, nil
+}
+
+// CountReviewedComments 统计主题下包含回复在内的已审核且未删除的评论数量
+func (dao *commentDao) CountReviewedComments(ctx context.Context, commentVO vo.CommentVO) (int, error) {
+	var count int64
+	query := dao.db.WithContext(ctx).Model(&model.Comment{}).
+		Where("is_review = ? AND is_delete = ? AND type = ?", 1, 0, commentVO.Type)
+	if hasCommentTopic(commentVO.Type) {
+		query = query.Where("topic_id = ?", commentVO.TopicID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+	return int(count), nil
 }
 
 // ListReplyCountByCommentId 查询评论id集合下的回复总量
@@ -157,6 +285,7 @@ func (dao *commentDao) ListReplyCountByCommentId(ctx context.Context, commentIdL
 			tb_comment
 		WHERE
 			is_review = 1
+			AND is_delete = 0
 			AND parent_id IN ?
 		GROUP BY
 			parent_id`
@@ -182,6 +311,7 @@ func (dao *commentDao) ListCommentCountByTopicIds(ctx context.Context, topicIdLi
 		WHERE
 			topic_id IN ?
 			AND parent_id IS NULL
+			AND is_delete = 0
 		GROUP BY
 			topic_id`
 
@@ -208,9 +338,12 @@ func (dao *commentDao) ListCommentBackDTO(ctx context.Context, current int, size
 			tb_comment.comment_content,
 			tb_comment.type,
 			tb_comment.create_time,
-			tb_comment.is_review
+			tb_comment.is_review,
+			tb_comment.is_delete,
+			tb_comment.edit_time,
+			tb_comment.spam_score
 		`).
-		Joins("LEFT JOIN tb_article a ON tb_comment.topic_id = a.id").
+		Joins("LEFT JOIN tb_article a ON tb_comment.topic_id = a.id AND tb_comment.type = ?", enums.ARTICLE.Type).
 		Joins("LEFT JOIN tb_user_info u ON tb_comment.user_id = u.id").
 		Joins("LEFT JOIN tb_user_info r ON tb_comment.reply_user_id = r.id").
 		Where("1 = 1")
@@ -221,6 +354,9 @@ func (dao *commentDao) ListCommentBackDTO(ctx context.Context, current int, size
 	if condition.IsReview != nil {
 		query = query.Where("tb_comment.is_review = ?", *condition.IsReview)
 	}
+	if condition.IsDelete != nil {
+		query = query.Where("tb_comment.is_delete = ?", *condition.IsDelete)
+	}
 	if condition.Keywords != nil {
 		query = query.Where("u.nickname LIKE ?", "%"+*condition.Keywords+"%")
 	}
@@ -250,6 +386,9 @@ func (dao *commentDao) CountCommentDTO(ctx context.Context, condition vo.Conditi
 	if condition.IsReview != nil {
 		query = query.Where("tb_comment.is_review = ?", *condition.IsReview)
 	}
+	if condition.IsDelete != nil {
+		query = query.Where("tb_comment.is_delete = ?", *condition.IsDelete)
+	}
 	if condition.Keywords != nil {
 		query = query.Where("u.nickname LIKE ?", "%"+*condition.Keywords+"%")
 	}
@@ -284,6 +423,66 @@ func (dao *commentDao) RemoveByIds(ctx context.Context, ids []int) error {
 	return nil
 }
 
+// ListCommentsByIds 根据 id 批量查询评论
+func (dao *commentDao) ListCommentsByIds(ctx context.Context, ids []int) ([]model.Comment, error) {
+	var comments []model.Comment
+	if len(ids) == 0 {
+		return comments, nil
+	}
+	err := dao.db.WithContext(ctx).Where("id IN ?", ids).Find(&comments).Error
+	return comments, err
+}
+
+// GetById 根据 id 查询评论，不存在时返回 nil
+func (dao *commentDao) GetById(ctx context.Context, commentId int) (*model.Comment, error) {
+	var comment model.Comment
+	err := dao.db.WithContext(ctx).Where("id = ?", commentId).First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// UpdateContentWithRevision 在事务中锁定评论，将修改前的内容保存为编辑历史后更新评论内容
+func (dao *commentDao) UpdateContentWithRevision(ctx context.Context, comment model.Comment) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁定评论行，并发编辑时按顺序记录每次修改前的内容
+		var current model.Comment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id, comment_content").
+			Where("id = ?", comment.ID).
+			First(&current).Error; err != nil {
+			return err
+		}
+		revision := model.CommentRevision{
+			CommentID:      current.ID,
+			CommentContent: current.CommentContent,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Comment{}).
+			Where("id = ?", comment.ID).
+			Updates(map[string]interface{}{
+				"comment_content": comment.CommentContent,
+				"comment_source":  comment.CommentSource,
+				"is_review":       comment.IsReview,
+				"spam_score":      comment.SpamScore,
+				"edit_time":       comment.EditTime,
+			}).Error
+	})
+}
+
+// UpdateDeleteById 修改评论的逻辑删除状态
+func (dao *commentDao) UpdateDeleteById(ctx context.Context, commentId int, isDelete int) error {
+	return dao.db.WithContext(ctx).Model(&model.Comment{}).
+		Where("id = ?", commentId).
+		Update("is_delete", isDelete).Error
+}
+
 func (dao *commentDao) GetCommentByID(ctx context.Context, commentID int) (dto.CommentDTO, error) {
 	var comment dto.CommentDTO
 	query := `
@@ -294,7 +493,8 @@ func (dao *commentDao) GetCommentByID(ctx context.Context, commentID int) (dto.C
             c.user_id,
             c.id,
             c.comment_content,
-            c.create_time
+            c.create_time,
+            c.edit_time IS NOT NULL AS is_edited
         FROM
             tb_comment c
             JOIN tb_user_info u ON c.user_id = u.id
@@ -308,3 +508,9 @@ func (dao *commentDao) GetCommentByID(ctx context.Context, commentID int) (dto.C
 
 	return comment, nil
 }
+
+// hasCommentTopic 判断评论类型是否按主题过滤，友链等不关联主题的类型查询全部评论
+func hasCommentTopic(commentType int) bool {
+	commentEnum := enums.GetCommentEnum(commentType)
+	return commentEnum == nil || commentEnum.HasTopic
+}
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	"goBolg/model"
+	"gorm.io/gorm"
+)
+
+// CommentRevisionDao 评论编辑历史 DAO 接口
+type CommentRevisionDao interface {
+	// 保存编辑历史
+	Insert(ctx context.Context, revision *model.CommentRevision) error
+
+	// 根据评论id列表查询编辑历史
+	ListByCommentIds(ctx context.Context, commentIdList []int) ([]model.CommentRevision, error)
+
+	// 根据评论id列表删除编辑历史
+	DeleteByCommentIds(ctx context.Context, commentIdList []int) error
+}
+
+type commentRevisionDao struct {
+	db *gorm.DB
+}
+
+// NewCommentRevisionDao 创建新的 CommentRevisionDao 实例
+func NewCommentRevisionDao(db *gorm.DB) CommentRevisionDao {
+	return &commentRevisionDao{db: db}
+}
+
+// Insert 保存编辑历史
+func (dao *commentRevisionDao) Insert(ctx context.Context, revision *model.CommentRevision) error {
+	return dao.db.WithContext(ctx).Create(revision).Error
+}
+
+// ListByCommentIds 按时间倒序查询评论的编辑历史
+func (dao *commentRevisionDao) ListByCommentIds(ctx context.Context, commentIdList []int) ([]model.CommentRevision, error) {
+	var revisions []model.CommentRevision
+	if len(commentIdList) == 0 {
+		return revisions, nil
+	}
+	err := dao.db.WithContext(ctx).
+		Where("comment_id IN ?", commentIdList).
+		Order("id DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// DeleteByCommentIds 根据评论id列表删除编辑历史
+func (dao *commentRevisionDao) DeleteByCommentIds(ctx context.Context, commentIdList []int) error {
+	if len(commentIdList) == 0 {
+		return nil
+	}
+	return dao.db.WithContext(ctx).Where("comment_id IN ?", commentIdList).Delete(&model.CommentRevision{}).Error
+}
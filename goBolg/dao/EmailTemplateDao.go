@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"goBolg/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// EmailTemplateDao 邮件模板数据访问接口
+type EmailTemplateDao interface {
+	// 查询全部自定义模板
+	ListEmailTemplates(ctx context.Context) ([]model.EmailTemplate, error)
+
+	// 根据模板名称查询自定义模板，不存在时返回 nil
+	GetEmailTemplateByName(ctx context.Context, name string) (*model.EmailTemplate, error)
+
+	// 保存自定义模板，同名模板已存在时更新
+	SaveOrUpdateEmailTemplate(ctx context.Context, emailTemplate *model.EmailTemplate) error
+
+	// 根据模板名称删除自定义模板
+	DeleteEmailTemplateByName(ctx context.Context, name string) error
+}
+
+type emailTemplateDao struct {
+	db *gorm.DB
+}
+
+// NewEmailTemplateDao 创建新的 EmailTemplateDao 实例
+func NewEmailTemplateDao(db *gorm.DB) EmailTemplateDao {
+	return &emailTemplateDao{db: db}
+}
+
+// ListEmailTemplates 查询全部自定义模板
+func (dao *emailTemplateDao) ListEmailTemplates(ctx context.Context) ([]model.EmailTemplate, error) {
+	var emailTemplates []model.EmailTemplate
+	err := dao.db.WithContext(ctx).Find(&emailTemplates).Error
+	return emailTemplates, err
+}
+
+// GetEmailTemplateByName 根据模板名称查询自定义模板
+func (dao *emailTemplateDao) GetEmailTemplateByName(ctx context.Context, name string) (*model.EmailTemplate, error) {
+	var emailTemplate model.EmailTemplate
+	err := dao.db.WithContext(ctx).Where("name = ?", name).First(&emailTemplate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &emailTemplate, nil
+}
+
+// SaveOrUpdateEmailTemplate 按模板名称唯一索引保存或更新自定义模板
+func (dao *emailTemplateDao) SaveOrUpdateEmailTemplate(ctx context.Context, emailTemplate *model.EmailTemplate) error {
+	return dao.db.WithContext(ctx).
+		Omit("UpdateTime").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"subject":      emailTemplate.Subject,
+				"html_content": emailTemplate.HTMLContent,
+				"text_content": emailTemplate.TextContent,
+				"update_time":  time.Now(),
+			}),
+		}).
+		Create(emailTemplate).Error
+}
+
+// DeleteEmailTemplateByName 根据模板名称删除自定义模板
+func (dao *emailTemplateDao) DeleteEmailTemplateByName(ctx context.Context, name string) error {
+	return dao.db.WithContext(ctx).Where("name = ?", name).Delete(&model.EmailTemplate{}).Error
+}
@@ -24,6 +24,9 @@ type MessageDao interface {
 
 	// 删除留言
 	RemoveByIds(ctx context.Context, ids []uint) error
+
+	// 根据 id 批量查询留言
+	ListMessagesByIds(ctx context.Context, ids []int) ([]model.Message, error)
 }
 
 // messageDaoImpl 是 MessageDao 的一个具体实现
@@ -111,3 +114,13 @@ func (dao *messageDaoImpl) UpdateBatchById(ctx context.Context, messages []model
 func (dao *messageDaoImpl) RemoveByIds(ctx context.Context, ids []uint) error {
 	return dao.db.WithContext(ctx).Where("id IN ?", ids).Delete(&model.Message{}).Error
 }
+
+// ListMessagesByIds 根据 id 批量查询留言
+func (dao *messageDaoImpl) ListMessagesByIds(ctx context.Context, ids []int) ([]model.Message, error) {
+	var messages []model.Message
+	if len(ids) == 0 {
+		return messages, nil
+	}
+	err := dao.db.WithContext(ctx).Where("id IN ?", ids).Find(&messages).Error
+	return messages, err
+}
@@ -0,0 +1,115 @@
+package dao
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/model"
+	"goBolg/vo"
+	"gorm.io/gorm"
+)
+
+// NotificationDao 站内通知数据访问接口
+type NotificationDao interface {
+	// 保存通知
+	SaveNotification(ctx context.Context, notification *model.Notification) error
+
+	// 是否存在同一用户对同一对象触发的同类未读通知
+	ExistsUnreadNotification(ctx context.Context, notification model.Notification) (bool, error)
+
+	// 分页查询用户的通知，类型条件表示通知类型
+	ListNotifications(ctx context.Context, userId int, offset int, size int, condition vo.ConditionVO) ([]dto.NotificationDTO, error)
+
+	// 统计用户的通知数量
+	CountNotifications(ctx context.Context, userId int, condition vo.ConditionVO) (int64, error)
+
+	// 统计用户的未读通知数量
+	CountUnreadNotifications(ctx context.Context, userId int) (int64, error)
+
+	// 将用户的通知标记为已读
+	ReadNotifications(ctx context.Context, userId int, notificationIdList []int) error
+
+	// 将用户的全部通知标记为已读
+	ReadAllNotifications(ctx context.Context, userId int) error
+}
+
+type notificationDao struct {
+	db *gorm.DB
+}
+
+// NewNotificationDao 创建新的 NotificationDao 实例
+func NewNotificationDao(db *gorm.DB) NotificationDao {
+	return &notificationDao{db: db}
+}
+
+// SaveNotification 保存通知
+func (dao *notificationDao) SaveNotification(ctx context.Context, notification *model.Notification) error {
+	return dao.db.WithContext(ctx).Create(notification).Error
+}
+
+// ExistsUnreadNotification 是否存在同一用户对同一对象触发的同类未读通知
+func (dao *notificationDao) ExistsUnreadNotification(ctx context.Context, notification model.Notification) (bool, error) {
+	var count int64
+	err := dao.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND from_user_id = ? AND type = ? AND target_id = ? AND is_read = 0",
+			notification.UserID, notification.FromUserID, notification.Type, notification.TargetID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// buildNotificationQuery 构建用户通知查询条件
+func (dao *notificationDao) buildNotificationQuery(ctx context.Context, userId int, condition vo.ConditionVO) *gorm.DB {
+	query := dao.db.WithContext(ctx).Table("tb_notification n").Where("n.user_id = ?", userId)
+	if condition.Type != nil {
+		query = query.Where("n.type = ?", *condition.Type)
+	}
+	if condition.IsRead != nil {
+		query = query.Where("n.is_read = ?", *condition.IsRead)
+	}
+	return query
+}
+
+// ListNotifications 分页查询用户的通知，最新的通知在前
+func (dao *notificationDao) ListNotifications(ctx context.Context, userId int, offset int, size int, condition vo.ConditionVO) ([]dto.NotificationDTO, error) {
+	var notifications []dto.NotificationDTO
+	err := dao.buildNotificationQuery(ctx, userId, condition).
+		Select("n.id, n.type, n.from_user_id, u.nickname AS from_nickname, u.avatar AS from_avatar, n.target_id, n.content, n.path, n.is_read, n.create_time").
+		Joins("LEFT JOIN tb_user_info u ON n.from_user_id = u.id").
+		Order("n.id DESC").
+		Offset(offset).
+		Limit(size).
+		Scan(&notifications).Error
+	return notifications, err
+}
+
+// CountNotifications 统计用户的通知数量
+func (dao *notificationDao) CountNotifications(ctx context.Context, userId int, condition vo.ConditionVO) (int64, error) {
+	var count int64
+	err := dao.buildNotificationQuery(ctx, userId, condition).Count(&count).Error
+	return count, err
+}
+
+// CountUnreadNotifications 统计用户的未读通知数量
+func (dao *notificationDao) CountUnreadNotifications(ctx context.Context, userId int) (int64, error) {
+	var count int64
+	err := dao.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND is_read = 0", userId).
+		Count(&count).Error
+	return count, err
+}
+
+// ReadNotifications 将用户的通知标记为已读，只更新属于该用户的通知
+func (dao *notificationDao) ReadNotifications(ctx context.Context, userId int, notificationIdList []int) error {
+	if len(notificationIdList) == 0 {
+		return nil
+	}
+	return dao.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND id IN ? AND is_read = 0", userId, notificationIdList).
+		Update("is_read", 1).Error
+}
+
+// ReadAllNotifications 将用户的全部通知标记为已读
+func (dao *notificationDao) ReadAllNotifications(ctx context.Context, userId int) error {
+	return dao.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND is_read = 0", userId).
+		Update("is_read", 1).Error
+}
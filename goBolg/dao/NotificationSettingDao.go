@@ -0,0 +1,68 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"goBolg/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// NotificationSettingDao 通知接收设置数据访问接口
+type NotificationSettingDao interface {
+	// 查询用户的全部通知设置
+	ListNotificationSettings(ctx context.Context, userId int) ([]model.NotificationSetting, error)
+
+	// 查询用户某类通知的设置，不存在时返回 nil
+	GetNotificationSetting(ctx context.Context, userId int, notificationType int) (*model.NotificationSetting, error)
+
+	// 批量保存通知设置，已存在的设置更新接收方式
+	SaveNotificationSettings(ctx context.Context, notificationSettings []model.NotificationSetting) error
+}
+
+type notificationSettingDao struct {
+	db *gorm.DB
+}
+
+// NewNotificationSettingDao 创建新的 NotificationSettingDao 实例
+func NewNotificationSettingDao(db *gorm.DB) NotificationSettingDao {
+	return &notificationSettingDao{db: db}
+}
+
+// ListNotificationSettings 查询用户的全部通知设置
+func (dao *notificationSettingDao) ListNotificationSettings(ctx context.Context, userId int) ([]model.NotificationSetting, error) {
+	var notificationSettings []model.NotificationSetting
+	err := dao.db.WithContext(ctx).Where("user_id = ?", userId).Find(&notificationSettings).Error
+	return notificationSettings, err
+}
+
+// GetNotificationSetting 查询用户某类通知的设置
+func (dao *notificationSettingDao) GetNotificationSetting(ctx context.Context, userId int, notificationType int) (*model.NotificationSetting, error) {
+	var notificationSetting model.NotificationSetting
+	err := dao.db.WithContext(ctx).Where("user_id = ? AND type = ?", userId, notificationType).First(&notificationSetting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notificationSetting, nil
+}
+
+// SaveNotificationSettings 批量保存通知设置，按用户和通知类型唯一索引更新已存在的记录
+func (dao *notificationSettingDao) SaveNotificationSettings(ctx context.Context, notificationSettings []model.NotificationSetting) error {
+	if len(notificationSettings) == 0 {
+		return nil
+	}
+	return dao.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"is_email":    gorm.Expr("VALUES(is_email)"),
+				"is_in_app":   gorm.Expr("VALUES(is_in_app)"),
+				"update_time": time.Now(),
+			}),
+		}).
+		Create(&notificationSettings).Error
+}
@@ -44,27 +44,51 @@ func NewRoleDao(db *gorm.DB) RoleDao {
 	return &roleDao{db: db}
 }
 
-// ListResourceRoles 查询路由角色列表
+// ListResourceRoles 查询路由角色列表，同一资源的角色合并到 RoleList，禁用的角色不计入
 func (dao *roleDao) ListResourceRoles(ctx context.Context) ([]dto.ResourceRoleDTO, error) {
-	var roles []dto.ResourceRoleDTO
+	var rows []struct {
+		ID            int
+		URL           string
+		RequestMethod string
+		IsAnonymous   int
+		RoleLabel     *string
+	}
 	err := dao.db.WithContext(ctx).
 		Raw(`
 			SELECT
 				re.id,
 				url,
 				request_method,
+				is_anonymous,
 				role_label
 			FROM
 				tb_resource re
 				LEFT JOIN tb_role_resource rep ON re.id = rep.resource_id
-				LEFT JOIN tb_role r ON rep.role_id = r.id
+				LEFT JOIN tb_role r ON rep.role_id = r.id AND r.is_disable = 0
 			WHERE
 				parent_id IS NOT NULL
-				AND is_anonymous = 0
-		`).Scan(&roles).Error
+			ORDER BY
+				re.id
+		`).Scan(&rows).Error
 	if err != nil {
 		return nil, err
 	}
+
+	var roles []dto.ResourceRoleDTO
+	for _, row := range rows {
+		if len(roles) == 0 || roles[len(roles)-1].ID != row.ID {
+			roles = append(roles, dto.ResourceRoleDTO{
+				ID:            row.ID,
+				URL:           row.URL,
+				RequestMethod: row.RequestMethod,
+				IsAnonymous:   row.IsAnonymous,
+				RoleList:      []string{},
+			})
+		}
+		if row.RoleLabel != nil {
+			roles[len(roles)-1].RoleList = append(roles[len(roles)-1].RoleList, *row.RoleLabel)
+		}
+	}
 	return roles, nil
 }
 
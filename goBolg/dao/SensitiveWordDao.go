@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"goBolg/model"
+	"goBolg/vo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// sensitiveWordBatchSize 批量导入时每条语句写入的敏感词数量
+const sensitiveWordBatchSize = 500
+
+// SensitiveWordDao 敏感词数据访问接口
+type SensitiveWordDao interface {
+	// 查询全部敏感词
+	ListSensitiveWords(ctx context.Context) ([]model.SensitiveWord, error)
+
+	// 分页查询后台敏感词
+	ListBackSensitiveWords(ctx context.Context, offset int, size int, condition vo.ConditionVO) ([]model.SensitiveWord, error)
+
+	// 统计后台敏感词数量
+	CountBackSensitiveWords(ctx context.Context, condition vo.ConditionVO) (int64, error)
+
+	// 根据敏感词查询，不存在时返回 nil
+	GetSensitiveWordByWord(ctx context.Context, word string) (*model.SensitiveWord, error)
+
+	// 保存或更新敏感词
+	SaveOrUpdateSensitiveWord(ctx context.Context, sensitiveWord *model.SensitiveWord) error
+
+	// 批量保存敏感词，已存在的敏感词更新处理方式
+	UpsertSensitiveWords(ctx context.Context, sensitiveWords []model.SensitiveWord) error
+
+	// 删除敏感词
+	DeleteSensitiveWordByIds(ctx context.Context, sensitiveWordIdList []int) error
+}
+
+type sensitiveWordDao struct {
+	db *gorm.DB
+}
+
+// NewSensitiveWordDao 创建新的 SensitiveWordDao 实例
+func NewSensitiveWordDao(db *gorm.DB) SensitiveWordDao {
+	return &sensitiveWordDao{db: db}
+}
+
+// ListSensitiveWords 查询全部敏感词
+func (dao *sensitiveWordDao) ListSensitiveWords(ctx context.Context) ([]model.SensitiveWord, error) {
+	var sensitiveWords []model.SensitiveWord
+	err := dao.db.WithContext(ctx).Select("id", "word", "action").Find(&sensitiveWords).Error
+	return sensitiveWords, err
+}
+
+// buildSensitiveWordQuery 构建后台敏感词查询条件，类型条件表示处理方式
+func (dao *sensitiveWordDao) buildSensitiveWordQuery(ctx context.Context, condition vo.ConditionVO) *gorm.DB {
+	query := dao.db.WithContext(ctx).Model(&model.SensitiveWord{})
+	if condition.Keywords != nil && *condition.Keywords != "" {
+		query = query.Where("word LIKE ?", "%"+*condition.Keywords+"%")
+	}
+	if condition.Type != nil {
+		query = query.Where("action = ?", *condition.Type)
+	}
+	return query
+}
+
+// ListBackSensitiveWords 分页查询后台敏感词
+func (dao *sensitiveWordDao) ListBackSensitiveWords(ctx context.Context, offset int, size int, condition vo.ConditionVO) ([]model.SensitiveWord, error) {
+	var sensitiveWords []model.SensitiveWord
+	err := dao.buildSensitiveWordQuery(ctx, condition).
+		Order("id DESC").
+		Offset(offset).
+		Limit(size).
+		Find(&sensitiveWords).Error
+	return sensitiveWords, err
+}
+
+// CountBackSensitiveWords 统计后台敏感词数量
+func (dao *sensitiveWordDao) CountBackSensitiveWords(ctx context.Context, condition vo.ConditionVO) (int64, error) {
+	var count int64
+	err := dao.buildSensitiveWordQuery(ctx, condition).Count(&count).Error
+	return count, err
+}
+
+// GetSensitiveWordByWord 根据敏感词查询
+func (dao *sensitiveWordDao) GetSensitiveWordByWord(ctx context.Context, word string) (*model.SensitiveWord, error) {
+	var sensitiveWord model.SensitiveWord
+	err := dao.db.WithContext(ctx).Where("word = ?", word).First(&sensitiveWord).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sensitiveWord, nil
+}
+
+// SaveOrUpdateSensitiveWord 保存或更新敏感词
+func (dao *sensitiveWordDao) SaveOrUpdateSensitiveWord(ctx context.Context, sensitiveWord *model.SensitiveWord) error {
+	if sensitiveWord.ID == 0 {
+		return dao.db.WithContext(ctx).Omit("UpdateTime").Create(sensitiveWord).Error
+	}
+
+	now := time.Now()
+	sensitiveWord.UpdateTime = &now
+	return dao.db.WithContext(ctx).Model(&model.SensitiveWord{}).
+		Where("id = ?", sensitiveWord.ID).
+		Updates(map[string]interface{}{
+			"word":        sensitiveWord.Word,
+			"action":      sensitiveWord.Action,
+			"update_time": sensitiveWord.UpdateTime,
+		}).Error
+}
+
+// UpsertSensitiveWords 批量保存敏感词，按敏感词唯一索引更新已存在记录的处理方式
+func (dao *sensitiveWordDao) UpsertSensitiveWords(ctx context.Context, sensitiveWords []model.SensitiveWord) error {
+	if len(sensitiveWords) == 0 {
+		return nil
+	}
+	return dao.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "word"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"action": gorm.Expr("VALUES(action)"), "update_time": time.Now()}),
+		}).
+		CreateInBatches(sensitiveWords, sensitiveWordBatchSize).Error
+}
+
+// DeleteSensitiveWordByIds 删除敏感词
+func (dao *sensitiveWordDao) DeleteSensitiveWordByIds(ctx context.Context, sensitiveWordIdList []int) error {
+	return dao.db.WithContext(ctx).Where("id IN ?", sensitiveWordIdList).Delete(&model.SensitiveWord{}).Error
+}
@@ -0,0 +1,190 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/model"
+	"goBolg/vo"
+	"gorm.io/gorm"
+	"time"
+)
+
+// SeriesDao 文章系列数据访问接口
+type SeriesDao interface {
+	// 查询所有系列
+	ListSeries(ctx context.Context) ([]model.Series, error)
+
+	// 分页查询后台系列
+	ListBackSeries(ctx context.Context, offset int, size int, condition vo.ConditionVO) ([]model.Series, error)
+
+	// 统计后台系列数量
+	CountBackSeries(ctx context.Context, condition vo.ConditionVO) (int64, error)
+
+	// 根据id查询系列，不存在时返回 nil
+	GetSeriesById(ctx context.Context, seriesId int) (*model.Series, error)
+
+	// 保存或更新系列，并按顺序替换系列中的文章
+	SaveOrUpdateSeries(ctx context.Context, series *model.Series, articleIdList []int) error
+
+	// 删除系列及其文章关联
+	DeleteSeriesByIds(ctx context.Context, seriesIdList []int) error
+
+	// 按顺序查询系列中的文章，publicOnly 为 true 时只返回已发布的公开文章
+	ListSeriesArticles(ctx context.Context, seriesIdList []int, publicOnly bool) ([]dto.SeriesArticleDTO, error)
+
+	// 查询文章所属的系列id，不属于任何系列时返回 0
+	GetSeriesIdByArticleId(ctx context.Context, articleId int) (int, error)
+
+	// 删除文章的系列关联
+	DeleteByArticleIds(ctx context.Context, articleIdList []int) error
+}
+
+type seriesDao struct {
+	db *gorm.DB
+}
+
+// NewSeriesDao 创建新的 SeriesDao 实例
+func NewSeriesDao(db *gorm.DB) SeriesDao {
+	return &seriesDao{db: db}
+}
+
+// ListSeries 按创建时间倒序查询所有系列
+func (dao *seriesDao) ListSeries(ctx context.Context) ([]model.Series, error) {
+	var seriesList []model.Series
+	err := dao.db.WithContext(ctx).Order("id DESC").Find(&seriesList).Error
+	return seriesList, err
+}
+
+// buildSeriesQuery 构建后台系列查询条件
+func (dao *seriesDao) buildSeriesQuery(ctx context.Context, condition vo.ConditionVO) *gorm.DB {
+	query := dao.db.WithContext(ctx).Model(&model.Series{})
+	if condition.Keywords != nil && *condition.Keywords != "" {
+		query = query.Where("series_title LIKE ?", "%"+*condition.Keywords+"%")
+	}
+	return query
+}
+
+// ListBackSeries 分页查询后台系列
+func (dao *seriesDao) ListBackSeries(ctx context.Context, offset int, size int, condition vo.ConditionVO) ([]model.Series, error) {
+	var seriesList []model.Series
+	err := dao.buildSeriesQuery(ctx, condition).
+		Order("id DESC").
+		Offset(offset).
+		Limit(size).
+		Find(&seriesList).Error
+	return seriesList, err
+}
+
+// CountBackSeries 统计后台系列数量
+func (dao *seriesDao) CountBackSeries(ctx context.Context, condition vo.ConditionVO) (int64, error) {
+	var count int64
+	err := dao.buildSeriesQuery(ctx, condition).Count(&count).Error
+	return count, err
+}
+
+// GetSeriesById 根据id查询系列
+func (dao *seriesDao) GetSeriesById(ctx context.Context, seriesId int) (*model.Series, error) {
+	var series model.Series
+	err := dao.db.WithContext(ctx).Where("id = ?", seriesId).First(&series).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &series, nil
+}
+
+// SaveOrUpdateSeries 在同一事务中保存系列并重建文章顺序，已属于其他系列的文章会移入当前系列
+func (dao *seriesDao) SaveOrUpdateSeries(ctx context.Context, series *model.Series, articleIdList []int) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if series.ID == 0 {
+			if err := tx.Omit("UpdateTime").Create(series).Error; err != nil {
+				return err
+			}
+		} else {
+			now := time.Now()
+			series.UpdateTime = &now
+			err := tx.Model(&model.Series{}).
+				Where("id = ?", series.ID).
+				Updates(map[string]interface{}{
+					"series_title": series.SeriesTitle,
+					"series_desc":  series.SeriesDesc,
+					"series_cover": series.SeriesCover,
+					"update_time":  series.UpdateTime,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("series_id = ?", series.ID).Delete(&model.SeriesArticle{}).Error; err != nil {
+			return err
+		}
+		if len(articleIdList) == 0 {
+			return nil
+		}
+		if err := tx.Where("article_id IN ?", articleIdList).Delete(&model.SeriesArticle{}).Error; err != nil {
+			return err
+		}
+
+		seriesArticles := make([]model.SeriesArticle, 0, len(articleIdList))
+		for i, articleId := range articleIdList {
+			seriesArticles = append(seriesArticles, model.SeriesArticle{
+				SeriesID:  series.ID,
+				ArticleID: articleId,
+				Sort:      i + 1,
+			})
+		}
+		return tx.Create(&seriesArticles).Error
+	})
+}
+
+// DeleteSeriesByIds 删除系列及其文章关联
+func (dao *seriesDao) DeleteSeriesByIds(ctx context.Context, seriesIdList []int) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id IN ?", seriesIdList).Delete(&model.SeriesArticle{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", seriesIdList).Delete(&model.Series{}).Error
+	})
+}
+
+// ListSeriesArticles 按系列和顺序查询文章
+func (dao *seriesDao) ListSeriesArticles(ctx context.Context, seriesIdList []int, publicOnly bool) ([]dto.SeriesArticleDTO, error) {
+	var articles []dto.SeriesArticleDTO
+	if len(seriesIdList) == 0 {
+		return articles, nil
+	}
+	query := dao.db.WithContext(ctx).Table("tb_series_article sa").
+		Select("sa.series_id, a.id, a.article_title, a.article_cover, a.create_time").
+		Joins("JOIN tb_article a ON sa.article_id = a.id").
+		Where("sa.series_id IN ?", seriesIdList)
+	if publicOnly {
+		query = query.
+			Where("a.is_delete = ? AND a.status = ?", 0, enums.PUBLIC.Status).
+			Where("a.publish_time IS NULL OR a.publish_time <= ?", time.Now())
+	}
+	err := query.Order("sa.series_id ASC, sa.sort ASC").Scan(&articles).Error
+	return articles, err
+}
+
+// GetSeriesIdByArticleId 查询文章所属的系列id
+func (dao *seriesDao) GetSeriesIdByArticleId(ctx context.Context, articleId int) (int, error) {
+	var seriesIds []int
+	err := dao.db.WithContext(ctx).Model(&model.SeriesArticle{}).
+		Where("article_id = ?", articleId).
+		Limit(1).
+		Pluck("series_id", &seriesIds).Error
+	if err != nil || len(seriesIds) == 0 {
+		return 0, err
+	}
+	return seriesIds[0], nil
+}
+
+// DeleteByArticleIds 删除文章的系列关联
+func (dao *seriesDao) DeleteByArticleIds(ctx context.Context, articleIdList []int) error {
+	return dao.db.WithContext(ctx).Where("article_id IN ?", articleIdList).Delete(&model.SeriesArticle{}).Error
+}
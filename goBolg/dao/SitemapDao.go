@@ -0,0 +1,104 @@
+package dao
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/enums"
+	"gorm.io/gorm"
+	"time"
+)
+
+// SitemapDao 查询站点地图需要收录的公开内容
+type SitemapDao interface {
+	// 查询已发布的公开文章
+	ListArticles(ctx context.Context) ([]dto.SitemapEntryDTO, error)
+
+	// 查询所有分类
+	ListCategories(ctx context.Context) ([]dto.SitemapEntryDTO, error)
+
+	// 查询所有标签
+	ListTags(ctx context.Context) ([]dto.SitemapEntryDTO, error)
+
+	// 查询已发布的公开说说
+	ListTalks(ctx context.Context) ([]dto.SitemapEntryDTO, error)
+
+	// 查询未删除的公开相册
+	ListPhotoAlbums(ctx context.Context) ([]dto.SitemapEntryDTO, error)
+
+	// 查询所有页面
+	ListPages(ctx context.Context) ([]dto.SitemapEntryDTO, error)
+}
+
+type sitemapDao struct {
+	db *gorm.DB
+}
+
+// NewSitemapDao 创建新的 SitemapDao 实例
+func NewSitemapDao(db *gorm.DB) SitemapDao {
+	return &sitemapDao{db: db}
+}
+
+// ListArticles 查询未删除、公开且发布时间已到的文章，私密文章和草稿不收录
+func (dao *sitemapDao) ListArticles(ctx context.Context) ([]dto.SitemapEntryDTO, error) {
+	var entries []dto.SitemapEntryDTO
+	err := dao.db.WithContext(ctx).Table("tb_article").
+		Select("id, create_time, update_time").
+		Where("is_delete = ? AND status = ?", 0, enums.PUBLIC.Status).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
+		Order("id DESC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+// ListCategories 查询所有分类
+func (dao *sitemapDao) ListCategories(ctx context.Context) ([]dto.SitemapEntryDTO, error) {
+	var entries []dto.SitemapEntryDTO
+	err := dao.db.WithContext(ctx).Table("tb_category").
+		Select("id, create_time, update_time").
+		Order("id ASC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+// ListTags 查询所有标签
+func (dao *sitemapDao) ListTags(ctx context.Context) ([]dto.SitemapEntryDTO, error) {
+	var entries []dto.SitemapEntryDTO
+	err := dao.db.WithContext(ctx).Table("tb_tag").
+		Select("id, create_time, update_time").
+		Order("id ASC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+// ListTalks 查询公开且发布时间已到的说说
+func (dao *sitemapDao) ListTalks(ctx context.Context) ([]dto.SitemapEntryDTO, error) {
+	var entries []dto.SitemapEntryDTO
+	err := dao.db.WithContext(ctx).Table("tb_talk").
+		Select("id, create_time, update_time").
+		Where("status = ?", enums.Public).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
+		Order("id DESC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+// ListPhotoAlbums 查询未删除的公开相册，私密相册不收录
+func (dao *sitemapDao) ListPhotoAlbums(ctx context.Context) ([]dto.SitemapEntryDTO, error) {
+	var entries []dto.SitemapEntryDTO
+	err := dao.db.WithContext(ctx).Table("tb_photo_album").
+		Select("id, create_time, update_time").
+		Where("is_delete = ? AND status = ?", 0, enums.PUBLIC_STATUS.Status).
+		Order("id DESC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+// ListPages 查询所有页面
+func (dao *sitemapDao) ListPages(ctx context.Context) ([]dto.SitemapEntryDTO, error) {
+	var entries []dto.SitemapEntryDTO
+	err := dao.db.WithContext(ctx).Table("tb_page").
+		Select("id, page_label AS label, create_time, update_time").
+		Order("id ASC").
+		Scan(&entries).Error
+	return entries, err
+}
@@ -3,9 +3,11 @@ package dao
 import (
 	"context"
 	"goBolg/dto"
+	"goBolg/enums"
 	"goBolg/model"
 	"goBolg/vo"
 	"gorm.io/gorm"
+	"time"
 )
 
 // TalkDao 说说 DAO 接口
@@ -32,6 +34,18 @@ type TalkDao interface {
 	DeleteTalks(ctx context.Context, talkIdList []uint) error
 
 	CountAll(ctx context.Context) (int, error)
+
+	// 查询已发布的公开说说总量
+	CountPublicTalks(ctx context.Context) (int, error)
+
+	// 保存或修改说说
+	SaveOrUpdateTalk(ctx context.Context, talk *model.Talk) error
+
+	// 发布已到期的定时说说
+	PublishDueTalks(ctx context.Context, now time.Time) (int64, error)
+
+	// 取消说说的定时发布
+	CancelTalkSchedule(ctx context.Context, talkId int, now time.Time) (int64, error)
 }
 
 type talkDao struct {
@@ -61,6 +75,7 @@ func (dao *talkDao) ListTalks(ctx context.Context, current, size int) ([]dto.Tal
 				JOIN tb_user_info ui ON t.user_id = ui.id
 			WHERE
 				t.status = 1
+				AND (t.publish_time IS NULL OR t.publish_time <= NOW())
 			ORDER BY
 				t.is_top DESC,
 				t.id DESC
@@ -78,34 +93,15 @@ func (dao *talkDao) ListTalks(ctx context.Context, current, size int) ([]dto.Tal
 func (dao *talkDao) ListBackTalks(ctx context.Context, current, size int, condition vo.ConditionVO) ([]dto.TalkBackDTO, error) {
 	var talks []dto.TalkBackDTO
 	query := dao.db.WithContext(ctx).
-		Raw(`
-			SELECT
-				t.id,
-				nickname,
-				avatar,
-				content,
-				images,
-				t.is_top,
-				t.status,
-				t.create_time
-			FROM
-				tb_talk t
-				JOIN tb_user_info ui ON t.user_id = ui.id
-			WHERE
-				1 = 1
-		`)
-
+		Table("tb_talk t").
+		Select("t.id, nickname, avatar, content, images, t.is_top, t.status, t.create_time, t.publish_time").
+		Joins("JOIN tb_user_info ui ON t.user_id = ui.id")
 	if condition.Status != nil {
 		query = query.Where("t.status = ?", *condition.Status)
 	}
-
-	query = query.Order("t.is_top DESC, t.id DESC").
-		Offset(current).
-		Limit(size).
-		Find(&talks)
-
-	if query.Error != nil {
-		return nil, query.Error
+	err := query.Order("t.is_top DESC, t.id DESC").Offset(current).Limit(size).Scan(&talks).Error
+	if err != nil {
+		return nil, err
 	}
 	return talks, nil
 }
@@ -128,6 +124,7 @@ func (dao *talkDao) GetTalkById(ctx context.Context, talkId int) (*dto.TalkDTO,
 			WHERE
 				t.id = ?
 				AND t.status = 1
+				AND (t.publish_time IS NULL OR t.publish_time <= NOW())
 		`, talkId).
 		Scan(&talk).Error
 
@@ -150,7 +147,8 @@ func (dao *talkDao) GetBackTalkById(ctx context.Context, talkId uint) (*dto.Talk
 				images,
 				t.is_top,
 				t.status,
-				t.create_time
+				t.create_time,
+				t.publish_time
 			FROM
 				tb_talk t
 				JOIN tb_user_info ui ON t.user_id = ui.id
@@ -170,6 +168,7 @@ func (dao *talkDao) ListLatestTalks(ctx context.Context) ([]string, error) {
 	var talks []model.Talk
 	err := dao.db.WithContext(ctx).
 		Where("status = ?", 1).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
 		Order("is_top DESC, id DESC").
 		Limit(10).
 		Find(&talks).Error
@@ -219,3 +218,46 @@ func (dao *talkDao) CountAll(ctx context.Context) (int, error) {
 	}
 	return int(count), nil
 }
+
+// CountPublicTalks 查询已发布的公开说说总量
+func (dao *talkDao) CountPublicTalks(ctx context.Context) (int, error) {
+	var count int64
+	err := dao.db.WithContext(ctx).Model(&model.Talk{}).
+		Where("status = ?", enums.Public).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// SaveOrUpdateTalk 保存或修改说说，修改时不覆盖用户和创建时间
+func (dao *talkDao) SaveOrUpdateTalk(ctx context.Context, talk *model.Talk) error {
+	if talk.ID == 0 {
+		return dao.db.WithContext(ctx).Create(talk).Error
+	}
+	return dao.db.WithContext(ctx).Model(talk).
+		Select("content", "images", "is_top", "status", "publish_time", "update_time").
+		Where("id = ?", talk.ID).
+		Updates(talk).Error
+}
+
+// PublishDueTalks 将发布时间已到的草稿说说改为公开，返回发布的数量
+func (dao *talkDao) PublishDueTalks(ctx context.Context, now time.Time) (int64, error) {
+	result := dao.db.WithContext(ctx).Model(&model.Talk{}).
+		Where("status = ? AND publish_time IS NOT NULL AND publish_time <= ?", enums.Draft, now).
+		UpdateColumns(map[string]interface{}{
+			"status":      enums.Public,
+			"update_time": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CancelTalkSchedule 清除尚未到期的定时发布时间，说说保持草稿状态，返回修改的数量
+func (dao *talkDao) CancelTalkSchedule(ctx context.Context, talkId int, now time.Time) (int64, error) {
+	result := dao.db.WithContext(ctx).Model(&model.Talk{}).
+		Where("id = ? AND status = ? AND publish_time > ?", talkId, enums.Draft, now).
+		UpdateColumn("publish_time", nil)
+	return result.RowsAffected, result.Error
+}
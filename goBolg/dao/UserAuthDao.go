@@ -20,6 +20,7 @@ type UserAuthDao interface {
 	FindIpSourceById(ctx context.Context, id uint) (string, error)
 	SelectUserByUsername(ctx context.Context, username string) (*model.UserAuth, error)
 	UpdateById(ctx context.Context, userAuth *model.UserAuth) error
+	ListUserAuthIdsByUserInfoId(ctx context.Context, userInfoId int) ([]int, error)
 }
 
 // uniqueViewDao 实现 UserAuthDao 接口
@@ -32,25 +33,16 @@ func NewUserAuthDao(db *gorm.DB) UserAuthDao {
 	return &userAuthDao{db: db}
 }
 
-// ListUsers 查询后台用户列表
+// ListUsers 查询后台用户列表，角色由调用方另行查询
 func (dao *userAuthDao) ListUsers(ctx context.Context, current, size int, condition vo.ConditionVO) ([]dto.UserBackDTO, error) {
 	var users []dto.UserBackDTO
 	query := dao.db.WithContext(ctx).
-		Table("tb_user_info").
-		Select("ui.id, ui.avatar, ui.nickname, ui.is_disable, ua.user_info_id, ua.avatar, ua.nickname, ua.login_type, r.id as role_id, r.role_name, ua.ip_address, ua.ip_source, ua.create_time, ua.last_login_time").
-		Joins("LEFT JOIN tb_user_auth ua ON ua.user_info_id = ui.id").
-		Joins("LEFT JOIN tb_user_role ur ON ui.id = ur.user_id").
-		Joins("LEFT JOIN tb_role r ON ur.role_id = r.id").
-		Where("1 = 1")
-
-	if condition.LoginType != nil {
-		query = query.Where("ua.login_type = ?", *condition.LoginType)
-	}
-	if condition.Keywords != nil {
-		query = query.Where("ui.nickname LIKE ?", "%"+*condition.Keywords+"%")
-	}
+		Table("tb_user_auth ua").
+		Select("ua.id, ua.user_info_id, ui.avatar, ui.nickname, ua.login_type, ua.ip_address, ua.ip_source, ua.create_time, ua.last_login_time, ui.is_disable").
+		Joins("LEFT JOIN tb_user_info ui ON ua.user_info_id = ui.id")
+	query = dao.userCondition(query, condition)
 
-	err := query.Offset(current).Limit(size).Order("ui.id").Find(&users).Error
+	err := query.Order("ua.id DESC").Offset(current).Limit(size).Scan(&users).Error
 	if err != nil {
 		return nil, err
 	}
@@ -61,16 +53,9 @@ func (dao *userAuthDao) ListUsers(ctx context.Context, current, size int, condit
 func (dao *userAuthDao) CountUser(ctx context.Context, condition vo.ConditionVO) (int64, error) {
 	var count int64
 	query := dao.db.WithContext(ctx).
-		Table("tb_user_auth").
-		Joins("LEFT JOIN tb_user_info ui ON tb_user_auth.user_info_id = ui.id").
-		Where("1 = 1")
-
-	if condition.Keywords != nil {
-		query = query.Where("ui.nickname LIKE ?", "%"+*condition.Keywords+"%")
-	}
-	if condition.LoginType != nil {
-		query = query.Where("tb_user_auth.login_type = ?", *condition.LoginType)
-	}
+		Table("tb_user_auth ua").
+		Joins("LEFT JOIN tb_user_info ui ON ua.user_info_id = ui.id")
+	query = dao.userCondition(query, condition)
 
 	err := query.Count(&count).Error
 	if err != nil {
@@ -79,6 +64,33 @@ func (dao *userAuthDao) CountUser(ctx context.Context, condition vo.ConditionVO)
 	return count, nil
 }
 
+// userCondition 拼接后台用户的查询条件：登录方式、昵称关键字和注册时间范围
+func (dao *userAuthDao) userCondition(query *gorm.DB, condition vo.ConditionVO) *gorm.DB {
+	if condition.LoginType != nil && *condition.LoginType != "" {
+		query = query.Where("ua.login_type = ?", *condition.LoginType)
+	}
+	if condition.Keywords != nil && *condition.Keywords != "" {
+		query = query.Where("ui.nickname LIKE ?", "%"+*condition.Keywords+"%")
+	}
+	if condition.StartTime != nil {
+		query = query.Where("ua.create_time >= ?", *condition.StartTime)
+	}
+	if condition.EndTime != nil {
+		query = query.Where("ua.create_time <= ?", *condition.EndTime)
+	}
+	return query
+}
+
+// ListUserAuthIdsByUserInfoId 查询用户信息对应的全部账号 id
+func (dao *userAuthDao) ListUserAuthIdsByUserInfoId(ctx context.Context, userInfoId int) ([]int, error) {
+	var ids []int
+	err := dao.db.WithContext(ctx).
+		Model(&model.UserAuth{}).
+		Where("user_info_id = ?", userInfoId).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
 // UpdateUserAuth 更新用户认证信息
 func (dao *userAuthDao) UpdateUserAuth(ctx context.Context, userAuth *model.UserAuth) error {
 	return dao.db.WithContext(ctx).Save(userAuth).Error
@@ -0,0 +1,70 @@
+package dao
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"goBolg/vo"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB 使用 sqlmock 创建 gorm 连接
+func newTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open returned error: %v", err)
+	}
+	return db, mock
+}
+
+func TestListUsers(t *testing.T) {
+	keywords := "felix"
+	tests := []struct {
+		name      string
+		condition vo.ConditionVO
+		args      []driver.Value
+	}{
+		{"无查询条件", vo.ConditionVO{}, []driver.Value{10}},
+		{"按昵称查询", vo.ConditionVO{Keywords: &keywords}, []driver.Value{"%felix%", 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newTestDB(t)
+			now := time.Now()
+			rows := sqlmock.NewRows([]string{"id", "user_info_id", "avatar", "nickname", "login_type", "ip_address", "ip_source", "create_time", "last_login_time", "is_disable"}).
+				AddRow(2, 12, "avatar.png", "felix", 1, "127.0.0.1", "本地", now, now, 0).
+				AddRow(1, 11, "admin.png", "admin", 1, "127.0.0.1", "本地", now, now, 1)
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT ua.id, ua.user_info_id, ui.avatar, ui.nickname, ua.login_type, ua.ip_address, ua.ip_source, ua.create_time, ua.last_login_time, ui.is_disable FROM tb_user_auth ua LEFT JOIN tb_user_info ui ON ua.user_info_id = ui.id")).
+				WithArgs(tt.args...).
+				WillReturnRows(rows)
+
+			users, err := NewUserAuthDao(db).ListUsers(context.Background(), 0, 10, tt.condition)
+			if err != nil {
+				t.Fatalf("ListUsers returned error: %v", err)
+			}
+			if len(users) != 2 {
+				t.Fatalf("ListUsers returned %d users, want 2", len(users))
+			}
+			if users[0].ID != 2 || users[0].UserInfoID != 12 || users[0].Nickname != "felix" || users[1].IsDisable != 1 {
+				t.Errorf("ListUsers returned %+v", users)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
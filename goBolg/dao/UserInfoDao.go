@@ -20,6 +20,8 @@ type UserInfoDao interface {
 
 	UpdateUserInfo(ctx context.Context, userInfo *model.UserInfo) error
 
+	UpdateUserDisable(ctx context.Context, userId int, isDisable int) error
+
 	// 新增：根据用户名查询用户信息
 	GetUserByUsername(ctx context.Context, username string) (*model.UserInfo, error)
 }
@@ -75,6 +77,17 @@ func (dao *userInfoDaoImpl) UpdateUserInfo(ctx context.Context, userInfo *model.
 	return dao.db.WithContext(ctx).Model(&userInfo).Omit("CreateTime").Updates(userInfo).Error
 }
 
+// UpdateUserDisable 修改用户禁用状态
+func (dao *userInfoDaoImpl) UpdateUserDisable(ctx context.Context, userId int, isDisable int) error {
+	return dao.db.WithContext(ctx).
+		Model(&model.UserInfo{}).
+		Where("id = ?", userId).
+		Updates(map[string]interface{}{
+			"is_disable":  isDisable,
+			"update_time": time.Now(),
+		}).Error
+}
+
 // 新增：根据用户名查询用户信息
 func (dao *userInfoDaoImpl) GetUserByUsername(ctx context.Context, username string) (*model.UserInfo, error) {
 	var user model.UserInfo
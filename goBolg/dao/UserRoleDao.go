@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"goBolg/dto"
 	"goBolg/model"
 	"gorm.io/gorm"
 )
@@ -11,6 +12,10 @@ type UserRoleDao interface {
 	CountRolesWithUsers(ctx context.Context, roleIdList []int) (int64, error)
 
 	InsertUserRole(ctx context.Context, userRole *model.UserRole) error
+
+	ListUserRolesByUserIds(ctx context.Context, userIdList []int) (map[int][]dto.UserRoleDTO, error)
+
+	UpdateUserRoles(ctx context.Context, userId int, roleIdList []int) error
 }
 
 // userRoleDaoImpl 实现 UserRoleDao 接口
@@ -36,3 +41,46 @@ func (dao *userRoleDaoImpl) CountRolesWithUsers(ctx context.Context, roleIdList
 func (dao *userRoleDaoImpl) InsertUserRole(ctx context.Context, userRole *model.UserRole) error {
 	return dao.db.WithContext(ctx).Create(userRole).Error
 }
+
+// ListUserRolesByUserIds 按用户信息 id 查询用户的角色
+func (dao *userRoleDaoImpl) ListUserRolesByUserIds(ctx context.Context, userIdList []int) (map[int][]dto.UserRoleDTO, error) {
+	userRoleMap := make(map[int][]dto.UserRoleDTO)
+	if len(userIdList) == 0 {
+		return userRoleMap, nil
+	}
+	var rows []struct {
+		UserID   int
+		ID       int
+		RoleName string
+	}
+	err := dao.db.WithContext(ctx).Table("tb_user_role ur").
+		Select("ur.user_id, r.id, r.role_name").
+		Joins("JOIN tb_role r ON ur.role_id = r.id").
+		Where("ur.user_id IN ?", userIdList).
+		Order("ur.user_id ASC, r.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		userRoleMap[row.UserID] = append(userRoleMap[row.UserID], dto.UserRoleDTO{ID: row.ID, RoleName: row.RoleName})
+	}
+	return userRoleMap, nil
+}
+
+// UpdateUserRoles 在同一事务中替换用户的全部角色
+func (dao *userRoleDaoImpl) UpdateUserRoles(ctx context.Context, userId int, roleIdList []int) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userId).Delete(&model.UserRole{}).Error; err != nil {
+			return err
+		}
+		if len(roleIdList) == 0 {
+			return nil
+		}
+		userRoles := make([]model.UserRole, 0, len(roleIdList))
+		for _, roleId := range roleIdList {
+			userRoles = append(userRoles, model.UserRole{UserID: userId, RoleID: roleId})
+		}
+		return tx.Create(&userRoles).Error
+	})
+}
@@ -6,16 +6,17 @@ import (
 
 // ArticleBackDTO 代表后台文章的 DTO
 type ArticleBackDTO struct {
-	ID           int       `json:"id"`
-	ArticleCover string    `json:"articleCover"`
-	ArticleTitle string    `json:"articleTitle"`
-	CreateTime   time.Time `json:"createTime"`
-	LikeCount    int       `json:"likeCount"`
-	ViewsCount   int       `json:"viewsCount"`
-	CategoryName string    `json:"categoryName"`
-	TagDTOList   []TagDTO  `json:"tagDTOList" gorm:"-"`
-	Type         int       `json:"type"`
-	IsTop        int       `json:"isTop"`
-	IsDelete     int       `json:"isDelete"`
-	Status       int       `json:"status"`
+	ID           int        `json:"id"`
+	ArticleCover string     `json:"articleCover"`
+	ArticleTitle string     `json:"articleTitle"`
+	CreateTime   time.Time  `json:"createTime"`
+	LikeCount    int        `json:"likeCount"`
+	ViewsCount   int        `json:"viewsCount"`
+	CategoryName string     `json:"categoryName"`
+	TagDTOList   []TagDTO   `json:"tagDTOList" gorm:"-"`
+	Type         int        `json:"type"`
+	IsTop        int        `json:"isTop"`
+	IsDelete     int        `json:"isDelete"`
+	Status       int        `json:"status"`
+	PublishTime  *time.Time `json:"publishTime"` // 定时发布时间
 }
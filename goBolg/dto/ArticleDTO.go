@@ -20,6 +20,7 @@ type ArticleDTO struct {
 	TagDTOList           []TagDTO                `json:"tagDTOList" gorm:"many2many:tb_article_tag"`
 	LastArticle          *ArticlePaginationDTO   `json:"lastArticle"`
 	NextArticle          *ArticlePaginationDTO   `json:"nextArticle"`
+	Series               *ArticleSeriesDTO       `json:"series" gorm:"-"`
 	RecommendArticleList ArticleRecommendDTOList `json:"recommendArticleList"`
 	NewestArticleList    ArticleRecommendDTOList `json:"newestArticleList"`
 	UserLiked            bool                    `json:"userLiked"`
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// ArticleFeedDTO 代表订阅源中的文章
+type ArticleFeedDTO struct {
+	ID             int        `json:"id"`                   // 文章id
+	ArticleTitle   string     `json:"articleTitle"`         // 文章标题
+	ArticleContent string     `json:"articleContent"`       // 文章内容
+	ArticleCover   string     `json:"articleCover"`         // 文章封面
+	CategoryName   string     `json:"categoryName"`         // 分类名
+	TagNameList    []string   `json:"tagNameList" gorm:"-"` // 标签名
+	CreateTime     time.Time  `json:"createTime"`           // 发表时间
+	UpdateTime     *time.Time `json:"updateTime"`           // 更新时间
+}
@@ -0,0 +1,10 @@
+package dto
+
+// ArticleImportDTO 代表单个文件的导入结果
+type ArticleImportDTO struct {
+	FileName     string `json:"fileName"`            // 文件名
+	ArticleID    int    `json:"articleId,omitempty"` // 文章id
+	ArticleTitle string `json:"articleTitle"`        // 文章标题
+	Result       string `json:"result"`              // 导入结果 created/updated/skipped
+	Reason       string `json:"reason,omitempty"`    // 跳过原因
+}
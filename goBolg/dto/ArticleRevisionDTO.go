@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// ArticleRevisionDTO 代表文章历史版本
+type ArticleRevisionDTO struct {
+	ID           int       `json:"id"`           // 版本id
+	ArticleID    int       `json:"articleId"`    // 文章id
+	UserID       int       `json:"userId"`       // 修改人id
+	ArticleTitle string    `json:"articleTitle"` // 文章标题
+	CategoryName string    `json:"categoryName"` // 分类名
+	TagNameList  []string  `json:"tagNameList"`  // 标签名
+	Status       int       `json:"status"`       // 文章状态
+	CreateTime   time.Time `json:"createTime"`   // 创建时间
+}
+
+// ArticleRevisionDiffDTO 代表两个历史版本之间的差异
+type ArticleRevisionDiffDTO struct {
+	OldRevision ArticleRevisionDTO `json:"oldRevision"` // 旧版本
+	NewRevision ArticleRevisionDTO `json:"newRevision"` // 新版本
+	LineList    []DiffLineDTO      `json:"lineList"`    // 逐行差异
+}
+
+// DiffLineDTO 代表一行差异
+type DiffLineDTO struct {
+	Type    string `json:"type"`              // equal/insert/delete
+	OldLine int    `json:"oldLine,omitempty"` // 旧版本行号
+	NewLine int    `json:"newLine,omitempty"` // 新版本行号
+	Content string `json:"content"`           // 行内容
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+// CachedContentDTO 代表缓存的生成内容及其协商缓存信息
+type CachedContentDTO struct {
+	Content      string    `json:"content"`      // 生成的内容
+	ETag         string    `json:"etag"`         // 内容摘要
+	LastModified time.Time `json:"lastModified"` // 最后修改时间
+}
@@ -4,13 +4,24 @@ import "time"
 
 // CommentBackDTO 代表后台评论 DTO
 type CommentBackDTO struct {
-	ID             int       `json:"id"`             // 评论 ID
-	Avatar         string    `json:"avatar"`         // 用户头像
-	Nickname       string    `json:"nickname"`       // 用户昵称
-	ReplyNickname  string    `json:"replyNickname"`  // 被回复用户昵称
-	ArticleTitle   string    `json:"articleTitle"`   // 文章标题
-	CommentContent string    `json:"commentContent"` // 评论内容
-	Type           int       `json:"type"`           // 评论类型
-	IsReview       int       `json:"isReview"`       // 是否审核
-	CreateTime     time.Time `json:"createTime"`     // 发表时间
+	ID             int                  `json:"id"`                    // 评论 ID
+	Avatar         string               `json:"avatar"`                // 用户头像
+	Nickname       string               `json:"nickname"`              // 用户昵称
+	ReplyNickname  string               `json:"replyNickname"`         // 被回复用户昵称
+	ArticleTitle   string               `json:"articleTitle"`          // 文章标题
+	CommentContent string               `json:"commentContent"`        // 评论内容
+	Type           int                  `json:"type"`                  // 评论类型
+	IsReview       int                  `json:"isReview"`              // 是否审核
+	IsDelete       int                  `json:"isDelete"`              // 是否被用户删除
+	CreateTime     time.Time            `json:"createTime"`            // 发表时间
+	EditTime       *time.Time           `json:"editTime"`              // 最后编辑时间，未编辑过为空
+	SpamScore      int                  `json:"spamScore"`             // 垃圾内容评分
+	RevisionList   []CommentRevisionDTO `json:"revisionList" gorm:"-"` // 编辑历史，按时间倒序
+}
+
+// CommentRevisionDTO 代表评论编辑前的内容
+type CommentRevisionDTO struct {
+	ID             int       `json:"id"`             // 版本 ID
+	CommentContent string    `json:"commentContent"` // 编辑前的评论内容
+	CreateTime     time.Time `json:"createTime"`     // 编辑时间
 }
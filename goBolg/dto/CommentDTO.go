@@ -12,6 +12,7 @@ type CommentDTO struct {
 	CommentContent string     `json:"commentContent"`                                        // 评论内容
 	LikeCount      int        `json:"likeCount"`                                             // 点赞数
 	CreateTime     time.Time  `json:"createTime"`                                            // 评论时间
+	IsEdited       bool       `json:"isEdited"`                                              // 是否编辑过
 	ReplyCount     int        `json:"replyCount"`                                            // 回复量
 	ReplyDTOList   []ReplyDTO `json:"replyDTOList" gorm:"foreignKey:ParentID;references:ID"` // 回复列表
 }
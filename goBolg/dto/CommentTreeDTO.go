@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// CommentTreeDTO 评论树中的节点
+type CommentTreeDTO struct {
+	ID             int               `json:"id"`                   // 评论 id
+	ParentID       int               `json:"parentId"`             // 父评论 id，顶层评论为 0，旧数据为按被回复用户找到的父评论
+	UserID         int               `json:"userId"`               // 用户 id
+	Nickname       string            `json:"nickname"`             // 用户昵称
+	Avatar         string            `json:"avatar"`               // 用户头像
+	WebSite        string            `json:"webSite"`              // 个人网站
+	ReplyUserID    int               `json:"replyUserId"`          // 被回复用户 id
+	ReplyNickname  string            `json:"replyNickname"`        // 被回复用户昵称
+	ReplyWebSite   string            `json:"replyWebSite"`         // 被回复个人网站
+	CommentContent string            `json:"commentContent"`       // 评论内容
+	LikeCount      int               `json:"likeCount"`            // 点赞数
+	CreateTime     time.Time         `json:"createTime"`           // 评论时间
+	IsEdited       bool              `json:"isEdited"`             // 是否编辑过
+	Depth          int               `json:"depth"`                // 所在层级，顶层评论为 0
+	ReplyCount     int               `json:"replyCount"`           // 全部后代回复的数量
+	Children       []*CommentTreeDTO `json:"children" gorm:"-"`    // 已加载的子评论
+	HasMore        bool              `json:"hasMore"`              // 是否还有未加载的子评论
+	NextCursor     string            `json:"nextCursor,omitempty"` // 加载更多子评论的游标，为空时从第一条开始加载
+}
+
+// CommentTreePageDTO 游标分页的评论树
+type CommentTreePageDTO struct {
+	RecordList []*CommentTreeDTO `json:"recordList"`           // 当前页的评论
+	Count      int               `json:"count"`                // 包含全部回复的评论总数
+	HasMore    bool              `json:"hasMore"`              // 是否还有下一页
+	NextCursor string            `json:"nextCursor,omitempty"` // 下一页的游标
+}
+
+// CommentTreeLinkDTO 评论树中的父子关系，用于组装评论树和统计回复数量
+type CommentTreeLinkDTO struct {
+	ID          int       `json:"id"`          // 评论 id
+	ParentID    int       `json:"parentId"`    // 父评论 id，顶层评论为 0
+	RootID      int       `json:"rootId"`      // 所属顶层评论 id
+	UserID      int       `json:"userId"`      // 用户 id
+	ReplyUserID int       `json:"replyUserId"` // 被回复用户 id
+	CreateTime  time.Time `json:"createTime"`  // 评论时间
+}
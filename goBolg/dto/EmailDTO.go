@@ -1,8 +1,56 @@
 package dto
 
-// EmailDTO 代表邮件 DTO
+import "time"
+
+// EmailDTO 代表邮件 DTO，指定模板时按模板渲染正文，否则使用 Content 作为纯文本正文
 type EmailDTO struct {
-	Email   string `json:"email"`   // 邮箱号
-	Subject string `json:"subject"` // 主题
-	Content string `json:"content"` // 内容
+	Email    string                 `json:"email"`              // 邮箱号
+	Subject  string                 `json:"subject"`            // 主题，使用模板时为空表示使用模板的主题
+	Content  string                 `json:"content"`            // 内容
+	Template string                 `json:"template,omitempty"` // 模板名称
+	Data     map[string]interface{} `json:"data,omitempty"`     // 模板变量
+}
+
+// EmailContentDTO 渲染后的邮件内容
+type EmailContentDTO struct {
+	Subject string // 主题
+	HTML    string // HTML 正文，为空时只发送纯文本
+	Text    string // 纯文本正文
+}
+
+// EmailSiteDTO 邮件模板中的站点信息
+type EmailSiteDTO struct {
+	Name   string // 站点名称
+	Avatar string // 站点头像
+	URL    string // 站点地址
+}
+
+// EmailTemplateDataDTO 邮件模板的渲染数据，模板中通过 .Site 和 .Data 访问
+type EmailTemplateDataDTO struct {
+	Site EmailSiteDTO
+	Data map[string]interface{}
+}
+
+// EmailTemplateDTO 后台邮件模板
+type EmailTemplateDTO struct {
+	Name        string   `json:"name"`        // 模板名称
+	Desc        string   `json:"desc"`        // 模板描述
+	Variables   []string `json:"variables"`   // 可以通过 .Data 使用的变量
+	Subject     string   `json:"subject"`     // 主题模板
+	HTMLContent string   `json:"htmlContent"` // HTML 正文模板
+	TextContent string   `json:"textContent"` // 纯文本正文模板
+	IsCustom    bool     `json:"isCustom"`    // 是否为自定义模板
+}
+
+// EmailDeadLetterDTO 死信队列中的邮件
+type EmailDeadLetterDTO struct {
+	Email      string                 `json:"email"`              // 邮箱号
+	Subject    string                 `json:"subject"`            // 主题
+	Content    string                 `json:"content"`            // 内容
+	Template   string                 `json:"template,omitempty"` // 模板名称
+	Data       map[string]interface{} `json:"data,omitempty"`     // 模板变量
+	RetryCount int                    `json:"retryCount"`         // 已重试的次数
+	Error      string                 `json:"error"`              // 最后一次发送失败的原因
+	DeadTime   time.Time              `json:"deadTime"`           // 移入死信队列的时间
+	Body       string                 `json:"body,omitempty"`     // 无法解析的原始消息
 }
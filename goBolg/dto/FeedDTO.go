@@ -0,0 +1,7 @@
+package dto
+
+// FeedDTO 代表生成好的订阅源
+type FeedDTO struct {
+	CachedContentDTO
+	ContentType string `json:"contentType"` // 内容类型
+}
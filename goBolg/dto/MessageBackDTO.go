@@ -14,4 +14,5 @@ type MessageBackDTO struct {
 	MessageContent string    `json:"messageContent"` // 留言内容
 	IsReview       int       `json:"isReview"`       // 是否审核
 	CreateTime     time.Time `json:"createTime"`     // 留言时间
+	SpamScore      int       `json:"spamScore"`      // 垃圾内容评分
 }
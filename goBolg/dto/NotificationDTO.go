@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// NotificationEventDTO 触发通知的事件
+type NotificationEventDTO struct {
+	UserID     int    // 接收用户 id
+	FromUserID int    // 触发用户 id，系统通知为 0
+	Type       int    // 通知类型
+	TargetID   int    // 关联的评论、文章或说说 id
+	Content    string // 通知内容
+	Path       string // 站内页面路径
+}
+
+// NotificationDTO 站内通知
+type NotificationDTO struct {
+	ID           int       `json:"id"`           // 通知 id
+	Type         int       `json:"type"`         // 通知类型
+	FromUserID   int       `json:"fromUserId"`   // 触发用户 id
+	FromNickname string    `json:"fromNickname"` // 触发用户昵称
+	FromAvatar   string    `json:"fromAvatar"`   // 触发用户头像
+	TargetID     int       `json:"targetId"`     // 关联的评论、文章或说说 id
+	Content      string    `json:"content"`      // 通知内容
+	Path         string    `json:"path"`         // 站内页面路径
+	IsRead       int       `json:"isRead"`       // 是否已读
+	CreateTime   time.Time `json:"createTime"`   // 通知时间
+}
+
+// NotificationSettingDTO 通知接收设置
+type NotificationSettingDTO struct {
+	Type    int    `json:"type"`    // 通知类型
+	Desc    string `json:"desc"`    // 通知类型描述
+	IsEmail int    `json:"isEmail"` // 是否发送邮件
+	IsInApp int    `json:"isInApp"` // 是否发送站内通知
+}
@@ -16,4 +16,5 @@ type ReplyDTO struct {
 	CommentContent string    `json:"commentContent"` // 评论内容
 	LikeCount      int       `json:"likeCount"`      // 点赞数
 	CreateTime     time.Time `json:"createTime"`     // 评论时间
+	IsEdited       bool      `json:"isEdited"`       // 是否编辑过
 }
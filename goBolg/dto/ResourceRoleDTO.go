@@ -5,5 +5,6 @@ type ResourceRoleDTO struct {
 	ID            int      `json:"id"`            // 资源id
 	URL           string   `json:"url"`           // 路径
 	RequestMethod string   `json:"requestMethod"` // 请求方式
+	IsAnonymous   int      `json:"isAnonymous"`   // 是否匿名访问
 	RoleList      []string `json:"roleList"`      // 角色名
 }
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// SensitiveWordDTO 后台敏感词
+type SensitiveWordDTO struct {
+	ID         int        `json:"id"`         // 敏感词id
+	Word       string     `json:"word"`       // 敏感词
+	Action     int        `json:"action"`     // 处理方式 1.替换 2.拒绝 3.审核
+	CreateTime time.Time  `json:"createTime"` // 创建时间
+	UpdateTime *time.Time `json:"updateTime"` // 修改时间
+}
+
+// SensitiveWordImportDTO 敏感词导入结果
+type SensitiveWordImportDTO struct {
+	SuccessCount int `json:"successCount"` // 新增或更新的数量
+	SkipCount    int `json:"skipCount"`    // 空行、重复或过长而跳过的数量
+}
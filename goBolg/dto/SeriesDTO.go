@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// SeriesDTO 代表前台展示的文章系列
+type SeriesDTO struct {
+	ID           int                    `json:"id"`           // 系列id
+	SeriesTitle  string                 `json:"seriesTitle"`  // 系列标题
+	SeriesDesc   string                 `json:"seriesDesc"`   // 系列描述
+	SeriesCover  string                 `json:"seriesCover"`  // 系列封面
+	ArticleCount int                    `json:"articleCount"` // 公开文章数量
+	ArticleList  []ArticlePaginationDTO `json:"articleList"`  // 按顺序排列的公开文章
+}
+
+// SeriesBackDTO 代表后台展示的文章系列
+type SeriesBackDTO struct {
+	ID          int                    `json:"id"`          // 系列id
+	SeriesTitle string                 `json:"seriesTitle"` // 系列标题
+	SeriesDesc  string                 `json:"seriesDesc"`  // 系列描述
+	SeriesCover string                 `json:"seriesCover"` // 系列封面
+	ArticleList []ArticlePaginationDTO `json:"articleList"` // 按顺序排列的全部文章
+	CreateTime  time.Time              `json:"createTime"`  // 创建时间
+	UpdateTime  *time.Time             `json:"updateTime"`  // 更新时间
+}
+
+// SeriesArticleDTO 代表系列中的一篇文章
+type SeriesArticleDTO struct {
+	SeriesID     int       `json:"seriesId"`     // 系列id
+	ID           int       `json:"id"`           // 文章id
+	ArticleTitle string    `json:"articleTitle"` // 文章标题
+	ArticleCover string    `json:"articleCover"` // 文章封面
+	CreateTime   time.Time `json:"createTime"`   // 创建时间
+}
+
+// ArticleSeriesDTO 代表文章详情中的系列导航
+type ArticleSeriesDTO struct {
+	ID          int                   `json:"id"`          // 系列id
+	SeriesTitle string                `json:"seriesTitle"` // 系列标题
+	Position    int                   `json:"position"`    // 当前文章在系列中的位置，从 1 开始
+	Total       int                   `json:"total"`       // 系列中的公开文章数量
+	LastArticle *ArticlePaginationDTO `json:"lastArticle"` // 系列中的上一篇
+	NextArticle *ArticlePaginationDTO `json:"nextArticle"` // 系列中的下一篇
+}
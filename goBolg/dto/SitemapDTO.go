@@ -0,0 +1,6 @@
+package dto
+
+// SitemapDTO 代表生成好的站点地图或站点地图索引
+type SitemapDTO struct {
+	CachedContentDTO
+}
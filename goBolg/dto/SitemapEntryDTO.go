@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// SitemapEntryDTO 代表站点地图中的一条记录
+type SitemapEntryDTO struct {
+	ID         int        `json:"id"`         // 主键
+	Label      string     `json:"label"`      // 页面标签，仅自定义页面使用
+	CreateTime time.Time  `json:"createTime"` // 创建时间
+	UpdateTime *time.Time `json:"updateTime"` // 更新时间
+}
+
+// LastMod 返回最后修改时间，未修改过时使用创建时间
+func (entry SitemapEntryDTO) LastMod() time.Time {
+	if entry.UpdateTime != nil && entry.UpdateTime.After(entry.CreateTime) {
+		return *entry.UpdateTime
+	}
+	return entry.CreateTime
+}
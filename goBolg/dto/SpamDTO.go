@@ -0,0 +1,16 @@
+package dto
+
+// SpamCheckDTO 待评分的用户提交内容
+type SpamCheckDTO struct {
+	Content   string // 过滤后的内容
+	UserID    int    // 发布用户 id，游客为 0
+	IPAddress string // 发布者 ip
+}
+
+// SpamScoreDTO 垃圾内容评分结果
+type SpamScoreDTO struct {
+	Score      int      // 0 到 100 的评分，越高越可能是垃圾内容
+	NeedReview bool     // 是否需要转为人工审核
+	Rejected   bool     // 是否需要拒绝提交
+	Signals    []string // 命中的信号及其得分
+}
@@ -0,0 +1,8 @@
+package dto
+
+// TokenDTO 登录令牌
+type TokenDTO struct {
+	Token        string `json:"token"`        // 访问令牌
+	RefreshToken string `json:"refreshToken"` // 刷新令牌，使用一次后失效
+	ExpiresIn    int    `json:"expiresIn"`    // 访问令牌有效期，单位秒
+}
@@ -1,15 +1,18 @@
 package enums
 
+import "strconv"
+
 type CommentTypeEnum struct {
-	Type int
-	Desc string
-	Path string
+	Type     int
+	Desc     string
+	Path     string
+	HasTopic bool // 是否关联评论主题，友链页面只有一个，评论不关联主题
 }
 
 var (
-	ARTICLE = CommentTypeEnum{1, "文章评论", "/articles/"}
-	LINK    = CommentTypeEnum{2, "友链评论", "/links/"}
-	TALK    = CommentTypeEnum{3, "说说评论", "/talks/"}
+	ARTICLE = CommentTypeEnum{1, "文章评论", "/articles/", true}
+	LINK    = CommentTypeEnum{2, "友链评论", "/links", false}
+	TALK    = CommentTypeEnum{3, "说说评论", "/talks/", true}
 )
 
 var commentTypeEnums = []CommentTypeEnum{
@@ -18,14 +21,16 @@ var commentTypeEnums = []CommentTypeEnum{
 	TALK,
 }
 
-// GetCommentPath 获取评论路径
-func GetCommentPath(commentType int) string {
-	for _, v := range commentTypeEnums {
-		if v.Type == commentType {
-			return v.Path
-		}
+// GetCommentPath 获取评论所在页面的路径，不关联主题的评论忽略主题id
+func GetCommentPath(commentType int, topicId int) string {
+	commentEnum := GetCommentEnum(commentType)
+	if commentEnum == nil {
+		return ""
+	}
+	if !commentEnum.HasTopic {
+		return commentEnum.Path
 	}
-	return ""
+	return commentEnum.Path + strconv.Itoa(topicId)
 }
 
 // GetCommentEnum 获取评论枚举
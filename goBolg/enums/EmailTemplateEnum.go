@@ -0,0 +1,32 @@
+package enums
+
+// EmailTemplateEnum 邮件模板枚举，Name 对应内置模板的文件名，Subject 为默认主题模板
+type EmailTemplateEnum struct {
+	Name      string
+	Desc      string
+	Subject   string
+	Variables []string // 模板中可以通过 .Data 使用的变量
+}
+
+// 定义邮件模板常量
+var (
+	CODE_EMAIL   = EmailTemplateEnum{"code", "验证码", "【{{.Site.Name}}】验证码", []string{"code", "expireMinutes"}}
+	REPLY_EMAIL  = EmailTemplateEnum{"reply", "回复通知", "【{{.Site.Name}}】评论提醒", []string{"url"}}
+	REVIEW_EMAIL = EmailTemplateEnum{"review", "审核提醒", "【{{.Site.Name}}】审核提醒", []string{}}
+	NOTICE_EMAIL = EmailTemplateEnum{"notice", "通知提醒", "【{{.Site.Name}}】{{.Data.title}}", []string{"title", "content", "url"}}
+)
+
+// GetEmailTemplateEnums 获取所有邮件模板
+func GetEmailTemplateEnums() []EmailTemplateEnum {
+	return []EmailTemplateEnum{CODE_EMAIL, REPLY_EMAIL, REVIEW_EMAIL, NOTICE_EMAIL}
+}
+
+// GetEmailTemplateEnum 根据模板名称获取枚举，不存在时返回 nil
+func GetEmailTemplateEnum(name string) *EmailTemplateEnum {
+	for _, templateEnum := range GetEmailTemplateEnums() {
+		if templateEnum.Name == name {
+			return &templateEnum
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package enums
+
+// FeedTypeEnum 订阅源类型枚举
+type FeedTypeEnum struct {
+	Type        string
+	FileName    string
+	ContentType string
+}
+
+// 定义订阅源类型常量
+var (
+	RSS       = FeedTypeEnum{"rss", "rss.xml", "application/rss+xml; charset=utf-8"}
+	ATOM      = FeedTypeEnum{"atom", "atom.xml", "application/atom+xml; charset=utf-8"}
+	JSON_FEED = FeedTypeEnum{"json", "feed.json", "application/feed+json; charset=utf-8"}
+)
+
+// GetFeedTypeEnums 获取所有订阅源类型枚举
+func GetFeedTypeEnums() []FeedTypeEnum {
+	return []FeedTypeEnum{RSS, ATOM, JSON_FEED}
+}
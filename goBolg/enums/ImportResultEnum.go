@@ -0,0 +1,14 @@
+package enums
+
+// ImportResultEnum 文章导入结果枚举
+type ImportResultEnum struct {
+	Result string
+	Desc   string
+}
+
+// 定义文章导入结果常量
+var (
+	IMPORT_CREATED = ImportResultEnum{"created", "新增"}
+	IMPORT_UPDATED = ImportResultEnum{"updated", "更新"}
+	IMPORT_SKIPPED = ImportResultEnum{"skipped", "跳过"}
+)
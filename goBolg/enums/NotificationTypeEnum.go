@@ -0,0 +1,35 @@
+package enums
+
+// NotificationTypeEnum 通知类型枚举，Email 和 InApp 为用户未设置时的默认接收方式
+type NotificationTypeEnum struct {
+	Type          int
+	Desc          string
+	EmailTemplate string // 发送邮件使用的模板
+	Email         bool   // 默认是否发送邮件
+	InApp         bool   // 默认是否发送站内通知
+	Merge         bool   // 同一用户对同一对象的未读通知只保留一条，避免反复点赞刷屏
+}
+
+// 定义通知类型常量
+var (
+	COMMENT_REPLY = NotificationTypeEnum{1, "评论回复", REPLY_EMAIL.Name, true, true, false}
+	COMMENT_LIKE  = NotificationTypeEnum{2, "评论点赞", NOTICE_EMAIL.Name, false, true, true}
+	ARTICLE_LIKE  = NotificationTypeEnum{3, "文章点赞", NOTICE_EMAIL.Name, false, true, true}
+	TALK_LIKE     = NotificationTypeEnum{4, "说说点赞", NOTICE_EMAIL.Name, false, true, true}
+	REVIEW_RESULT = NotificationTypeEnum{5, "评论审核结果", NOTICE_EMAIL.Name, false, true, false}
+)
+
+// GetNotificationTypeEnums 获取所有通知类型
+func GetNotificationTypeEnums() []NotificationTypeEnum {
+	return []NotificationTypeEnum{COMMENT_REPLY, COMMENT_LIKE, ARTICLE_LIKE, TALK_LIKE, REVIEW_RESULT}
+}
+
+// GetNotificationTypeEnum 根据类型获取枚举，不存在时返回 nil
+func GetNotificationTypeEnum(notificationType int) *NotificationTypeEnum {
+	for _, typeEnum := range GetNotificationTypeEnums() {
+		if typeEnum.Type == notificationType {
+			return &typeEnum
+		}
+	}
+	return nil
+}
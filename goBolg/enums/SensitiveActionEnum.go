@@ -0,0 +1,30 @@
+package enums
+
+// SensitiveActionEnum 敏感词处理方式枚举，Priority 越大越严格
+type SensitiveActionEnum struct {
+	Action   int
+	Desc     string
+	Priority int
+}
+
+// 定义敏感词处理方式常量
+var (
+	MASK   = SensitiveActionEnum{1, "替换", 1}
+	REJECT = SensitiveActionEnum{2, "拒绝", 3}
+	REVIEW = SensitiveActionEnum{3, "审核", 2}
+)
+
+// GetSensitiveActionEnums 按严格程度从高到低获取所有处理方式
+func GetSensitiveActionEnums() []SensitiveActionEnum {
+	return []SensitiveActionEnum{REJECT, REVIEW, MASK}
+}
+
+// GetSensitiveActionEnum 根据处理方式获取枚举，不存在时返回 nil
+func GetSensitiveActionEnum(action int) *SensitiveActionEnum {
+	for _, actionEnum := range GetSensitiveActionEnums() {
+		if actionEnum.Action == action {
+			return &actionEnum
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ var (
 	SUCCESS            = StatusCodeEnum{20000, "操作成功"}
 	NO_LOGIN           = StatusCodeEnum{40001, "用户未登录"}
 	AUTHORIZED         = StatusCodeEnum{40300, "没有操作权限"}
+	TOO_MANY_REQUESTS  = StatusCodeEnum{42900, "请求过于频繁，请稍后再试"}
 	SYSTEM_ERROR       = StatusCodeEnum{50000, "系统异常"}
 	FAIL               = StatusCodeEnum{51000, "操作失败"}
 	VALID_ERROR        = StatusCodeEnum{52000, "参数格式不正确"}
@@ -23,6 +24,7 @@ var StatusCodeEnums = []StatusCodeEnum{
 	SUCCESS,
 	NO_LOGIN,
 	AUTHORIZED,
+	TOO_MANY_REQUESTS,
 	SYSTEM_ERROR,
 	FAIL,
 	VALID_ERROR,
@@ -8,6 +8,8 @@ const (
 	Public TalkStatusEnum = iota + 1
 	// Secret 私密状态
 	Secret
+	// Draft 草稿状态，定时发布的说说到期前处于该状态
+	Draft
 )
 
 // String 返回 TalkStatusEnum 的描述
@@ -17,6 +19,8 @@ func (s TalkStatusEnum) String() string {
 		return "公开"
 	case Secret:
 		return "私密"
+	case Draft:
+		return "草稿"
 	default:
 		return "未知状态"
 	}
@@ -1 +1,67 @@
 package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"goBolg/constant"
+	"goBolg/enums"
+	"goBolg/utils"
+	"goBolg/vo"
+	"log"
+	"net/http"
+)
+
+// AccessDeniedHandlerImpl 按资源角色校验当前用户的访问权限
+type AccessDeniedHandlerImpl struct {
+	FilterInvocationSecurityMetadataSource *FilterInvocationSecurityMetadataSourceImpl
+}
+
+func NewAccessDeniedHandlerImpl(filterInvocationSecurityMetadataSource *FilterInvocationSecurityMetadataSourceImpl) *AccessDeniedHandlerImpl {
+	return &AccessDeniedHandlerImpl{
+		FilterInvocationSecurityMetadataSource: filterInvocationSecurityMetadataSource,
+	}
+}
+
+// Middleware 匹配请求对应的资源，匿名资源直接放行，其余资源要求当前用户拥有其中一个角色，
+// 没有配置资源的接口不允许访问，需要在资源管理中添加后分配给角色
+func (a *AccessDeniedHandlerImpl) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resourceRole, err := a.FilterInvocationSecurityMetadataSource.GetAttributes(c.Request.Context(), c.Request.URL.Path, c.Request.Method)
+		if err != nil {
+			log.Printf("Failed to get resource roles: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, vo.FailWithCodeAndMessage(enums.SYSTEM_ERROR.Code, enums.SYSTEM_ERROR.Desc))
+			return
+		}
+		if resourceRole != nil && resourceRole.IsAnonymous == constants.True {
+			c.Next()
+			return
+		}
+
+		user, ok := utils.GetLoginUser(c.Request.Context())
+		if !ok || resourceRole == nil || !hasAnyRole(user.RoleList, resourceRole.RoleList) {
+			a.handle(c.Writer)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// hasAnyRole 判断用户是否拥有其中一个角色
+func hasAnyRole(userRoleList []string, roleList []string) bool {
+	for _, userRole := range userRoleList {
+		for _, role := range roleList {
+			if userRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *AccessDeniedHandlerImpl) handle(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	response := vo.FailWithCodeAndMessage(enums.AUTHORIZED.Code, enums.AUTHORIZED.Desc)
+	json.NewEncoder(w).Encode(response)
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/sessions"
 	"goBolg/dto"
+	"goBolg/exception"
 	"goBolg/service"
 	"goBolg/utils"
 
@@ -15,13 +18,15 @@ import (
 // AuthHandler handles authentication related requests.
 type AuthHandler struct {
 	UserDetailsService service.UserDetailsService
+	TokenService       service.TokenService
 	SessionStore       *sessions.CookieStore
 }
 
 // NewAuthHandler creates a new AuthHandler instance.
-func NewAuthHandler(userDetailsService service.UserDetailsService) *AuthHandler {
+func NewAuthHandler(userDetailsService service.UserDetailsService, tokenService service.TokenService) *AuthHandler {
 	return &AuthHandler{
 		UserDetailsService: userDetailsService,
+		TokenService:       tokenService,
 	}
 }
 
@@ -50,9 +55,16 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// 生成 JWT
-	token, err := utils.GenerateJWT(userDetail.ID, userDetail.Username)
+	// 禁用的账号不能登录
+	if !userDetail.IsAccountNonLocked() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "用户帐号已被禁用"})
+		return
+	}
+
+	// 创建登录会话，生成访问令牌和刷新令牌
+	token, err := h.TokenService.CreateToken(c.Request.Context(), userDetail.ID, userDetail.Username)
 	if err != nil {
+		log.Printf("Error creating token: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
@@ -60,9 +72,11 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	// 在返回响应之前，先将用户ID存储到上下文中
 	c.Set("userID", userDetail.ID)
 	c.JSON(http.StatusOK, gin.H{
-		"message": "登录成功",
-		"user":    userDetail,
-		"token":   token,
+		"message":      "登录成功",
+		"user":         userDetail,
+		"token":        token.Token,
+		"refreshToken": token.RefreshToken,
+		"expiresIn":    token.ExpiresIn,
 	})
 	c.Set("userID", userDetail.ID)
 	// 更新用户信息
@@ -70,9 +84,54 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	go h.UserDetailsService.UpdateUserInfo(c.Request, userDetail)
 }
 
+// RefreshToken 使用刷新令牌换取新的访问令牌，刷新令牌同时轮换，旧的刷新令牌不能再次使用
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	refreshToken := c.PostForm("refreshToken")
+	if refreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "刷新令牌不能为空"})
+		return
+	}
+
+	token, err := h.TokenService.RefreshToken(c.Request.Context(), refreshToken)
+	if err != nil {
+		if bizErr, ok := err.(*exception.BizError); ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": bizErr.Message})
+			return
+		}
+		log.Printf("Error refreshing token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "刷新成功",
+		"token":        token.Token,
+		"refreshToken": token.RefreshToken,
+		"expiresIn":    token.ExpiresIn,
+	})
+}
+
 // Logout handles the user logout request.
+// 注销当前 token 或刷新令牌所属的登录会话，访问令牌过期时可以只提交刷新令牌，令牌无效时视为已登出
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// 使 JWT 失效的逻辑（如果需要）可以在这里处理
+	tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if tokenString != "" {
+		claims, err := utils.ValidateJWT(tokenString)
+		if err == nil && claims.SessionID != "" {
+			if err := h.TokenService.RemoveSession(c.Request.Context(), claims.UserID, claims.SessionID); err != nil {
+				log.Printf("Error removing session: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+				return
+			}
+		}
+	}
+	if refreshToken := c.PostForm("refreshToken"); refreshToken != "" {
+		if err := h.TokenService.RemoveSessionByRefreshToken(c.Request.Context(), refreshToken); err != nil {
+			log.Printf("Error removing session: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+			return
+		}
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "登出成功"})
 }
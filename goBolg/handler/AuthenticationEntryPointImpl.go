@@ -5,21 +5,28 @@ import (
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"goBolg/constant"
+	"goBolg/dto"
 	"goBolg/enums"
 	"goBolg/service"
 	"goBolg/utils"
 	"goBolg/vo"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type AuthenticationEntryPointImpl struct {
 	UserDetailsService service.UserDetailsService
+	TokenService       service.TokenService
+	OnlineUserService  service.OnlineUserService
 }
 
-func NewAuthenticationEntryPointImpl(userDetailsService service.UserDetailsService) *AuthenticationEntryPointImpl {
+func NewAuthenticationEntryPointImpl(userDetailsService service.UserDetailsService, tokenService service.TokenService, onlineUserService service.OnlineUserService) *AuthenticationEntryPointImpl {
 	return &AuthenticationEntryPointImpl{
 		UserDetailsService: userDetailsService,
+		TokenService:       tokenService,
+		OnlineUserService:  onlineUserService,
 	}
 }
 
@@ -54,11 +61,22 @@ func (a *AuthenticationEntryPointImpl) Middleware() gin.HandlerFunc {
 		// 从数据库中加载用户详细信息
 		user, err := a.UserDetailsService.LoadUserByID(c.Request.Context(), userIDInt)
 		if err != nil {
-			//c.JSON(http.StatusInternalServerError, "Failed to load user")
+			log.Printf("Failed to load user %d: %v", userIDInt, err)
+			a.commence(c.Writer, c.Request)
+			c.Abort()
+			return
+		}
+		// 账号被禁用后立即拒绝，不等待 token 过期
+		if !user.IsAccountNonLocked() {
+			a.commence(c.Writer, c.Request)
+			c.Abort()
 			return
 		}
 		//log.Printf("User loaded from LoadUserByID: %+v", user)
 
+		// 刷新在线用户的最近活跃信息
+		a.refreshOnlineUser(c, user)
+
 		// 将用户信息存入上下文
 		ctx := context.WithValue(c.Request.Context(), constants.UserContextKey, user)
 		c.Request = c.Request.WithContext(ctx)
@@ -89,14 +107,52 @@ func (a *AuthenticationEntryPointImpl) isAuthenticated(c *gin.Context) bool {
 		return false
 	}
 
+	// 没有登录会话的 token 无法注销，不再接受
+	if claims.SessionID == "" {
+		return false
+	}
+
+	// 检查登录会话是否已注销
+	revoked, err := a.TokenService.IsRevoked(c.Request.Context(), claims.SessionID)
+	if err != nil {
+		log.Printf("Failed to check revoked session: %v", err)
+		return false
+	}
+	if revoked {
+		return false
+	}
+
 	//log.Printf("Authenticated claims: %+v", claims)
 
-	// 将用户ID设置到Gin的上下文中
+	// 将用户ID和登录会话设置到Gin的上下文中
 	c.Set("userID", claims.UserID)
+	c.Set("sessionID", claims.SessionID)
 
 	return true
 }
 
+// refreshOnlineUser 记录当前会话的用户、请求 IP、浏览器、操作系统和活跃时间，失败时只记录日志
+func (a *AuthenticationEntryPointImpl) refreshOnlineUser(c *gin.Context, user *dto.UserDetailDTO) {
+	sessionID := c.GetString("sessionID")
+	if sessionID == "" {
+		return
+	}
+	userAgent := utils.GetUserAgent(c.Request)
+	browser, _ := userAgent.Browser()
+	onlineUser := dto.UserOnlineDTO{
+		UserInfoID:    user.UserInfoID,
+		Nickname:      user.Nickname,
+		Avatar:        user.Avatar,
+		IPAddress:     c.ClientIP(), // IP 来源由在线用户服务按需查询
+		Browser:       browser,
+		OS:            userAgent.OS(),
+		LastLoginTime: time.Now(),
+	}
+	if err := a.OnlineUserService.RefreshOnlineUser(c.Request.Context(), sessionID, onlineUser); err != nil {
+		log.Printf("Failed to refresh online user: %v", err)
+	}
+}
+
 func (a *AuthenticationEntryPointImpl) commence(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	response := vo.FailWithCodeAndMessage(enums.NO_LOGIN.Code, enums.NO_LOGIN.Desc)
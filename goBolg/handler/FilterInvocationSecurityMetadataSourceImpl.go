@@ -2,60 +2,119 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"goBolg/constant"
 	"goBolg/dao"
 	"goBolg/dto"
+	"goBolg/service"
+	"goBolg/utils"
 	"log"
 	"sync"
+	"time"
 )
 
+// resourceRoleReloadInterval 定期清空资源角色信息的间隔，用于补偿断线期间丢失的变更通知
+const resourceRoleReloadInterval = 5 * time.Minute
+
+// FilterInvocationSecurityMetadataSourceImpl 缓存接口资源和可访问角色的对应关系
 type FilterInvocationSecurityMetadataSourceImpl struct {
-	roleDao dao.RoleDao
+	roleDao          dao.RoleDao
+	redisService     service.RedisService
+	mu               sync.RWMutex
+	resourceRoleList []dto.ResourceRoleDTO
+	loaded           bool
+	stop             chan struct{}
+	stopOnce         sync.Once
 }
 
-var resourceRoleList []dto.ResourceRoleDTO
-var mu sync.Mutex
-
-func NewFilterInvocationSecurityMetadataSourceImpl(roleDao dao.RoleDao) *FilterInvocationSecurityMetadataSourceImpl {
+func NewFilterInvocationSecurityMetadataSourceImpl(roleDao dao.RoleDao, redisService service.RedisService) *FilterInvocationSecurityMetadataSourceImpl {
 	return &FilterInvocationSecurityMetadataSourceImpl{
-		roleDao: roleDao,
+		roleDao:      roleDao,
+		redisService: redisService,
+		stop:         make(chan struct{}),
 	}
 }
 
 // LoadDataSource 加载资源角色信息
-func (f *FilterInvocationSecurityMetadataSourceImpl) LoadDataSource(ctx context.Context) {
-	mu.Lock()
-	defer mu.Unlock()
-
+func (f *FilterInvocationSecurityMetadataSourceImpl) LoadDataSource(ctx context.Context) error {
 	resourceRoles, err := f.roleDao.ListResourceRoles(ctx)
 	if err != nil {
-		log.Printf("加载资源角色信息错误: %v", err)
-		return
+		return fmt.Errorf("加载资源角色信息错误: %w", err)
 	}
-	resourceRoleList = resourceRoles
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resourceRoleList = resourceRoles
+	f.loaded = true
+	return nil
 }
 
-// ClearDataSource 清空资源角色信息
-func (f *FilterInvocationSecurityMetadataSourceImpl) ClearDataSource() {
-	mu.Lock()
-	defer mu.Unlock()
+// ClearDataSource 清空当前实例的资源角色信息，下次鉴权时重新加载，并通知其他实例清空
+func (f *FilterInvocationSecurityMetadataSourceImpl) ClearDataSource(ctx context.Context) {
+	f.clear()
+	if _, err := f.redisService.Publish(ctx, constants.ResourceRoleChannel, time.Now().UnixNano()); err != nil {
+		log.Printf("Error publishing resource role change: %v", err)
+	}
+}
 
-	resourceRoleList = nil
+// clear 清空当前实例的资源角色信息
+func (f *FilterInvocationSecurityMetadataSourceImpl) clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resourceRoleList = nil
+	f.loaded = false
 }
 
-// GetAttributes 获取请求的属性
-func (f *FilterInvocationSecurityMetadataSourceImpl) GetAttributes(ctx context.Context, url, method string) []string {
-	mu.Lock()
-	defer mu.Unlock()
+// Start 在后台监听变更通知，同时定期清空资源角色信息
+func (f *FilterInvocationSecurityMetadataSourceImpl) Start() {
+	go func() {
+		pubSub := f.redisService.Subscribe(context.Background(), constants.ResourceRoleChannel)
+		defer pubSub.Close()
+		ticker := time.NewTicker(resourceRoleReloadInterval)
+		defer ticker.Stop()
 
-	if len(resourceRoleList) == 0 {
-		f.LoadDataSource(ctx)
+		messages := pubSub.Channel()
+		for {
+			select {
+			case <-messages:
+			case <-ticker.C:
+			case <-f.stop:
+				return
+			}
+			f.clear()
+		}
+	}()
+}
+
+// Stop 停止监听变更通知
+func (f *FilterInvocationSecurityMetadataSourceImpl) Stop() {
+	f.stopOnce.Do(func() {
+		close(f.stop)
+	})
+}
+
+// GetAttributes 获取请求匹配的资源及可访问的角色，优先匹配不含通配符的路径，没有匹配的资源时返回 nil
+func (f *FilterInvocationSecurityMetadataSourceImpl) GetAttributes(ctx context.Context, url, method string) (*dto.ResourceRoleDTO, error) {
+	f.mu.RLock()
+	loaded := f.loaded
+	f.mu.RUnlock()
+	if !loaded {
+		if err := f.LoadDataSource(ctx); err != nil {
+			return nil, err
+		}
 	}
 
-	for _, resourceRole := range resourceRoleList {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for i, resourceRole := range f.resourceRoleList {
 		if resourceRole.URL == url && resourceRole.RequestMethod == method {
-			return resourceRole.RoleList
+			return &f.resourceRoleList[i], nil
 		}
 	}
-
-	return nil
+	for i, resourceRole := range f.resourceRoleList {
+		if resourceRole.RequestMethod == method && utils.MatchPath(resourceRole.URL, url) {
+			return &f.resourceRoleList[i], nil
+		}
+	}
+	return nil, nil
 }
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/enums"
+	"goBolg/service"
+	"goBolg/utils"
+	"goBolg/vo"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RateLimitMiddleware 按配置的规则限制请求频率，超出限制时返回 429，并通过 Retry-After 告知需要等待的秒数，
+// 客户端 IP 只从信任的代理转发的请求头中读取
+func RateLimitMiddleware(rateLimitService service.RateLimitService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		retryAfter, err := rateLimitService.Acquire(ctx, c.Request.Method, c.FullPath(), requestUserId(c), c.ClientIP())
+		if err != nil {
+			// 限流失败时放行，避免 Redis 故障导致接口不可用
+			log.Printf("Error checking rate limit: %v", err)
+		}
+		if retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, vo.FailWithCodeAndMessage(enums.TOO_MANY_REQUESTS.Code, enums.TOO_MANY_REQUESTS.Desc))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requestUserId 从请求携带的 token 中获取用户 id，限流在登录校验之前执行，未登录或 token 无效时返回 0
+func requestUserId(c *gin.Context) int {
+	tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if tokenString == "" {
+		return 0
+	}
+	claims, err := utils.ValidateJWT(tokenString)
+	if err != nil || claims == nil {
+		return 0
+	}
+	return claims.UserID
+}
@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/utils"
+)
+
+// RequestInfoMiddleware 将请求的 IP 地址和用户代理保存到请求上下文中，供服务层读取
+func RequestInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := utils.SetRequestInfo(c.Request.Context(), c.Request)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
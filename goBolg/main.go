@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/gin-contrib/cors"
 	"goBolg/app"
@@ -8,6 +9,10 @@ import (
 	"goBolg/handler"
 	"goBolg/router"
 	"goBolg/service/Impl"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 // @title Swagger Example API
@@ -29,6 +34,30 @@ import (
 // @name Authorization
 // @security BearerAuth
 func main() {
+	mode := flag.String("mode", "server", "运行模式，server 启动 Web 服务，worker 只消费邮件队列")
+	flag.Parse()
+	if *mode != "server" && *mode != "worker" {
+		fmt.Printf("Unknown mode: %s\n", *mode)
+		return
+	}
+
+	// worker 模式只消费邮件队列，收到退出信号后等待正在发送的邮件完成
+	if *mode == "worker" {
+		worker, err := app.InitializeWorker()
+		if err != nil {
+			fmt.Printf("Initialization failed: %v\n", err)
+			return
+		}
+		defer worker.Close()
+
+		worker.EmailConsumer.Start()
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+		<-quit
+		log.Println("Shutting down email worker")
+		return
+	}
+
 	// 初始化应用
 	application, err := app.Initialize()
 	if err != nil {
@@ -37,17 +66,26 @@ func main() {
 	}
 	defer application.Close()
 
+	if application.Config.EmailWorker.Enabled {
+		application.EmailConsumer.Start()
+	}
+
 	// 初始化 UserDetailsService
 	userDetailsService := Impl.NewUserDetailsServiceImpl(application.Controllers.UserAuthDao, application.Controllers.UserInfoDao, application.Controllers.RoleDao, application.Controllers.RedisService, nil)
 
 	// 初始化 AuthHandler
-	authHandler := handler.NewAuthHandler(userDetailsService)
+	authHandler := handler.NewAuthHandler(userDetailsService, application.Controllers.TokenService)
 
 	// 初始化 WebSecurityConfig
 	webSecurityConfig := config.NewWebSecurityConfig(*authHandler)
 
 	// 设置路由
 	r := router.SetupRouter(application.Controllers, webSecurityConfig, userDetailsService)
+	// 只信任配置的反向代理转发的客户端 IP，防止伪造 X-Forwarded-For 绕过限流
+	if err := r.SetTrustedProxies(application.Config.Server.TrustedProxies); err != nil {
+		fmt.Printf("Invalid trusted proxies: %v\n", err)
+		return
+	}
 
 	// 设置 CORS 中间件
 	r.Use(cors.New(cors.Config{
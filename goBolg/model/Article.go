@@ -19,6 +19,7 @@ type Article struct {
 	Status         *int       `gorm:"column:status"`
 	CreateTime     time.Time  `gorm:"column:create_time;autoCreateTime"`
 	UpdateTime     *time.Time `gorm:"column:update_time"`
+	PublishTime    *time.Time `gorm:"column:publish_time"` // 定时发布时间
 }
 
 func (Article) TableName() string {
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+)
+
+// ArticleRevision 文章历史版本
+type ArticleRevision struct {
+	// 版本id
+	ID int `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+
+	// 文章id
+	ArticleID int `json:"articleId" gorm:"column:article_id;index"`
+
+	// 修改人id
+	UserID int `json:"userId" gorm:"column:user_id"`
+
+	// 文章标题
+	ArticleTitle string `json:"articleTitle" gorm:"column:article_title;type:varchar(255)"`
+
+	// 文章内容
+	ArticleContent string `json:"articleContent" gorm:"column:article_content;type:longtext"`
+
+	// 分类名
+	CategoryName string `json:"categoryName" gorm:"column:category_name;type:varchar(50)"`
+
+	// 标签名，json 数组
+	TagNames string `json:"tagNames" gorm:"column:tag_names;type:varchar(1000)"`
+
+	// 文章状态
+	Status int `json:"status" gorm:"column:status"`
+
+	// 创建时间
+	CreateTime time.Time `json:"createTime" gorm:"autoCreateTime;column:create_time"`
+}
+
+// TableName 设置表名
+func (ArticleRevision) TableName() string {
+	return "tb_article_revision"
+}
@@ -17,9 +17,12 @@ type Comment struct {
 	// 评论主题ID
 	TopicID int `gorm:"column:topic_id" json:"topic_id"`
 
-	// 评论内容
+	// 评论内容，渲染后的 HTML
 	CommentContent string `gorm:"column:comment_content" json:"comment_content"`
 
+	// 评论原文，用户提交的 markdown
+	CommentSource string `gorm:"column:comment_source;type:text" json:"comment_source"`
+
 	// 父评论ID
 	ParentID *int `gorm:"column:parent_id" json:"parent_id"`
 
@@ -37,6 +40,12 @@ type Comment struct {
 
 	// 修改时间
 	UpdateTime time.Time `gorm:"column:update_time;autoUpdateTime" json:"update_time"`
+
+	// 垃圾内容评分
+	SpamScore int `gorm:"column:spam_score;type:int;not null;default:0" json:"spam_score"`
+
+	// 最后编辑时间，未编辑过为空
+	EditTime *time.Time `gorm:"column:edit_time" json:"edit_time"`
 }
 
 // TableName 设置表名
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+)
+
+// CommentRevision 评论编辑历史
+type CommentRevision struct {
+	// 版本id
+	ID int `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+
+	// 评论id
+	CommentID int `json:"commentId" gorm:"column:comment_id;index"`
+
+	// 编辑前的评论内容
+	CommentContent string `json:"commentContent" gorm:"column:comment_content;type:text"`
+
+	// 编辑时间
+	CreateTime time.Time `json:"createTime" gorm:"autoCreateTime;column:create_time"`
+}
+
+// TableName 设置表名
+func (CommentRevision) TableName() string {
+	return "tb_comment_revision"
+}
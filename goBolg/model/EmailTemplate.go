@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// EmailTemplate 管理员自定义的邮件模板，覆盖同名的内置模板
+type EmailTemplate struct {
+	// 模板id
+	ID int `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+
+	// 模板名称
+	Name string `json:"name" gorm:"column:name;type:varchar(50);uniqueIndex:idx_name"`
+
+	// 主题模板
+	Subject string `json:"subject" gorm:"column:subject;type:varchar(255)"`
+
+	// HTML 正文模板
+	HTMLContent string `json:"htmlContent" gorm:"column:html_content;type:text"`
+
+	// 纯文本正文模板
+	TextContent string `json:"textContent" gorm:"column:text_content;type:text"`
+
+	// 创建时间
+	CreateTime time.Time `json:"createTime" gorm:"autoCreateTime;column:create_time"`
+
+	// 修改时间
+	UpdateTime *time.Time `json:"updateTime" gorm:"column:update_time"`
+}
+
+// TableName 设置表名
+func (EmailTemplate) TableName() string {
+	return "tb_email_template"
+}
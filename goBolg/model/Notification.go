@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+)
+
+// Notification 站内通知
+type Notification struct {
+	// 通知id
+	ID int `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+
+	// 接收用户id
+	UserID int `json:"userId" gorm:"column:user_id;index:idx_user_read,priority:1"`
+
+	// 触发通知的用户id，系统通知为 0
+	FromUserID int `json:"fromUserId" gorm:"column:from_user_id;default:0"`
+
+	// 通知类型 1.评论回复 2.评论点赞 3.文章点赞 4.说说点赞 5.评论审核结果
+	Type int `json:"type" gorm:"column:type;type:tinyint"`
+
+	// 关联的评论、文章或说说id
+	TargetID int `json:"targetId" gorm:"column:target_id"`
+
+	// 通知内容
+	Content string `json:"content" gorm:"column:content;type:varchar(255)"`
+
+	// 站内页面路径
+	Path string `json:"path" gorm:"column:path;type:varchar(255)"`
+
+	// 是否已读
+	IsRead int `json:"isRead" gorm:"column:is_read;type:tinyint;default:0;index:idx_user_read,priority:2"`
+
+	// 创建时间
+	CreateTime time.Time `json:"createTime" gorm:"autoCreateTime;column:create_time"`
+}
+
+// TableName 设置表名
+func (Notification) TableName() string {
+	return "tb_notification"
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// NotificationSetting 用户的通知接收设置，未设置的通知类型使用默认接收方式
+type NotificationSetting struct {
+	// 设置id
+	ID int `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+
+	// 用户id
+	UserID int `json:"userId" gorm:"column:user_id;uniqueIndex:idx_user_type,priority:1"`
+
+	// 通知类型
+	Type int `json:"type" gorm:"column:type;type:tinyint;uniqueIndex:idx_user_type,priority:2"`
+
+	// 是否发送邮件
+	IsEmail int `json:"isEmail" gorm:"column:is_email;type:tinyint"`
+
+	// 是否发送站内通知
+	IsInApp int `json:"isInApp" gorm:"column:is_in_app;type:tinyint"`
+
+	// 修改时间
+	UpdateTime time.Time `json:"updateTime" gorm:"autoUpdateTime;column:update_time"`
+}
+
+// TableName 设置表名
+func (NotificationSetting) TableName() string {
+	return "tb_notification_setting"
+}
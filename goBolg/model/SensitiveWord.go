@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+// SensitiveWord 敏感词
+type SensitiveWord struct {
+	// 敏感词id
+	ID int `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+
+	// 敏感词
+	Word string `json:"word" gorm:"column:word;type:varchar(50);uniqueIndex:idx_word"`
+
+	// 处理方式 1.替换 2.拒绝 3.审核
+	Action int `json:"action" gorm:"column:action;type:tinyint"`
+
+	// 创建时间
+	CreateTime time.Time `json:"createTime" gorm:"autoCreateTime;column:create_time"`
+
+	// 修改时间
+	UpdateTime *time.Time `json:"updateTime" gorm:"column:update_time"`
+}
+
+// TableName 设置表名
+func (SensitiveWord) TableName() string {
+	return "tb_sensitive_word"
+}
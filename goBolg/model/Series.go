@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// Series 文章系列，按顺序组织多篇文章
+type Series struct {
+	// 系列id
+	ID int `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+
+	// 系列标题
+	SeriesTitle string `json:"seriesTitle" gorm:"column:series_title;type:varchar(50)"`
+
+	// 系列描述
+	SeriesDesc string `json:"seriesDesc" gorm:"column:series_desc;type:varchar(255)"`
+
+	// 系列封面
+	SeriesCover string `json:"seriesCover" gorm:"column:series_cover;type:varchar(255)"`
+
+	// 创建时间
+	CreateTime time.Time `json:"createTime" gorm:"autoCreateTime;column:create_time"`
+
+	// 修改时间
+	UpdateTime *time.Time `json:"updateTime" gorm:"column:update_time"`
+}
+
+// TableName 设置表名
+func (Series) TableName() string {
+	return "tb_series"
+}
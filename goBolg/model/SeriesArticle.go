@@ -0,0 +1,21 @@
+package model
+
+// SeriesArticle 系列与文章的关联，一篇文章只属于一个系列
+type SeriesArticle struct {
+	// 主键
+	ID int `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+
+	// 系列id
+	SeriesID int `json:"seriesId" gorm:"column:series_id;index"`
+
+	// 文章id
+	ArticleID int `json:"articleId" gorm:"column:article_id;uniqueIndex"`
+
+	// 文章在系列中的顺序，从 1 开始
+	Sort int `json:"sort" gorm:"column:sort"`
+}
+
+// TableName 设置表名
+func (SeriesArticle) TableName() string {
+	return "tb_series_article"
+}
@@ -22,7 +22,7 @@ type Talk struct {
 	// 是否置顶
 	IsTop int `json:"isTop" gorm:"column:is_top"`
 
-	// 说说状态 1.公开 2.私密
+	// 说说状态 1.公开 2.私密 3.草稿
 	Status int `json:"status" gorm:"column:status"`
 
 	// 创建时间
@@ -30,6 +30,9 @@ type Talk struct {
 
 	// 修改时间
 	UpdateTime time.Time `json:"updateTime" gorm:"autoUpdateTime;column:update_time"`
+
+	// 定时发布时间
+	PublishTime *time.Time `json:"publishTime" gorm:"column:publish_time"`
 }
 
 // String 返回结构体的字符串表示
@@ -0,0 +1,230 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/streadway/amqp"
+	"goBolg/config"
+	constants "goBolg/constant"
+	"goBolg/dto"
+	"goBolg/rabbitmq/rabbitService"
+	"goBolg/service"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// emailConsumerTag 邮件队列的消费者标签
+	emailConsumerTag = "goBolg-email-consumer"
+	// emailReconnectDelay 通道异常关闭后重新订阅的间隔
+	emailReconnectDelay = 5 * time.Second
+	// emailRenderTimeout 渲染邮件模板的超时时间
+	emailRenderTimeout = 30 * time.Second
+	// maxEmailErrorLength 记录在消息头中的失败原因的最大长度
+	maxEmailErrorLength = 512
+)
+
+// EmailConsumer 消费邮件队列，发送失败时按指数退避重试，超过重试次数或无法解析的消息移入死信队列
+type EmailConsumer struct {
+	connection           *amqp.Connection
+	emailService         *rabbitService.EmailService
+	emailTemplateService service.EmailTemplateService
+	config               config.EmailWorkerConfig
+	stop                 chan struct{}
+	stopOnce             sync.Once
+	wg                   sync.WaitGroup
+}
+
+// NewEmailConsumer 创建新的 EmailConsumer 实例
+func NewEmailConsumer(connection *amqp.Connection, emailService *rabbitService.EmailService, emailTemplateService service.EmailTemplateService, workerConfig config.EmailWorkerConfig) *EmailConsumer {
+	return &EmailConsumer{
+		connection:           connection,
+		emailService:         emailService,
+		emailTemplateService: emailTemplateService,
+		config:               workerConfig,
+		stop:                 make(chan struct{}),
+	}
+}
+
+// Start 在后台开始消费邮件队列，通道异常关闭时自动重新订阅
+func (c *EmailConsumer) Start() {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		log.Printf("Email consumer started with concurrency %d", c.concurrency())
+		for {
+			if err := c.consume(); err != nil {
+				log.Printf("Email consumer stopped unexpectedly: %v", err)
+			}
+			select {
+			case <-c.stop:
+				return
+			case <-time.After(emailReconnectDelay):
+			}
+		}
+	}()
+}
+
+// Stop 停止消费并等待正在发送的邮件完成，未确认的消息会重新回到队列
+func (c *EmailConsumer) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+	c.wg.Wait()
+}
+
+// consume 声明队列后订阅邮件队列，直到停止或通道关闭
+func (c *EmailConsumer) consume() error {
+	channel, err := c.connection.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer channel.Close()
+
+	if err := DeclareEmailQueue(channel); err != nil {
+		return err
+	}
+	retryKeys := make([]string, 0, c.config.MaxRetries)
+	for i := 0; i < c.config.MaxRetries; i++ {
+		retryKey, err := DeclareEmailRetryQueue(channel, c.retryDelay(i))
+		if err != nil {
+			return err
+		}
+		retryKeys = append(retryKeys, retryKey)
+	}
+
+	// 每个协程同时只处理一条消息
+	concurrency := c.concurrency()
+	if err := channel.Qos(concurrency, 0, false); err != nil {
+		return fmt.Errorf("failed to set qos: %w", err)
+	}
+	deliveries, err := channel.Consume(constants.EmailQueue, emailConsumerTag, false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume queue %s: %w", constants.EmailQueue, err)
+	}
+	closed := channel.NotifyClose(make(chan *amqp.Error, 1))
+
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for delivery := range deliveries {
+				c.handle(channel, retryKeys, delivery)
+			}
+		}()
+	}
+
+	select {
+	case <-c.stop:
+		// 取消订阅后 deliveries 会被关闭，等待已收到的消息处理完成
+		if err := channel.Cancel(emailConsumerTag, false); err != nil {
+			log.Printf("Failed to cancel email consumer: %v", err)
+		}
+		workers.Wait()
+		return nil
+	case err := <-closed:
+		workers.Wait()
+		return fmt.Errorf("channel closed: %v", err)
+	}
+}
+
+// handle 发送一封邮件，失败时转入重试队列或死信队列
+func (c *EmailConsumer) handle(channel *amqp.Channel, retryKeys []string, delivery amqp.Delivery) {
+	var emailDTO dto.EmailDTO
+	if err := json.Unmarshal(delivery.Body, &emailDTO); err != nil {
+		log.Printf("Failed to decode email message, moving to dead letter queue: %v", err)
+		c.republish(channel, constants.EmailDeadExchange, "", delivery, EmailRetryCount(delivery.Headers), err)
+		return
+	}
+	if emailDTO.Email == "" {
+		log.Println("Email message has no recipient, moving to dead letter queue")
+		c.republish(channel, constants.EmailDeadExchange, "", delivery, EmailRetryCount(delivery.Headers), fmt.Errorf("email is empty"))
+		return
+	}
+
+	err := c.send(emailDTO)
+	if err == nil {
+		if err := delivery.Ack(false); err != nil {
+			log.Printf("Failed to ack email message: %v", err)
+		}
+		return
+	}
+
+	retryCount := EmailRetryCount(delivery.Headers)
+	if retryCount >= len(retryKeys) {
+		log.Printf("Failed to send email to %s after %d retries, moving to dead letter queue: %v", emailDTO.Email, retryCount, err)
+		c.republish(channel, constants.EmailDeadExchange, "", delivery, retryCount, err)
+		return
+	}
+	log.Printf("Failed to send email to %s, retrying in %s: %v", emailDTO.Email, c.retryDelay(retryCount), err)
+	c.republish(channel, constants.EmailRetryExchange, retryKeys[retryCount], delivery, retryCount+1, err)
+}
+
+// send 渲染并发送邮件
+func (c *EmailConsumer) send(emailDTO dto.EmailDTO) error {
+	ctx, cancel := context.WithTimeout(context.Background(), emailRenderTimeout)
+	defer cancel()
+
+	content, err := c.emailTemplateService.RenderEmail(ctx, emailDTO)
+	if err != nil {
+		return err
+	}
+	return c.emailService.SendContent(emailDTO.Email, content)
+}
+
+// republish 将消息连同重试次数和失败原因投递到指定交换机后确认原消息，投递失败时原消息重新入队
+func (c *EmailConsumer) republish(channel *amqp.Channel, exchange string, routingKey string, delivery amqp.Delivery, retryCount int, cause error) {
+	headers := amqp.Table{}
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+	headers[constants.EmailRetryCountHeader] = int32(retryCount)
+	headers[constants.EmailErrorHeader] = truncateEmailError(cause.Error())
+
+	err := channel.Publish(exchange, routingKey, false, false, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  delivery.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+		Body:         delivery.Body,
+	})
+	if err != nil {
+		log.Printf("Failed to publish email message to %s, requeueing: %v", exchange, err)
+		if err := delivery.Nack(false, true); err != nil {
+			log.Printf("Failed to nack email message: %v", err)
+		}
+		return
+	}
+	if err := delivery.Ack(false); err != nil {
+		log.Printf("Failed to ack email message: %v", err)
+	}
+}
+
+// retryDelay 第 retryCount+1 次重试前的等待时间
+func (c *EmailConsumer) retryDelay(retryCount int) time.Duration {
+	delay := time.Duration(c.config.RetryDelay) * time.Second
+	if delay <= 0 {
+		delay = time.Second
+	}
+	return delay << retryCount
+}
+
+// concurrency 同时发送的邮件数量，至少为 1
+func (c *EmailConsumer) concurrency() int {
+	if c.config.Concurrency <= 0 {
+		return 1
+	}
+	return c.config.Concurrency
+}
+
+// truncateEmailError 截断过长的失败原因，避免消息头过大
+func truncateEmailError(cause string) string {
+	runes := []rune(cause)
+	if len(runes) > maxEmailErrorLength {
+		return string(runes[:maxEmailErrorLength])
+	}
+	return cause
+}
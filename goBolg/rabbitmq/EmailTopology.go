@@ -0,0 +1,76 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"github.com/streadway/amqp"
+	constants "goBolg/constant"
+	"strconv"
+	"time"
+)
+
+// DeclareEmailQueue 声明邮件交换机、邮件队列和死信队列，重复声明不会产生影响，
+// 邮件交换机沿用已有部署中的 topic 类型，队列绑定 # 接收全部路由键的消息
+func DeclareEmailQueue(channel *amqp.Channel) error {
+	queues := []struct {
+		exchange   string
+		kind       string
+		queue      string
+		bindingKey string
+	}{
+		{constants.EmailExchange, amqp.ExchangeTopic, constants.EmailQueue, "#"},
+		{constants.EmailDeadExchange, amqp.ExchangeFanout, constants.EmailDeadQueue, ""},
+	}
+	for _, item := range queues {
+		if err := channel.ExchangeDeclare(item.exchange, item.kind, true, false, false, false, nil); err != nil {
+			return fmt.Errorf("failed to declare exchange %s: %w", item.exchange, err)
+		}
+		if _, err := channel.QueueDeclare(item.queue, true, false, false, false, nil); err != nil {
+			return fmt.Errorf("failed to declare queue %s: %w", item.queue, err)
+		}
+		if err := channel.QueueBind(item.queue, item.bindingKey, item.exchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind queue %s: %w", item.queue, err)
+		}
+	}
+	return nil
+}
+
+// DeclareEmailRetryQueue 声明等待 delay 后重新投递到邮件交换机的重试队列，返回投递到该队列使用的路由键
+func DeclareEmailRetryQueue(channel *amqp.Channel, delay time.Duration) (string, error) {
+	if err := channel.ExchangeDeclare(constants.EmailRetryExchange, amqp.ExchangeDirect, true, false, false, false, nil); err != nil {
+		return "", fmt.Errorf("failed to declare exchange %s: %w", constants.EmailRetryExchange, err)
+	}
+
+	// 消息在重试队列中过期后转入邮件交换机
+	routingKey := strconv.FormatInt(delay.Milliseconds(), 10)
+	queue := constants.EmailRetryQueue + routingKey
+	args := amqp.Table{
+		"x-message-ttl":          delay.Milliseconds(),
+		"x-dead-letter-exchange": constants.EmailExchange,
+	}
+	if _, err := channel.QueueDeclare(queue, true, false, false, false, args); err != nil {
+		return "", fmt.Errorf("failed to declare queue %s: %w", queue, err)
+	}
+	if err := channel.QueueBind(queue, routingKey, constants.EmailRetryExchange, false, nil); err != nil {
+		return "", fmt.Errorf("failed to bind queue %s: %w", queue, err)
+	}
+	return routingKey, nil
+}
+
+// EmailRetryCount 读取邮件已重试的次数
+func EmailRetryCount(headers amqp.Table) int {
+	switch count := headers[constants.EmailRetryCountHeader].(type) {
+	case int32:
+		return int(count)
+	case int64:
+		return int(count)
+	case int:
+		return count
+	}
+	return 0
+}
+
+// EmailError 读取邮件最后一次发送失败的原因
+func EmailError(headers amqp.Table) string {
+	cause, _ := headers[constants.EmailErrorHeader].(string)
+	return cause
+}
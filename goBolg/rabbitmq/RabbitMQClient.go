@@ -37,8 +37,9 @@ func (c *RabbitMQClient) Publish(exchange, key string, body []byte) error {
 		false,
 		false,
 		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
 		},
 	)
 }
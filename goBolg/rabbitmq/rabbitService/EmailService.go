@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"goBolg/config"
+	"goBolg/dto"
 	"gopkg.in/gomail.v2"
 	"log"
 )
@@ -29,13 +30,21 @@ func NewEmailService(config config.MailConfig) *EmailService {
 	}
 }
 
-// SendEmail 发送邮件
+// SendEmail 发送纯文本邮件
 func (s *EmailService) SendEmail(to string, subject string, body string) error {
+	return s.SendContent(to, dto.EmailContentDTO{Subject: subject, Text: body})
+}
+
+// SendContent 发送渲染后的邮件，有 HTML 正文时纯文本正文作为不支持 HTML 的客户端的备选
+func (s *EmailService) SendContent(to string, content dto.EmailContentDTO) error {
 	m := gomail.NewMessage()
 	m.SetHeader("From", s.From)
 	m.SetHeader("To", to)
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/plain", body)
+	m.SetHeader("Subject", content.Subject)
+	m.SetBody("text/plain", content.Text)
+	if content.HTML != "" {
+		m.AddAlternative("text/html", content.HTML)
+	}
 
 	d := gomail.NewDialer(s.Host, s.Port, s.Username, s.Password)
 	d.TLSConfig = &tls.Config{InsecureSkipVerify: true} // 启用 TLS
@@ -3,6 +3,7 @@ package rabbitImpl
 import (
 	"encoding/json"
 	"github.com/streadway/amqp"
+	constants "goBolg/constant"
 	"goBolg/dto"
 	"goBolg/rabbitmq/rabbitService"
 )
@@ -31,13 +32,14 @@ func (r *rabbitServiceImpl) SendEmail(emailDTO dto.EmailDTO) error {
 
 	// 发送消息到 RabbitMQ
 	err = channel.Publish(
-		"EMAIL_EXCHANGE", // 交换机
-		"",               // 路由键
-		false,            // Mandatory
-		false,            // Immediate
+		constants.EmailExchange, // 交换机
+		"",                      // 路由键
+		false,                   // Mandatory
+		false,                   // Immediate
 		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
 		})
 	if err != nil {
 		return err
@@ -3,28 +3,36 @@ package router
 import (
 	"github.com/gin-gonic/gin"
 	"goBolg/app"
+	"goBolg/handler"
 )
 
-func SetupAdminRoutes(router *gin.RouterGroup, app *app.Controllers, authMiddleWare gin.HandlerFunc) {
+func SetupAdminRoutes(router *gin.RouterGroup, app *app.Controllers, authMiddleWare gin.HandlerFunc, accessMiddleWare gin.HandlerFunc) {
 	adminGroup := router.Group("/admin")
-	adminGroup.Use(authMiddleWare)
+	adminGroup.Use(authMiddleWare, accessMiddleWare)
 	{
 		// 文章
 		adminGroup.GET("/articles", app.ArticleController.ListArticleBacks)
-		//	adminGroup.GET("/articles/:articleId", app.ArticleController.GetArticleBackById)
+		adminGroup.GET("/articles/:articleId", app.ArticleController.GetArticleBackById)
 		adminGroup.POST("/articles", app.ArticleController.SaveOrUpdateArticle)
-		//	adminGroup.POST("/articles/images", app.ArticleController.SaveArticleImages)
+		adminGroup.POST("/articles/images", app.ArticleController.SaveArticleImages)
 		adminGroup.PUT("/articles/top", app.ArticleController.UpdateArticleTop)
 		adminGroup.PUT("/articles", app.ArticleController.UpdateArticleDelete)
 		adminGroup.DELETE("/articles", app.ArticleController.DeleteArticles)
+		adminGroup.POST("/articles/import", app.ArticleController.ImportArticles)
+		adminGroup.POST("/articles/export", app.ArticleController.ExportArticles)
+		adminGroup.POST("/articles/search/index", app.ArticleController.RebuildSearchIndex)
+		adminGroup.DELETE("/articles/:articleId/schedule", app.ArticleController.CancelArticleSchedule)
+		adminGroup.GET("/articles/:articleId/revisions", app.ArticleController.ListArticleRevisions)
+		adminGroup.GET("/articles/:articleId/revisions/diff", app.ArticleController.GetArticleRevisionDiff)
+		adminGroup.POST("/articles/:articleId/revisions/:revisionId/restore", app.ArticleController.RestoreArticleRevision)
 		// 网站
 		adminGroup.GET("", app.BlogInfoController.GetBlogBackInfo)
 		adminGroup.PUT("/website/config", app.BlogInfoController.UpdateWebsiteConfig)
 		adminGroup.PUT("/about", app.BlogInfoController.UpdateAbout)
 		// 分类
-		//adminGroup.GET("/categories", app.CategoryController.ListBackCategories)
-		//adminGroup.GET("/categories/search", app.CategoryController.ListCategoriesBySearch)
-		//adminGroup.POST("/categories", app.CategoryController.SaveOrUpdateCategory)
+		adminGroup.GET("/categories", app.CategoryController.ListBackCategories)
+		adminGroup.GET("/categories/search", app.CategoryController.ListCategoriesBySearch)
+		adminGroup.POST("/categories", app.CategoryController.SaveOrUpdateCategory)
 		adminGroup.DELETE("/categories", app.CategoryController.DeleteCategories)
 		//评论
 		adminGroup.PUT("/comments/review", app.CommentController.UpdateCommentsReview)
@@ -43,6 +51,24 @@ func SetupAdminRoutes(router *gin.RouterGroup, app *app.Controllers, authMiddleW
 		adminGroup.POST("/links", app.FriendLinkController.SaveOrUpdateFriendLink)
 		adminGroup.DELETE("/links", app.FriendLinkController.RemoveFriendLinks)
 
+		// 系列
+		adminGroup.GET("/series", handler.PaginationMiddleware(), app.SeriesController.ListBackSeries)
+		adminGroup.GET("/series/:seriesId", app.SeriesController.GetBackSeriesById)
+		adminGroup.POST("/series", app.SeriesController.SaveOrUpdateSeries)
+		adminGroup.DELETE("/series", app.SeriesController.DeleteSeries)
+		adminGroup.GET("/sensitive-words", handler.PaginationMiddleware(), app.SensitiveWordController.ListBackSensitiveWords)
+		adminGroup.POST("/sensitive-words", app.SensitiveWordController.SaveOrUpdateSensitiveWord)
+		adminGroup.DELETE("/sensitive-words", app.SensitiveWordController.DeleteSensitiveWords)
+		adminGroup.POST("/sensitive-words/import", app.SensitiveWordController.ImportSensitiveWords)
+
+		adminGroup.GET("/email-templates", app.EmailTemplateController.ListEmailTemplates)
+		adminGroup.POST("/email-templates", app.EmailTemplateController.SaveEmailTemplate)
+		adminGroup.DELETE("/email-templates/:name", app.EmailTemplateController.DeleteEmailTemplate)
+
+		adminGroup.GET("/emails/dead-letters", handler.PaginationMiddleware(), app.EmailQueueController.ListDeadLetters)
+		adminGroup.POST("/emails/dead-letters/retry", app.EmailQueueController.RetryDeadLetters)
+		adminGroup.DELETE("/emails/dead-letters", app.EmailQueueController.PurgeDeadLetters)
+
 		//日志
 		adminGroup.GET("/operation/logs", app.LogController.ListOperationLogs)
 
@@ -106,15 +132,23 @@ func SetupAdminRoutes(router *gin.RouterGroup, app *app.Controllers, authMiddleW
 		adminGroup.POST("/role", app.RoleController.SaveOrUpdateRole) // 更新不知道更新的是什么？？？？？并且创建会进行填充更新时间
 
 		//标签
-		//	adminGroup.GET("/tags", app.TagController.ListTagBackDTO)
-		//adminGroup.DELETE("/tags", app.TagController.DeleteTag)
-		//adminGroup.POST("/tags", app.TagController.SaveOrUpdateTag) // 更新不知道更新的是什么？？？？？并且创建会进行填充更新时间
-		//adminGroup.GET("/tags/search", app.TagController.ListTagsBySearch)
+		adminGroup.GET("/tags", app.TagController.ListTagBackDTO)
+		adminGroup.DELETE("/tags", app.TagController.DeleteTag)
+		adminGroup.POST("/tags", app.TagController.SaveOrUpdateTag) // 更新不知道更新的是什么？？？？？并且创建会进行填充更新时间
+		adminGroup.GET("/tags/search", app.TagController.ListTagsBySearch)
 
 		// 说说
 		adminGroup.POST("/talks/images", app.TalkController.SaveTalkImages)
+		adminGroup.GET("/talks", handler.PaginationMiddleware(), app.TalkController.ListBackTalks)
+		adminGroup.POST("/talks", app.TalkController.SaveOrUpdateTalk)
+		adminGroup.DELETE("/talks/:talkId/schedule", app.TalkController.CancelTalkSchedule)
 
 		//后台
 		adminGroup.GET("/users/area", app.UserAuthController.ListUserAreas)
+		adminGroup.GET("/users", handler.PaginationMiddleware(), app.UserAuthController.ListUsers)
+		adminGroup.PUT("/users/disable", app.UserInfoController.UpdateUserDisable)
+		adminGroup.PUT("/users/role", app.UserInfoController.UpdateUserRole)
+		adminGroup.DELETE("/users/:userInfoId/sessions", app.UserSessionController.RemoveUserSessions)
+		adminGroup.GET("/users/online", handler.PaginationMiddleware(), app.UserSessionController.ListOnlineUsers)
 	}
 }
@@ -3,6 +3,7 @@ package router
 import (
 	"github.com/gin-gonic/gin"
 	"goBolg/app"
+	"goBolg/handler"
 )
 
 func SetupCategoryRoutes(router *gin.RouterGroup, controllers *app.Controllers, authMiddleWare gin.HandlerFunc) {
@@ -21,6 +22,8 @@ func SetupCategoryRoutes(router *gin.RouterGroup, controllers *app.Controllers,
 
 	router.GET("/links", controllers.FriendLinkController.ListFriendLinks)
 
+	router.GET("/series", controllers.SeriesController.ListSeries)
+
 	router.POST("/messages", controllers.MessageController.SaveMessage)
 
 	router.GET("/messages", controllers.MessageController.ListMessages)
@@ -39,6 +42,8 @@ func SetupCategoryRoutes(router *gin.RouterGroup, controllers *app.Controllers,
 
 	router.GET("/comments", controllers.CommentController.ListComments)
 
+	router.GET("/comments/tree", controllers.CommentController.ListCommentTree)
+
 	router.GET("/comments/:commentId/replies", controllers.CommentController.ListRepliesByCommentId)
 
 	router.GET("/articles/:articleId", controllers.ArticleController.GetArticleById)
@@ -53,25 +58,21 @@ func SetupCategoryRoutes(router *gin.RouterGroup, controllers *app.Controllers,
 
 	router.GET("/users/code", controllers.UserAuthController.SendCode)
 
-	router.POST("/register", controllers.UserAuthController.Register)
-
-	router.PUT("/users/password", controllers.UserAuthController.UpdatePassword)
-
-	router.GET("/admin/articles/:articleId", controllers.ArticleController.GetArticleBackById)
+	router.GET("/users/notification-settings", authMiddleWare, controllers.NotificationController.ListNotificationSettings)
 
-	router.POST("/admin/articles/images", controllers.ArticleController.SaveArticleImages)
+	router.PUT("/users/notification-settings", authMiddleWare, controllers.NotificationController.SaveNotificationSettings)
 
-	router.POST("/admin/categories", controllers.CategoryController.SaveOrUpdateCategory)
+	router.DELETE("/users/sessions", authMiddleWare, controllers.UserSessionController.RemoveOwnSessions)
 
-	router.GET("/admin/tags", controllers.TagController.ListTagBackDTO)
+	router.GET("/notifications", authMiddleWare, handler.PaginationMiddleware(), controllers.NotificationController.ListNotifications)
 
-	router.DELETE("/admin/tags", controllers.TagController.DeleteTag)
+	router.GET("/notifications/unread-count", authMiddleWare, controllers.NotificationController.CountUnreadNotifications)
 
-	router.POST("/admin/tags", controllers.TagController.SaveOrUpdateTag)
+	router.PUT("/notifications/read", authMiddleWare, controllers.NotificationController.ReadNotifications)
 
-	router.GET("/admin/tags/search", controllers.TagController.ListTagsBySearch)
+	router.PUT("/notifications/read-all", authMiddleWare, controllers.NotificationController.ReadAllNotifications)
 
-	router.GET("/admin/categories", controllers.CategoryController.ListBackCategories)
+	router.POST("/register", controllers.UserAuthController.Register)
 
-	router.GET("/admin/categories/search", controllers.CategoryController.ListCategoriesBySearch)
+	router.PUT("/users/password", controllers.UserAuthController.UpdatePassword)
 }
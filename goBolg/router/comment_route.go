@@ -21,8 +21,10 @@ func SetupCommentRoutes(router *gin.RouterGroup, controllers *app.Controllers, a
 			  "type": 1
 			}
 		*/
-		commentGroup.POST("", authMiddleWare, controllers.CommentController.SaveComment)
-		commentGroup.POST("/:commentId/like", authMiddleWare, controllers.CommentController.SaveCommentLike)
+		commentGroup.POST("", controllers.CommentController.SaveComment)
+		commentGroup.POST("/:commentId/like", controllers.CommentController.SaveCommentLike)
+		commentGroup.PUT("/:commentId", controllers.CommentController.UpdateComment)
+		commentGroup.DELETE("/:commentId", controllers.CommentController.RemoveOwnComment)
 
 	}
 }
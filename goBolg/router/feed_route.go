@@ -0,0 +1,27 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/app"
+)
+
+func SetupFeedRoutes(router *gin.RouterGroup, controllers *app.Controllers) {
+
+	router.GET("/rss.xml", controllers.FeedController.RSS)
+
+	router.GET("/atom.xml", controllers.FeedController.Atom)
+
+	router.GET("/feed.json", controllers.FeedController.JSONFeed)
+
+	router.GET("/categories/:categoryId/rss.xml", controllers.FeedController.RSS)
+
+	router.GET("/categories/:categoryId/atom.xml", controllers.FeedController.Atom)
+
+	router.GET("/categories/:categoryId/feed.json", controllers.FeedController.JSONFeed)
+
+	router.GET("/tags/:tagId/rss.xml", controllers.FeedController.RSS)
+
+	router.GET("/tags/:tagId/atom.xml", controllers.FeedController.Atom)
+
+	router.GET("/tags/:tagId/feed.json", controllers.FeedController.JSONFeed)
+}
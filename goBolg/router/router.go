@@ -12,8 +12,10 @@ import (
 
 func SetupRouter(app *app.Controllers, webSecurityConfig *config.WebSecurityConfig, userDetailsService service.UserDetailsService) *gin.Engine {
 	router := gin.Default()
+	router.Use(handler.RequestInfoMiddleware(), handler.RateLimitMiddleware(app.RateLimitService))
 	webSecurityConfig.Configure(router)
-	authMiddleware := handler.NewAuthenticationEntryPointImpl(userDetailsService).Middleware()
+	authMiddleware := handler.NewAuthenticationEntryPointImpl(userDetailsService, app.TokenService, app.OnlineUserService).Middleware()
+	accessMiddleware := handler.NewAccessDeniedHandlerImpl(app.SecurityMetadataSource).Middleware()
 
 	// Swagger router
 	router.GET("swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -27,8 +29,10 @@ func SetupRouter(app *app.Controllers, webSecurityConfig *config.WebSecurityConf
 	/*SetupBlogInfoRoutes(api, app)*/
 	SetupCategoryRoutes(api, app, authMiddleware)
 	SetupCommentRoutes(api, app, authMiddleware)
+	SetupFeedRoutes(api, app)
+	SetupSitemapRoutes(api, app)
 	// Setup admin routes
-	SetupAdminRoutes(api, app, authMiddleware)
+	SetupAdminRoutes(api, app, authMiddleware, accessMiddleware)
 
 	return router
 }
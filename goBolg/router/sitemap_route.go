@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"goBolg/app"
+)
+
+func SetupSitemapRoutes(router *gin.RouterGroup, controllers *app.Controllers) {
+
+	router.GET("/sitemap.xml", controllers.SitemapController.Sitemap)
+
+	router.GET("/sitemaps/:page", controllers.SitemapController.SitemapPage)
+
+	router.GET("/robots.txt", controllers.SitemapController.Robots)
+}
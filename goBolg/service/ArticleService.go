@@ -4,6 +4,7 @@ import (
 	"context"
 	"goBolg/dto"
 	"goBolg/vo"
+	"mime/multipart"
 )
 
 // ArticleService 文章服务接口
@@ -43,9 +44,25 @@ type ArticleService interface {
 
 	// 搜索文章
 	ListArticlesBySearch(ctx context.Context, condition vo.ConditionVO) ([]dto.ArticleSearchDTO, error)
-	/*
 
+	// 导入 Hexo 文章
+	ImportArticles(ctx context.Context, fileList []*multipart.FileHeader) ([]dto.ArticleImportDTO, error)
 
-		// 导出文章
-		ExportArticles(ctx contxt.Context, articleIdList []int) ([]string, error)*/
+	// 导出文章
+	ExportArticles(ctx context.Context, articleIdList []int) ([]string, error)
+
+	// 取消文章定时发布
+	CancelArticleSchedule(ctx context.Context, articleId int) error
+
+	// 重建文章搜索索引，返回索引的文章数量
+	RebuildSearchIndex(ctx context.Context) (int, error)
+
+	// 查询文章历史版本
+	ListArticleRevisions(ctx context.Context, articleId int) ([]dto.ArticleRevisionDTO, error)
+
+	// 比较文章的两个历史版本
+	GetArticleRevisionDiff(ctx context.Context, articleId int, oldRevisionId int, newRevisionId int) (*dto.ArticleRevisionDiffDTO, error)
+
+	// 恢复文章到指定历史版本
+	RestoreArticleRevision(ctx context.Context, articleId int, revisionId int) error
 }
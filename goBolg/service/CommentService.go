@@ -3,16 +3,27 @@ package service
 import (
 	"context"
 	"goBolg/dto"
+	"goBolg/utils"
 	"goBolg/vo"
 )
 
 type CommentService interface {
 	ListComments(ctx context.Context, commentVO vo.CommentVO, current int, size int) ([]dto.CommentDTO, int, error)
 
-	ListRepliesByCommentId(ctx context.Context, commentId int) ([]dto.ReplyDTO, error)
+	// ListCommentTree 游标分页查询顶层评论，每条评论下按层级展开回复
+	ListCommentTree(ctx context.Context, commentVO vo.CommentVO, cursor *utils.Cursor, size int, depth int) (*dto.CommentTreePageDTO, error)
+
+	// ListRepliesByCommentId 游标分页查询评论下的直接回复，每条回复下按层级展开，评论不存在时返回 nil
+	ListRepliesByCommentId(ctx context.Context, commentId int, cursor *utils.Cursor, size int, depth int) (*dto.CommentTreePageDTO, error)
 
 	SaveComment(ctx context.Context, commentVO vo.CommentVO) (dto.CommentDTO, error)
 
+	// UpdateComment 作者在可编辑时间内修改自己的评论，修改前的内容保存为编辑历史
+	UpdateComment(ctx context.Context, commentId int, commentEditVO vo.CommentEditVO) (dto.CommentDTO, error)
+
+	// RemoveOwnComment 作者逻辑删除自己的评论
+	RemoveOwnComment(ctx context.Context, commentId int) error
+
 	// 点赞评论 没测试出来
 	SaveCommentLike(ctx context.Context, commentId int) (int, error)
 
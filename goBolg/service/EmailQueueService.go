@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/vo"
+)
+
+// EmailQueueService 邮件队列管理服务接口
+type EmailQueueService interface {
+	// 分页查看死信队列中的邮件，消息仍保留在死信队列中
+	ListDeadLetters(ctx context.Context) (vo.PageResult[dto.EmailDeadLetterDTO], error)
+
+	// 将死信队列中的邮件重新投递到邮件队列，返回投递的数量
+	RetryDeadLetters(ctx context.Context) (int, error)
+
+	// 清空死信队列，返回删除的数量
+	PurgeDeadLetters(ctx context.Context) (int, error)
+}
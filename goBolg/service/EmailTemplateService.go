@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/vo"
+)
+
+// EmailTemplateService 邮件模板服务接口
+type EmailTemplateService interface {
+	// 渲染邮件的主题、HTML 正文和纯文本正文，未指定模板时直接使用邮件内容作为纯文本正文
+	RenderEmail(ctx context.Context, emailDTO dto.EmailDTO) (dto.EmailContentDTO, error)
+
+	// 查询全部邮件模板，自定义模板覆盖内置模板
+	ListEmailTemplates(ctx context.Context) ([]dto.EmailTemplateDTO, error)
+
+	// 保存自定义模板
+	SaveEmailTemplate(ctx context.Context, emailTemplateVO vo.EmailTemplateVO) error
+
+	// 删除自定义模板，恢复为内置模板
+	DeleteEmailTemplate(ctx context.Context, name string) error
+}
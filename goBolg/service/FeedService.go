@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/vo"
+)
+
+// FeedService 订阅源服务接口
+type FeedService interface {
+	// 生成订阅源，可按分类或标签筛选，分类或标签不存在时返回 nil
+	GetFeed(ctx context.Context, feedType enums.FeedTypeEnum, condition vo.ConditionVO, mode string) (*dto.FeedDTO, error)
+}
@@ -1,7 +1,10 @@
 package Impl
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
@@ -15,36 +18,61 @@ import (
 	"goBolg/utils"
 	"goBolg/vo"
 	"gorm.io/gorm"
+	"io"
 	"log"
+	"mime/multipart"
+	"path"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
+// maxImportFileSize 单个导入文件的最大字节数
+const maxImportFileSize = 20 << 20
+
+// defaultRevisionLimit 未配置时每篇文章保留的历史版本数量
+const defaultRevisionLimit = 20
+
+// maxSearchSize 搜索结果每页的最大数量
+const maxSearchSize = 50
+
 type ArticleServiceImpl struct {
-	articleDao      dao.ArticleDao
-	categoryDao     dao.CategoryDao
-	tagDao          dao.TagDao
-	tagService      service.TagService
-	articleTagDao   dao.ArticleTagDao
-	redisService    service.RedisService
-	searchStrategy  *contxt.SearchStrategyContext
-	blogInfoService service.BlogInfoService
-	articleSet      sync.Map // 使用 sync.Map 代替 Set
-	db              *gorm.DB // 添加对 gorm.DB 的引用，用于事务处理
-}
-
-func NewArticleServiceImpl(articleDao dao.ArticleDao, articleTagDao dao.ArticleTagDao, categoryDao dao.CategoryDao, tagDao dao.TagDao, tagService service.TagService, redisService service.RedisService, blogInfoService service.BlogInfoService, searchStrategy *contxt.SearchStrategyContext, db *gorm.DB) *ArticleServiceImpl {
+	articleDao          dao.ArticleDao
+	categoryDao         dao.CategoryDao
+	tagDao              dao.TagDao
+	tagService          service.TagService
+	articleTagDao       dao.ArticleTagDao
+	revisionDao         dao.ArticleRevisionDao
+	revisionLimit       int // 每篇文章保留的历史版本数量
+	seriesDao           dao.SeriesDao
+	seriesService       service.SeriesService
+	redisService        service.RedisService
+	searchStrategy      *contxt.SearchStrategyContext
+	uploadStrategy      *contxt.UploadStrategyContext
+	blogInfoService     service.BlogInfoService
+	notificationService service.NotificationService
+	articleSet          sync.Map // 使用 sync.Map 代替 Set
+	db                  *gorm.DB // 添加对 gorm.DB 的引用，用于事务处理
+}
+
+func NewArticleServiceImpl(articleDao dao.ArticleDao, articleTagDao dao.ArticleTagDao, revisionDao dao.ArticleRevisionDao, revisionLimit int, seriesDao dao.SeriesDao, seriesService service.SeriesService, categoryDao dao.CategoryDao, tagDao dao.TagDao, tagService service.TagService, redisService service.RedisService, blogInfoService service.BlogInfoService, notificationService service.NotificationService, searchStrategy *contxt.SearchStrategyContext, uploadStrategy *contxt.UploadStrategyContext, db *gorm.DB) *ArticleServiceImpl {
 	return &ArticleServiceImpl{
-		articleDao:      articleDao,
-		categoryDao:     categoryDao,
-		tagDao:          tagDao,
-		tagService:      tagService,
-		articleTagDao:   articleTagDao,
-		redisService:    redisService,
-		blogInfoService: blogInfoService,
-		searchStrategy:  searchStrategy,
-		db:              db,
+		articleDao:          articleDao,
+		categoryDao:         categoryDao,
+		tagDao:              tagDao,
+		tagService:          tagService,
+		articleTagDao:       articleTagDao,
+		revisionDao:         revisionDao,
+		revisionLimit:       revisionLimit,
+		seriesDao:           seriesDao,
+		seriesService:       seriesService,
+		redisService:        redisService,
+		blogInfoService:     blogInfoService,
+		notificationService: notificationService,
+		searchStrategy:      searchStrategy,
+		uploadStrategy:      uploadStrategy,
+		db:                  db,
 	}
 }
 
@@ -226,6 +254,13 @@ func (s *ArticleServiceImpl) GetArticleById(ctx context.Context, articleId int)
 	utils.BeanCopy(&lastArticle, article.LastArticle)
 	utils.BeanCopy(&nextArticle, article.NextArticle)
 
+	// 查询系列导航
+	articleSeries, err := s.seriesService.GetArticleSeries(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+	article.Series = articleSeries
+
 	// 获取点赞次数
 	likeCountStr, err := s.redisService.HGet(ctx, constants.ArticleLikeCount, strconv.Itoa(articleId))
 	if err != nil && err != redis.Nil {
@@ -413,6 +448,10 @@ func (s *ArticleServiceImpl) SaveArticleLike(ctx context.Context, articleId int)
 			return 0, fmt.Errorf("failed to increment like count: %v", err)
 		}
 		log.Printf("Article %d liked by user %d", articleId, userInfoID)
+
+		if err := s.noticeArticleLike(ctx, articleId, userInfoID); err != nil {
+			log.Printf("Error sending like notification for article %d: %v", articleId, err)
+		}
 	}
 
 	// 检查最终的点赞数量
@@ -433,8 +472,30 @@ func (s *ArticleServiceImpl) SaveArticleLike(ctx context.Context, articleId int)
 	return finalLikeCount, nil
 }
 
+// noticeArticleLike 通知文章作者文章被点赞
+func (s *ArticleServiceImpl) noticeArticleLike(ctx context.Context, articleId int, userId int) error {
+	article, err := s.articleDao.GetArticleById(ctx, articleId)
+	if err != nil {
+		return fmt.Errorf("failed to get article by ID: %w", err)
+	}
+	return s.notificationService.Notify(ctx, dto.NotificationEventDTO{
+		UserID:     article.UserID,
+		FromUserID: userId,
+		Type:       enums.ARTICLE_LIKE.Type,
+		TargetID:   articleId,
+		Content:    article.ArticleTitle,
+		Path:       enums.ARTICLE.Path + strconv.Itoa(articleId),
+	})
+}
+
 // SaveOrUpdateArticle 保存或更新文章
 func (s *ArticleServiceImpl) SaveOrUpdateArticle(ctx context.Context, articleVO vo.ArticleVO) error {
+	_, err := s.saveOrUpdateArticle(ctx, articleVO)
+	return err
+}
+
+// saveOrUpdateArticle 保存或更新文章，返回文章id
+func (s *ArticleServiceImpl) saveOrUpdateArticle(ctx context.Context, articleVO vo.ArticleVO) (int, error) {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var webConfig vo.WebsiteConfigVO
@@ -458,20 +519,19 @@ func (s *ArticleServiceImpl) SaveOrUpdateArticle(ctx context.Context, articleVO
 	// 保存文章分类
 	category, err = s.saveArticleCategory(ctx, articleVO)
 	if err != nil {
-
-		return err
+		return 0, err
 	}
 
 	// 等待并发操作完成
 	wg.Wait()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// 获取当前登录用户
 	user, ok := utils.GetLoginUser(ctx)
 	if !ok {
-		return errors.New("failed to get login user")
+		return 0, errors.New("failed to get login user")
 	}
 
 	article := model.Article{}
@@ -490,25 +550,46 @@ func (s *ArticleServiceImpl) SaveOrUpdateArticle(ctx context.Context, articleVO
 
 	article.UserID = user.UserInfoID
 
+	// 发布时间在未来时先保存为草稿，到期后由定时任务公开
+	if article.PublishTime != nil {
+		if article.PublishTime.After(time.Now()) {
+			draft := enums.DRAFT.Status
+			article.Status = &draft
+		} else {
+			article.PublishTime = nil
+		}
+	}
+
 	// 解引用 ArticleVO 中的 ID 并判断是否为 0
 	if articleVO.ID == nil || *articleVO.ID == 0 {
 		err = s.articleDao.SaveArticle(ctx, &article)
 	} else {
 		article.ID = *articleVO.ID // 将解引用后的 ID 赋值给 article.ID
+		// 首次修改前先保存原始版本，便于回退
+		if err = s.saveBaselineRevision(ctx, article.ID); err != nil {
+			return 0, err
+		}
 		err = s.articleDao.UpdateArticle(ctx, &article)
 	}
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// 保存文章标签
 	err = s.saveArticleTag(ctx, articleVO, article.ID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	// 保存本次修改后的版本
+	if err = s.saveArticleRevision(ctx, article.ID, user.UserInfoID); err != nil {
+		return 0, err
+	}
+
+	s.updateSearchIndex(ctx, []int{article.ID})
+	s.clearFeedCache(ctx)
+	return article.ID, nil
 }
 
 // SaveArticleTag 保存文章标签
@@ -628,6 +709,10 @@ func (s *ArticleServiceImpl) UpdateArticleDelete(ctx context.Context, deleteVO v
 	if err := s.articleDao.UpdateBatchById(ctx, articles); err != nil {
 		return fmt.Errorf("failed to update articles: %v", err)
 	}
+
+	// 逻辑删除时移出索引，恢复时重新加入
+	s.updateSearchIndex(ctx, deleteVO.IDList)
+	s.clearFeedCache(ctx)
 	return nil
 }
 
@@ -653,6 +738,18 @@ func (s *ArticleServiceImpl) DeleteArticles(ctx context.Context, articleIdList [
 		return fmt.Errorf("删除文章标签失败: %v", err)
 	}
 
+	// 删除文章历史版本
+	if err := s.revisionDao.DeleteByArticleIds(ctx, articleIdList); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("删除文章历史版本失败: %v", err)
+	}
+
+	// 删除文章的系列关联
+	if err := s.seriesDao.DeleteByArticleIds(ctx, articleIdList); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("删除文章系列关联失败: %v", err)
+	}
+
 	// 删除文章
 	if err := s.articleDao.DeleteArticlesByIds(ctx, articleIdList); err != nil {
 		tx.Rollback()
@@ -664,6 +761,10 @@ func (s *ArticleServiceImpl) DeleteArticles(ctx context.Context, articleIdList [
 		return fmt.Errorf("事务提交失败: %v", err)
 	}
 
+	if err := s.searchStrategy.DeleteArticleIndex(ctx, articleIdList); err != nil {
+		log.Printf("Error deleting articles %v from search index: %v", articleIdList, err)
+	}
+	s.clearFeedCache(ctx)
 	return nil
 }
 
@@ -672,5 +773,541 @@ func (s *ArticleServiceImpl) ListArticlesBySearch(ctx context.Context, condition
 	if condition.Keywords != nil {
 		keywords = *condition.Keywords
 	}
-	return s.searchStrategy.ExecuteSearchStrategy(ctx, keywords)
+
+	// 未传分页参数时使用默认分页
+	current := utils.GetCurrent(ctx)
+	if condition.Current != nil && *condition.Current > 0 {
+		current = *condition.Current
+	}
+	size := utils.GetSize(ctx)
+	if condition.Size != nil && *condition.Size > 0 {
+		size = min(*condition.Size, maxSearchSize)
+	}
+	return s.searchStrategy.ExecuteSearchStrategy(ctx, keywords, current, size)
+}
+
+// RebuildSearchIndex 重建文章搜索索引
+func (s *ArticleServiceImpl) RebuildSearchIndex(ctx context.Context) (int, error) {
+	return s.searchStrategy.RebuildArticleIndex(ctx)
+}
+
+// updateSearchIndex 同步文章搜索索引，索引失败不影响文章保存，可通过重建索引修复
+func (s *ArticleServiceImpl) updateSearchIndex(ctx context.Context, articleIdList []int) {
+	if err := s.searchStrategy.UpdateArticleIndex(ctx, articleIdList); err != nil {
+		log.Printf("Error updating search index for articles %v: %v", articleIdList, err)
+	}
+}
+
+// clearFeedCache 文章发布、修改或删除后清除订阅源缓存，清除失败时等待缓存过期
+func (s *ArticleServiceImpl) clearFeedCache(ctx context.Context) {
+	if _, err := s.redisService.DelByPrefix(ctx, constants.FeedCache); err != nil {
+		log.Printf("Error clearing feed cache: %v", err)
+	}
+}
+
+// ImportArticles 导入 Hexo 文章，支持多个 md 文件或 source/_posts 目录的 zip 压缩包
+func (s *ArticleServiceImpl) ImportArticles(ctx context.Context, fileList []*multipart.FileHeader) ([]dto.ArticleImportDTO, error) {
+	resultList := make([]dto.ArticleImportDTO, 0, len(fileList))
+	for _, fileHeader := range fileList {
+		data, err := readImportFile(fileHeader)
+		if err != nil {
+			resultList = append(resultList, skippedImport(fileHeader.Filename, "", err.Error()))
+			continue
+		}
+
+		switch strings.ToLower(path.Ext(fileHeader.Filename)) {
+		case ".md", ".markdown":
+			resultList = append(resultList, s.importHexoArticle(ctx, fileHeader.Filename, data))
+		case ".zip":
+			resultList = append(resultList, s.importHexoZip(ctx, fileHeader.Filename, data)...)
+		default:
+			resultList = append(resultList, skippedImport(fileHeader.Filename, "", "unsupported file type"))
+		}
+	}
+	return resultList, nil
+}
+
+// importHexoZip 导入 zip 压缩包中的所有 md 文件
+func (s *ArticleServiceImpl) importHexoZip(ctx context.Context, fileName string, data []byte) []dto.ArticleImportDTO {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return []dto.ArticleImportDTO{skippedImport(fileName, "", fmt.Sprintf("invalid zip file: %v", err))}
+	}
+
+	var resultList []dto.ArticleImportDTO
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+		if ext := strings.ToLower(path.Ext(file.Name)); ext != ".md" && ext != ".markdown" {
+			continue
+		}
+
+		content, err := readZipFile(file)
+		if err != nil {
+			resultList = append(resultList, skippedImport(file.Name, "", err.Error()))
+			continue
+		}
+		resultList = append(resultList, s.importHexoArticle(ctx, file.Name, content))
+	}
+
+	if len(resultList) == 0 {
+		return []dto.ArticleImportDTO{skippedImport(fileName, "", "no markdown file found in zip")}
+	}
+	return resultList
+}
+
+// importHexoArticle 导入单篇 Hexo 文章，同名文章会被更新
+func (s *ArticleServiceImpl) importHexoArticle(ctx context.Context, fileName string, data []byte) dto.ArticleImportDTO {
+	hexoArticle, err := utils.ParseHexoMarkdown(data)
+	if err != nil {
+		return skippedImport(fileName, "", err.Error())
+	}
+
+	articleVO := hexoArticle.ArticleVO
+	if articleVO.CategoryName == nil {
+		categoryName := constants.DefaultCategoryName
+		articleVO.CategoryName = &categoryName
+	}
+	// 原创
+	articleType := 1
+	articleVO.Type = &articleType
+	status := hexoImportStatus(hexoArticle)
+	articleVO.Status = &status
+
+	result := enums.IMPORT_CREATED
+	articleId, err := s.articleDao.GetArticleIdByTitle(ctx, articleVO.ArticleTitle)
+	if err != nil {
+		return skippedImport(fileName, articleVO.ArticleTitle, err.Error())
+	}
+	if articleId != 0 {
+		articleVO.ID = &articleId
+		result = enums.IMPORT_UPDATED
+	}
+
+	articleId, err = s.saveOrUpdateArticle(ctx, articleVO)
+	if err != nil {
+		log.Printf("Error importing article %s: %v", fileName, err)
+		return skippedImport(fileName, articleVO.ArticleTitle, err.Error())
+	}
+
+	// 保留原文的发布时间
+	if !hexoArticle.CreateTime.IsZero() {
+		if err := s.articleDao.UpdateArticleTime(ctx, articleId, hexoArticle.CreateTime, hexoArticle.UpdateTime); err != nil {
+			log.Printf("Error keeping create time of article %d: %v", articleId, err)
+		}
+	}
+
+	return dto.ArticleImportDTO{
+		FileName:     fileName,
+		ArticleID:    articleId,
+		ArticleTitle: articleVO.ArticleTitle,
+		Result:       result.Result,
+	}
+}
+
+// hexoImportStatus 按 Front-matter 中的状态导入，导出的草稿和私密文章恢复后保持原状态；
+// 设置了密码的文章导入为私密文章，未指定状态时公开
+func hexoImportStatus(hexoArticle *utils.HexoArticle) int {
+	if hexoArticle.Password != "" {
+		return enums.SECRET.Status
+	}
+	if hexoArticle.Status != nil {
+		return *hexoArticle.Status
+	}
+	return enums.PUBLIC.Status
+}
+
+// readImportFile 读取上传的文件
+func readImportFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	if fileHeader.Size > maxImportFileSize {
+		return nil, fmt.Errorf("file size exceeds %d bytes", maxImportFileSize)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// readZipFile 读取压缩包中的文件
+func readZipFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > maxImportFileSize {
+		return nil, fmt.Errorf("file size exceeds %d bytes", maxImportFileSize)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, maxImportFileSize))
+}
+
+// skippedImport 构造跳过的导入结果
+func skippedImport(fileName, articleTitle, reason string) dto.ArticleImportDTO {
+	return dto.ArticleImportDTO{
+		FileName:     fileName,
+		ArticleTitle: articleTitle,
+		Result:       enums.IMPORT_SKIPPED.Result,
+		Reason:       reason,
+	}
+}
+
+// ExportArticles 导出文章为 Hexo 格式的 md 文件，并打包成 zip 上传
+func (s *ArticleServiceImpl) ExportArticles(ctx context.Context, articleIdList []int) ([]string, error) {
+	articles, err := s.articleDao.ListArticlesByIds(ctx, articleIdList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list articles: %w", err)
+	}
+	if len(articles) == 0 {
+		return nil, errors.New("文章不存在")
+	}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for _, article := range articles {
+		hexoArticle, err := s.buildHexoArticle(ctx, article)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := utils.BuildHexoMarkdown(hexoArticle)
+		if err != nil {
+			return nil, err
+		}
+
+		writer, err := zipWriter.CreateHeader(&zip.FileHeader{
+			Name:     utils.HexoFileName(article.ID, article.ArticleTitle),
+			Method:   zip.Deflate,
+			Modified: hexoArticle.CreateTime,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zip entry: %w", err)
+		}
+		if _, err := writer.Write(content); err != nil {
+			return nil, fmt.Errorf("failed to write zip entry: %w", err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close zip writer: %w", err)
+	}
+
+	fileName := fmt.Sprintf("articles_%s.zip", time.Now().Format("20060102150405"))
+	url, err := s.uploadStrategy.ExecuteUploadStrategyStream(fileName, &buf, enums.Markdown)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload export file: %w", err)
+	}
+	return []string{url}, nil
+}
+
+// buildHexoArticle 查询文章的分类和标签，组装导出数据
+func (s *ArticleServiceImpl) buildHexoArticle(ctx context.Context, article model.Article) (*utils.HexoArticle, error) {
+	hexoArticle := &utils.HexoArticle{UpdateTime: article.UpdateTime}
+	hexoArticle.CreateTime = article.CreateTime
+	hexoArticle.ArticleTitle = article.ArticleTitle
+	hexoArticle.ArticleContent = article.ArticleContent
+	hexoArticle.ArticleCover = &article.ArticleCover
+	hexoArticle.IsTop = article.IsTop
+	hexoArticle.Status = article.Status
+
+	category, err := s.categoryDao.GetCategoryByID(ctx, article.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category != nil {
+		hexoArticle.CategoryName = &category.Name
+	}
+
+	tagNameList, err := s.tagDao.ListTagNameByArticleId(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	hexoArticle.TagNameList = tagNameList
+	return hexoArticle, nil
+}
+
+// CancelArticleSchedule 取消文章的定时发布，文章保留为草稿
+func (s *ArticleServiceImpl) CancelArticleSchedule(ctx context.Context, articleId int) error {
+	rows, err := s.articleDao.CancelArticleSchedule(ctx, articleId, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to cancel article schedule: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("文章没有待发布的定时任务")
+	}
+	return nil
+}
+
+// ListArticleRevisions 查询文章的历史版本
+func (s *ArticleServiceImpl) ListArticleRevisions(ctx context.Context, articleId int) ([]dto.ArticleRevisionDTO, error) {
+	revisions, err := s.revisionDao.ListByArticleId(ctx, articleId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list article revisions: %w", err)
+	}
+
+	revisionList := make([]dto.ArticleRevisionDTO, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionList = append(revisionList, toArticleRevisionDTO(revision))
+	}
+	return revisionList, nil
+}
+
+// GetArticleRevisionDiff 比较文章的两个历史版本
+func (s *ArticleServiceImpl) GetArticleRevisionDiff(ctx context.Context, articleId int, oldRevisionId int, newRevisionId int) (*dto.ArticleRevisionDiffDTO, error) {
+	oldRevision, err := s.getArticleRevision(ctx, articleId, oldRevisionId)
+	if err != nil {
+		return nil, err
+	}
+	newRevision, err := s.getArticleRevision(ctx, articleId, newRevisionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ArticleRevisionDiffDTO{
+		OldRevision: toArticleRevisionDTO(*oldRevision),
+		NewRevision: toArticleRevisionDTO(*newRevision),
+		LineList:    utils.DiffLines(oldRevision.ArticleContent, newRevision.ArticleContent),
+	}, nil
+}
+
+// RestoreArticleRevision 将文章恢复到指定版本，恢复后会生成新的版本。
+// 文章内容、标签和新版本在同一事务中保存，标签替换为该版本的标签
+func (s *ArticleServiceImpl) RestoreArticleRevision(ctx context.Context, articleId int, revisionId int) error {
+	revision, err := s.getArticleRevision(ctx, articleId, revisionId)
+	if err != nil {
+		return err
+	}
+
+	articles, err := s.articleDao.ListArticlesByIds(ctx, []int{articleId})
+	if err != nil {
+		return fmt.Errorf("failed to get article: %w", err)
+	}
+	if len(articles) == 0 {
+		return errors.New("文章不存在")
+	}
+
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return errors.New("failed to get login user")
+	}
+
+	// 封面、类型等未纳入版本的字段保持当前值
+	article := articles[0]
+	article.ArticleTitle = revision.ArticleTitle
+	article.ArticleContent = revision.ArticleContent
+	status := revision.Status
+	// 定时发布的文章在发布前保持草稿
+	if article.PublishTime != nil && article.PublishTime.After(time.Now()) {
+		status = enums.DRAFT.Status
+	}
+	article.Status = &status
+
+	category, err := s.saveArticleCategory(ctx, vo.ArticleVO{CategoryName: &revision.CategoryName, Status: &status})
+	if err != nil {
+		return err
+	}
+	article.CategoryID = category.ID
+
+	tagNameList := parseRevisionTagNames(revision.TagNames)
+	tagNames, err := json.Marshal(tagNameList)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := dao.NewArticleDao(tx).UpdateArticle(ctx, &article); err != nil {
+			return fmt.Errorf("failed to update article: %w", err)
+		}
+		if err := replaceArticleTags(ctx, tx, articleId, tagNameList); err != nil {
+			return fmt.Errorf("failed to replace article tags: %w", err)
+		}
+
+		// 保存恢复后的内容为新版本
+		revisionDao := dao.NewArticleRevisionDao(tx)
+		if err := revisionDao.Insert(ctx, &model.ArticleRevision{
+			ArticleID:      articleId,
+			UserID:         user.UserInfoID,
+			ArticleTitle:   revision.ArticleTitle,
+			ArticleContent: revision.ArticleContent,
+			CategoryName:   revision.CategoryName,
+			TagNames:       string(tagNames),
+			Status:         status,
+		}); err != nil {
+			return fmt.Errorf("failed to save article revision: %w", err)
+		}
+		limit := s.revisionLimit
+		if limit <= 0 {
+			limit = defaultRevisionLimit
+		}
+		return revisionDao.DeleteOutdated(ctx, articleId, limit)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore article revision: %w", err)
+	}
+
+	s.updateSearchIndex(ctx, []int{articleId})
+	s.clearFeedCache(ctx)
+	return nil
+}
+
+// replaceArticleTags 将文章标签替换为 tagNameList，不存在的标签会新建，不在列表中的关联会删除
+func replaceArticleTags(ctx context.Context, tx *gorm.DB, articleId int, tagNameList []string) error {
+	tagDao := dao.NewTagDao(tx)
+	articleTagDao := dao.NewArticleTagDao(tx)
+
+	tagIds := make(map[string]int, len(tagNameList))
+	if len(tagNameList) > 0 {
+		existTagList, err := tagDao.ListTagsByNames(ctx, tagNameList)
+		if err != nil {
+			return err
+		}
+		for _, tag := range existTagList {
+			tagIds[tag.TagName] = tag.ID
+		}
+	}
+
+	// 新建不存在的标签
+	var newTagList []model.Tag
+	for _, tagName := range tagNameList {
+		if _, ok := tagIds[tagName]; ok {
+			continue
+		}
+		tagIds[tagName] = 0
+		newTagList = append(newTagList, model.Tag{TagName: tagName})
+	}
+	if len(newTagList) > 0 {
+		if err := tagDao.SaveBatch(ctx, newTagList); err != nil {
+			return err
+		}
+		for _, tag := range newTagList {
+			tagIds[tag.TagName] = tag.ID
+		}
+	}
+
+	tagIdList := make([]int, 0, len(tagIds))
+	for _, tagName := range tagNameList {
+		if tagId, ok := tagIds[tagName]; ok {
+			tagIdList = append(tagIdList, tagId)
+			delete(tagIds, tagName)
+		}
+	}
+	if err := articleTagDao.DeleteByArticleIdNotInTagIds(ctx, articleId, tagIdList); err != nil {
+		return err
+	}
+
+	// 补充缺少的关联
+	articleTagList, err := articleTagDao.ListByArticleId(ctx, articleId)
+	if err != nil {
+		return err
+	}
+	linkedTagIds := make(map[int]bool, len(articleTagList))
+	for _, articleTag := range articleTagList {
+		linkedTagIds[articleTag.TagID] = true
+	}
+	var newArticleTagList []model.ArticleTag
+	for _, tagId := range tagIdList {
+		if !linkedTagIds[tagId] {
+			newArticleTagList = append(newArticleTagList, model.ArticleTag{ArticleID: articleId, TagID: tagId})
+		}
+	}
+	if len(newArticleTagList) == 0 {
+		return nil
+	}
+	return articleTagDao.SaveBatch(newArticleTagList)
+}
+
+// getArticleRevision 查询属于指定文章的历史版本
+func (s *ArticleServiceImpl) getArticleRevision(ctx context.Context, articleId int, revisionId int) (*model.ArticleRevision, error) {
+	revision, err := s.revisionDao.GetById(ctx, revisionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article revision: %w", err)
+	}
+	if revision == nil || revision.ArticleID != articleId {
+		return nil, errors.New("历史版本不存在")
+	}
+	return revision, nil
+}
+
+// saveBaselineRevision 文章还没有历史版本时，保存当前内容作为初始版本
+func (s *ArticleServiceImpl) saveBaselineRevision(ctx context.Context, articleId int) error {
+	count, err := s.revisionDao.CountByArticleId(ctx, articleId)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	articles, err := s.articleDao.ListArticlesByIds(ctx, []int{articleId})
+	if err != nil || len(articles) == 0 {
+		return err
+	}
+	return s.saveArticleRevision(ctx, articleId, articles[0].UserID)
+}
+
+// saveArticleRevision 保存文章当前内容为新版本，并清理超出保留数量的旧版本
+func (s *ArticleServiceImpl) saveArticleRevision(ctx context.Context, articleId int, userId int) error {
+	articles, err := s.articleDao.ListArticlesByIds(ctx, []int{articleId})
+	if err != nil {
+		return fmt.Errorf("failed to get article: %w", err)
+	}
+	if len(articles) == 0 {
+		return nil
+	}
+
+	hexoArticle, err := s.buildHexoArticle(ctx, articles[0])
+	if err != nil {
+		return err
+	}
+	tagNames, err := json.Marshal(hexoArticle.TagNameList)
+	if err != nil {
+		return err
+	}
+
+	revision := &model.ArticleRevision{
+		ArticleID:      articleId,
+		UserID:         userId,
+		ArticleTitle:   hexoArticle.ArticleTitle,
+		ArticleContent: hexoArticle.ArticleContent,
+		TagNames:       string(tagNames),
+	}
+	if hexoArticle.CategoryName != nil {
+		revision.CategoryName = *hexoArticle.CategoryName
+	}
+	if hexoArticle.Status != nil {
+		revision.Status = *hexoArticle.Status
+	}
+	if err := s.revisionDao.Insert(ctx, revision); err != nil {
+		return fmt.Errorf("failed to save article revision: %w", err)
+	}
+
+	limit := s.revisionLimit
+	if limit <= 0 {
+		limit = defaultRevisionLimit
+	}
+	return s.revisionDao.DeleteOutdated(ctx, articleId, limit)
+}
+
+// toArticleRevisionDTO 转换历史版本
+func toArticleRevisionDTO(revision model.ArticleRevision) dto.ArticleRevisionDTO {
+	return dto.ArticleRevisionDTO{
+		ID:           revision.ID,
+		ArticleID:    revision.ArticleID,
+		UserID:       revision.UserID,
+		ArticleTitle: revision.ArticleTitle,
+		CategoryName: revision.CategoryName,
+		TagNameList:  parseRevisionTagNames(revision.TagNames),
+		Status:       revision.Status,
+		CreateTime:   revision.CreateTime,
+	}
+}
+
+// parseRevisionTagNames 解析历史版本中保存的标签名
+func parseRevisionTagNames(tagNames string) []string {
+	var tagNameList []string
+	if tagNames == "" {
+		return tagNameList
+	}
+	if err := json.Unmarshal([]byte(tagNames), &tagNameList); err != nil {
+		log.Printf("Error parsing revision tag names: %v", err)
+	}
+	return tagNameList
 }
@@ -0,0 +1,185 @@
+package Impl
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"goBolg/enums"
+	"goBolg/utils"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestHexoExportImportRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		password   string
+		wantStatus int
+	}{
+		{"公开文章", enums.PUBLIC.Status, "", enums.PUBLIC.Status},
+		{"私密文章", enums.SECRET.Status, "", enums.SECRET.Status},
+		{"草稿", enums.DRAFT.Status, "", enums.DRAFT.Status},
+		{"设置了密码的文章导入为私密文章", enums.PUBLIC.Status, "123456", enums.SECRET.Status},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createTime := time.Date(2024, 5, 1, 8, 30, 0, 0, time.Local)
+			updateTime := createTime.Add(time.Hour)
+			category := "Go"
+			cover := "https://example.com/cover.png"
+			isTop := 1
+			status := tt.status
+
+			exported := &utils.HexoArticle{UpdateTime: &updateTime}
+			exported.ArticleTitle = "测试文章"
+			exported.ArticleContent = "# 标题\n\n正文"
+			exported.CreateTime = createTime
+			exported.CategoryName = &category
+			exported.ArticleCover = &cover
+			exported.TagNameList = []string{"go", "博客"}
+			exported.IsTop = &isTop
+			exported.Status = &status
+
+			content, err := utils.BuildHexoMarkdown(exported)
+			if err != nil {
+				t.Fatalf("BuildHexoMarkdown returned error: %v", err)
+			}
+			imported, err := utils.ParseHexoMarkdown(content)
+			if err != nil {
+				t.Fatalf("ParseHexoMarkdown returned error: %v", err)
+			}
+			imported.Password = tt.password
+
+			if got := hexoImportStatus(imported); got != tt.wantStatus {
+				t.Errorf("hexoImportStatus = %d, want %d", got, tt.wantStatus)
+			}
+			if imported.ArticleTitle != exported.ArticleTitle || imported.ArticleContent != exported.ArticleContent {
+				t.Errorf("imported article = %q %q, want %q %q", imported.ArticleTitle, imported.ArticleContent, exported.ArticleTitle, exported.ArticleContent)
+			}
+			if !imported.CreateTime.Equal(createTime) || imported.UpdateTime == nil || !imported.UpdateTime.Equal(updateTime) {
+				t.Errorf("imported time = %v %v, want %v %v", imported.CreateTime, imported.UpdateTime, createTime, updateTime)
+			}
+			if imported.CategoryName == nil || *imported.CategoryName != category {
+				t.Errorf("imported category = %v, want %s", imported.CategoryName, category)
+			}
+			if imported.ArticleCover == nil || *imported.ArticleCover != cover {
+				t.Errorf("imported cover = %v, want %s", imported.ArticleCover, cover)
+			}
+			if !reflect.DeepEqual(imported.TagNameList, exported.TagNameList) {
+				t.Errorf("imported tags = %v, want %v", imported.TagNameList, exported.TagNameList)
+			}
+			if imported.IsTop == nil || *imported.IsTop != 1 {
+				t.Errorf("imported isTop = %v, want 1", imported.IsTop)
+			}
+		})
+	}
+}
+
+func TestHexoImportStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   int
+	}{
+		{"未指定状态时公开", "---\ntitle: a\n---\nbody", enums.PUBLIC.Status},
+		{"草稿", "---\ntitle: a\nstatus: draft\n---\nbody", enums.DRAFT.Status},
+		{"状态忽略大小写", "---\ntitle: a\nstatus: Secret\n---\nbody", enums.SECRET.Status},
+		{"未知状态时公开", "---\ntitle: a\nstatus: archived\n---\nbody", enums.PUBLIC.Status},
+		{"密码优先于状态", "---\ntitle: a\nstatus: draft\npassword: 123\n---\nbody", enums.SECRET.Status},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := utils.ParseHexoMarkdown([]byte(tt.source))
+			if err != nil {
+				t.Fatalf("ParseHexoMarkdown returned error: %v", err)
+			}
+			if got := hexoImportStatus(article); got != tt.want {
+				t.Errorf("hexoImportStatus = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestDB 使用 sqlmock 创建 gorm 连接
+func newTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open returned error: %v", err)
+	}
+	return db, mock
+}
+
+func TestReplaceArticleTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		tagNameList []string
+		expect      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "新建缺少的标签并替换关联",
+			tagNameList: []string{"go", "博客", "go"},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tb_tag` WHERE tag_name IN (?,?,?)")).
+					WithArgs("go", "博客", "go").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "tag_name"}).AddRow(1, "go"))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tb_tag`")).
+					WithArgs("博客", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tb_article_tag` WHERE article_id = ? AND tag_id NOT IN (?,?)")).
+					WithArgs(10, 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tb_article_tag` WHERE article_id = ?")).
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "article_id", "tag_id"}).AddRow(100, 10, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tb_article_tag`")).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 10, 2).
+					WillReturnResult(sqlmock.NewResult(101, 1))
+			},
+		},
+		{
+			name:        "版本没有标签时删除全部关联",
+			tagNameList: nil,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tb_article_tag` WHERE article_id = ?")).
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tb_article_tag` WHERE article_id = ?")).
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "article_id", "tag_id"}))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newTestDB(t)
+			mock.ExpectBegin()
+			tt.expect(mock)
+			mock.ExpectCommit()
+
+			ctx := context.Background()
+			err := db.Transaction(func(tx *gorm.DB) error {
+				return replaceArticleTags(ctx, tx, 10, tt.tagNameList)
+			})
+			if err != nil {
+				t.Fatalf("replaceArticleTags returned error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
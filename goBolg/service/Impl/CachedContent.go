@@ -0,0 +1,47 @@
+package Impl
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"goBolg/dto"
+	"goBolg/service"
+	"log"
+	"time"
+)
+
+// getCachedContent 优先读取缓存，缓存失效后调用 build 生成内容并计算 ETag 和最后修改时间，build 返回 false 表示内容不存在
+func getCachedContent(ctx context.Context, redisService service.RedisService, cacheKey string, ttl time.Duration, build func() ([]byte, time.Time, bool, error)) (*dto.CachedContentDTO, error) {
+	if cached, err := redisService.Get(ctx, cacheKey); err == nil {
+		var cachedContent dto.CachedContentDTO
+		if err := json.Unmarshal([]byte(cached), &cachedContent); err == nil {
+			return &cachedContent, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		log.Printf("Error reading cache %s: %v", cacheKey, err)
+	}
+
+	content, lastModified, found, err := build()
+	if err != nil || !found {
+		return nil, err
+	}
+	if lastModified.IsZero() {
+		lastModified = time.Now()
+	}
+
+	sum := sha1.Sum(content)
+	cachedContent := &dto.CachedContentDTO{
+		Content:      string(content),
+		ETag:         `"` + hex.EncodeToString(sum[:]) + `"`,
+		LastModified: lastModified.UTC().Truncate(time.Second),
+	}
+	if data, err := json.Marshal(cachedContent); err == nil {
+		if err := redisService.Set(ctx, cacheKey, string(data), ttl); err != nil {
+			log.Printf("Error writing cache %s: %v", cacheKey, err)
+		}
+	}
+	return cachedContent, nil
+}
@@ -3,51 +3,77 @@ package Impl
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
+	"goBolg/config"
 	constants "goBolg/constant"
 	"goBolg/dao"
 	"goBolg/dto"
 	"goBolg/enums"
+	"goBolg/exception"
 	"goBolg/model"
 	"goBolg/rabbitmq"
 	"goBolg/service"
 	"goBolg/utils"
 	"goBolg/vo"
-	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
 	"log"
+	"sort"
 	"strconv"
 	"time"
 )
 
+// 评论树配置
+const (
+	defaultCommentReplySize = 10 // 未配置时每页的评论数量
+	maxCommentPageSize      = 50 // 每页评论数量的最大值
+	maxCommentTreeLevel     = 64 // 未配置时允许展开的最大层级，查找所在层级时也用于防止异常数据形成环
+)
+
 // commentServiceImpl 实现了 CommentService 接口
 type commentServiceImpl struct {
-	commentDao      dao.CommentDao
-	articleDao      dao.ArticleDao
-	talkDao         dao.TalkDao
-	userInfoDao     dao.UserInfoDao
-	rabbitMQClient  *rabbitmq.RabbitMQClient
-	redisService    service.RedisService
-	blogInfoService service.BlogInfoService
-	websiteUrl      string
+	commentDao         dao.CommentDao
+	commentRevisionDao dao.CommentRevisionDao
+	articleDao         dao.ArticleDao
+	talkDao            dao.TalkDao
+	userInfoDao        dao.UserInfoDao
+	rabbitMQClient     *rabbitmq.RabbitMQClient
+	redisService       service.RedisService
+	blogInfoService    service.BlogInfoService
+	websiteUrl         string
+	commentConfig      config.CommentConfig
+
+	sensitiveWordService service.SensitiveWordService
+	spamService          service.SpamService
+	notificationService  service.NotificationService
 }
 
 // NewCommentServiceImpl 创建一个新的 commentServiceImpl 实例
-func NewCommentServiceImpl(commentDao dao.CommentDao, articleDao dao.ArticleDao, talkDao dao.TalkDao, userInfoDao dao.UserInfoDao, redisService service.RedisService, rabbitMQClient *rabbitmq.RabbitMQClient, blogInfoService service.BlogInfoService, websiteUrl string) service.CommentService {
+func NewCommentServiceImpl(commentDao dao.CommentDao, commentRevisionDao dao.CommentRevisionDao, articleDao dao.ArticleDao, talkDao dao.TalkDao, userInfoDao dao.UserInfoDao, redisService service.RedisService, rabbitMQClient *rabbitmq.RabbitMQClient, blogInfoService service.BlogInfoService, websiteUrl string, commentConfig config.CommentConfig, sensitiveWordService service.SensitiveWordService, spamService service.SpamService, notificationService service.NotificationService) service.CommentService {
 	return &commentServiceImpl{
-		commentDao:      commentDao,
-		articleDao:      articleDao,
-		talkDao:         talkDao,
-		userInfoDao:     userInfoDao,
-		redisService:    redisService,
-		rabbitMQClient:  rabbitMQClient,
-		blogInfoService: blogInfoService,
-		websiteUrl:      websiteUrl,
+		commentDao:         commentDao,
+		commentRevisionDao: commentRevisionDao,
+		articleDao:         articleDao,
+		talkDao:            talkDao,
+		userInfoDao:        userInfoDao,
+		redisService:       redisService,
+		rabbitMQClient:     rabbitMQClient,
+		blogInfoService:    blogInfoService,
+		websiteUrl:         websiteUrl,
+		commentConfig:      commentConfig,
+
+		sensitiveWordService: sensitiveWordService,
+		spamService:          spamService,
+		notificationService:  notificationService,
 	}
 }
 
 // ListComments 实现了 CommentService 接口中的 ListComments 方法
 func (s *commentServiceImpl) ListComments(ctx context.Context, commentVO vo.CommentVO, current int, size int) ([]dto.CommentDTO, int, error) {
+	if err := checkCommentTopic(&commentVO); err != nil {
+		return nil, 0, err
+	}
 	commentDTOList, err := s.commentDao.ListComments(ctx, current, size, commentVO)
 	if err != nil {
 		return nil, 0, err
@@ -114,38 +140,331 @@ func (s *commentServiceImpl) ListComments(ctx context.Context, commentVO vo.Comm
 	return commentDTOList, len(commentDTOList), nil
 }
 
-// ListRepliesByCommentId 实现了 CommentService 接口中的 ListRepliesByCommentId 方法
-func (s *commentServiceImpl) ListRepliesByCommentId(ctx context.Context, commentId int) ([]dto.ReplyDTO, error) {
-	current := utils.GetCurrent(ctx)
-	size := utils.GetSize(ctx)
+// ListCommentTree 游标分页查询顶层评论，并为每条评论展开 depth 层回复
+func (s *commentServiceImpl) ListCommentTree(ctx context.Context, commentVO vo.CommentVO, cursor *utils.Cursor, size int, depth int) (*dto.CommentTreePageDTO, error) {
+	if err := checkCommentTopic(&commentVO); err != nil {
+		return nil, err
+	}
+	size = s.commentPageSize(size, utils.GetSize(ctx))
+	depth = s.commentTreeDepth(depth, 0)
 
-	// Log the pagination parameters and comment ID
-	log.Printf("Fetching replies for commentId: %d, current page: %d, size: %d", commentId, current, size)
+	// 多查一条用于判断是否还有下一页
+	roots, err := s.commentDao.ListTopCommentTreeNodes(ctx, commentVO, cursor, size+1)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.commentDao.CountReviewedComments(ctx, commentVO)
+	if err != nil {
+		return nil, err
+	}
 
-	replyDTOList, err := s.commentDao.ListRepliesByCommentId(ctx, current, size, commentId)
+	page := &dto.CommentTreePageDTO{RecordList: []*dto.CommentTreeDTO{}, Count: count}
+	if len(roots) > size {
+		roots = roots[:size]
+		page.HasMore = true
+		page.NextCursor = utils.EncodeCursor(roots[size-1].CreateTime, roots[size-1].ID)
+	}
+	rootIdList := make([]int, 0, len(roots))
+	for i := range roots {
+		page.RecordList = append(page.RecordList, &roots[i])
+		rootIdList = append(rootIdList, roots[i].ID)
+	}
+
+	thread, err := s.loadCommentThread(ctx, rootIdList)
 	if err != nil {
-		log.Printf("Database query failed for comment ID: %d, error: %v", commentId, err)
 		return nil, err
 	}
+	if err := s.buildCommentTrees(ctx, thread, page.RecordList, depth); err != nil {
+		return nil, err
+	}
+	if err := s.fillCommentLikeCount(ctx, page.RecordList); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
 
-	likeCountMap, err := s.redisService.HGetAll(ctx, constants.CommentLikeCount)
+// ListRepliesByCommentId 游标分页查询评论下的直接回复，并为每条回复展开 depth - 1 层回复
+func (s *commentServiceImpl) ListRepliesByCommentId(ctx context.Context, commentId int, cursor *utils.Cursor, size int, depth int) (*dto.CommentTreePageDTO, error) {
+	size = s.commentPageSize(size, s.commentConfig.ReplySize)
+	depth = s.commentTreeDepth(depth, 1)
+
+	// 旧数据的回复都挂在顶层评论下，需要查询整棵树的回复关系才能找到真正的子评论
+	rootId, err := s.findRootCommentId(ctx, commentId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	thread, err := s.loadCommentThread(ctx, []int{rootId})
 	if err != nil {
-		log.Printf("Error getting like count from Redis for comment ID: %d, error: %v", commentId, err)
 		return nil, err
 	}
+	// 评论未审核、已删除或所在分支不可见
+	if _, exists := thread.links[commentId]; !exists {
+		return nil, nil
+	}
 
-	for i := range replyDTOList {
-		replyIDStr := strconv.Itoa(replyDTOList[i].ID)
-		if likeCount, ok := likeCountMap[replyIDStr]; ok {
-			replyDTOList[i].LikeCount, _ = strconv.Atoi(likeCount)
-		} else {
-			replyDTOList[i].LikeCount = 0
-			log.Printf("No like count found for reply ID: %d", replyDTOList[i].ID)
+	branch := thread.pageChildren(commentId, cursor, size)
+	children, err := s.listCommentTreeNodes(ctx, branch.idList)
+	if err != nil {
+		return nil, err
+	}
+	page := &dto.CommentTreePageDTO{
+		RecordList: children,
+		Count:      thread.replyCount[commentId],
+		HasMore:    branch.hasMore,
+		NextCursor: branch.nextCursor,
+	}
+	if err := s.buildCommentTrees(ctx, thread, page.RecordList, depth-1); err != nil {
+		return nil, err
+	}
+	if err := s.fillCommentLikeCount(ctx, page.RecordList); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// commentPageSize 未指定每页数量时使用默认值，并限制最大值
+func (s *commentServiceImpl) commentPageSize(size int, defaultSize int) int {
+	if size <= 0 {
+		size = defaultSize
+	}
+	if size <= 0 {
+		size = defaultCommentReplySize
+	}
+	return min(size, maxCommentPageSize)
+}
+
+// commentTreeDepth 未指定层级时使用配置的默认层级，并限制在 [minDepth, 最大层级] 范围内
+func (s *commentServiceImpl) commentTreeDepth(depth int, minDepth int) int {
+	if depth <= 0 {
+		depth = s.commentConfig.TreeDepth
+	}
+	maxDepth := s.commentConfig.MaxTreeDepth
+	if maxDepth <= 0 {
+		maxDepth = maxCommentTreeLevel
+	}
+	return max(minDepth, min(depth, maxDepth))
+}
+
+// findRootCommentId 沿父评论向上查找顶层评论
+func (s *commentServiceImpl) findRootCommentId(ctx context.Context, commentId int) (int, error) {
+	for level := 0; level < maxCommentTreeLevel; level++ {
+		parentId, err := s.commentDao.GetCommentParentId(ctx, commentId)
+		if err != nil {
+			return 0, err
+		}
+		if parentId == 0 {
+			return commentId, nil
+		}
+		commentId = parentId
+	}
+	return 0, fmt.Errorf("comment %d exceeds max tree level", commentId)
+}
+
+// loadCommentThread 查询顶层评论下全部回复的父子关系
+func (s *commentServiceImpl) loadCommentThread(ctx context.Context, rootIdList []int) (*commentThread, error) {
+	links, err := s.commentDao.ListCommentTreeLinks(ctx, rootIdList, maxCommentTreeLevel)
+	if err != nil {
+		return nil, err
+	}
+	return newCommentThread(links), nil
+}
+
+// listCommentTreeNodes 查询评论节点，按 commentIdList 的顺序返回
+func (s *commentServiceImpl) listCommentTreeNodes(ctx context.Context, commentIdList []int) ([]*dto.CommentTreeDTO, error) {
+	nodes, err := s.commentDao.ListCommentTreeNodesByIds(ctx, commentIdList)
+	if err != nil {
+		return nil, err
+	}
+	nodeMap := make(map[int]*dto.CommentTreeDTO, len(nodes))
+	for i := range nodes {
+		nodeMap[nodes[i].ID] = &nodes[i]
+	}
+	result := make([]*dto.CommentTreeDTO, 0, len(commentIdList))
+	for _, commentId := range commentIdList {
+		if node, exists := nodeMap[commentId]; exists {
+			result = append(result, node)
+		}
+	}
+	return result, nil
+}
+
+// buildCommentTrees 为评论逐层展开 depth 层回复，每个分支只返回第一页，未展开的分支由游标继续加载。
+// 先按父子关系确定要展开的评论，再一次查询这些评论的内容
+func (s *commentServiceImpl) buildCommentTrees(ctx context.Context, thread *commentThread, nodes []*dto.CommentTreeDTO, depth int) error {
+	size := s.commentPageSize(s.commentConfig.ReplySize, 0)
+	branchMap := make(map[int]commentBranch)
+	var commentIdList []int
+	frontier := make([]int, 0, len(nodes))
+	for _, node := range nodes {
+		frontier = append(frontier, node.ID)
+	}
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		var next []int
+		for _, commentId := range frontier {
+			branch := thread.pageChildren(commentId, nil, size)
+			branchMap[commentId] = branch
+			next = append(next, branch.idList...)
+		}
+		commentIdList = append(commentIdList, next...)
+		frontier = next
+	}
+
+	children, err := s.listCommentTreeNodes(ctx, commentIdList)
+	if err != nil {
+		return err
+	}
+	nodeMap := make(map[int]*dto.CommentTreeDTO, len(children))
+	for _, child := range children {
+		nodeMap[child.ID] = child
+	}
+
+	var attach func(node *dto.CommentTreeDTO)
+	attach = func(node *dto.CommentTreeDTO) {
+		node.Depth = thread.depth[node.ID]
+		node.ReplyCount = thread.replyCount[node.ID]
+		node.Children = []*dto.CommentTreeDTO{}
+		branch, expanded := branchMap[node.ID]
+		if !expanded {
+			// 超过层级未展开的分支只返回回复数量
+			node.HasMore = len(thread.children[node.ID]) > 0
+			return
+		}
+		node.HasMore = branch.hasMore
+		node.NextCursor = branch.nextCursor
+		for _, childId := range branch.idList {
+			child, exists := nodeMap[childId]
+			if !exists {
+				continue
+			}
+			child.ParentID = node.ID
+			node.Children = append(node.Children, child)
+			attach(child)
+		}
+	}
+	for _, node := range nodes {
+		attach(node)
+	}
+	return nil
+}
+
+// commentThread 顶层评论下全部回复的父子关系
+type commentThread struct {
+	links      map[int]dto.CommentTreeLinkDTO // 评论的父子关系，父评论已按被回复用户修正
+	children   map[int][]int                  // 按创建时间升序排列的子评论 id
+	depth      map[int]int                    // 所在层级，顶层评论为 0
+	replyCount map[int]int                    // 全部后代回复的数量
+}
+
+// commentBranch 分支中的一页子评论
+type commentBranch struct {
+	idList     []int  // 子评论 id
+	hasMore    bool   // 是否还有更多子评论
+	nextCursor string // 下一页的游标
+}
+
+// newCommentThread 组装评论的父子关系并统计后代回复数量。旧版本只有两级评论，回复都挂在顶层评论下，
+// 通过被回复用户找到同一棵树中该用户此前最近的一条评论作为真正的父评论
+func newCommentThread(links []dto.CommentTreeLinkDTO) *commentThread {
+	thread := &commentThread{
+		links:      make(map[int]dto.CommentTreeLinkDTO, len(links)),
+		children:   make(map[int][]int),
+		depth:      make(map[int]int, len(links)),
+		replyCount: make(map[int]int, len(links)),
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if !links[i].CreateTime.Equal(links[j].CreateTime) {
+			return links[i].CreateTime.Before(links[j].CreateTime)
 		}
+		return links[i].ID < links[j].ID
+	})
+
+	// 记录每棵树中各用户最近一条挂在顶层评论下的评论，只在这些评论中查找父评论，
+	// 父评论总是更早的评论，不会形成环
+	var rootIdList []int
+	latestMap := make(map[int]map[int]int)
+	for _, link := range links {
+		if link.ID == link.RootID {
+			thread.links[link.ID] = link
+			rootIdList = append(rootIdList, link.ID)
+			latestMap[link.ID] = map[int]int{link.UserID: link.ID}
+		}
+	}
+	for _, link := range links {
+		if _, exists := thread.links[link.ID]; exists {
+			continue
+		}
+		root := thread.links[link.RootID]
+		if link.ParentID == root.ID {
+			if link.ReplyUserID != 0 && link.ReplyUserID != root.UserID {
+				if replied, exists := latestMap[root.ID][link.ReplyUserID]; exists {
+					link.ParentID = replied
+				}
+			}
+			latestMap[root.ID][link.UserID] = link.ID
+		}
+		thread.links[link.ID] = link
+		thread.children[link.ParentID] = append(thread.children[link.ParentID], link.ID)
+	}
+
+	for _, rootId := range rootIdList {
+		thread.countReplies(rootId, 0)
+	}
+	return thread
+}
+
+// countReplies 设置评论及其回复的层级，返回后代回复数量
+func (t *commentThread) countReplies(commentId int, depth int) int {
+	t.depth[commentId] = depth
+	count := 0
+	for _, childId := range t.children[commentId] {
+		count += t.countReplies(childId, depth+1) + 1
 	}
+	t.replyCount[commentId] = count
+	return count
+}
 
-	log.Printf("Successfully fetched replies for comment ID: %d", commentId)
-	return replyDTOList, nil
+// pageChildren 按创建时间升序返回游标之后的一页子评论
+func (t *commentThread) pageChildren(commentId int, cursor *utils.Cursor, size int) commentBranch {
+	children := t.children[commentId]
+	start := 0
+	if cursor != nil {
+		start = sort.Search(len(children), func(i int) bool {
+			link := t.links[children[i]]
+			return link.CreateTime.After(cursor.CreateTime) || (link.CreateTime.Equal(cursor.CreateTime) && link.ID > cursor.ID)
+		})
+	}
+	end := min(len(children), start+size)
+	branch := commentBranch{idList: children[start:end]}
+	if end < len(children) && end > start {
+		last := t.links[children[end-1]]
+		branch.hasMore = true
+		branch.nextCursor = utils.EncodeCursor(last.CreateTime, last.ID)
+	}
+	return branch
+}
+
+// fillCommentLikeCount 从 Redis 中读取树中全部评论的点赞量
+func (s *commentServiceImpl) fillCommentLikeCount(ctx context.Context, nodes []*dto.CommentTreeDTO) error {
+	likeCountMap, err := s.redisService.HGetAll(ctx, constants.CommentLikeCount)
+	if err != nil {
+		log.Printf("Error getting like count from Redis: %v", err)
+		return err
+	}
+
+	var fill func(nodes []*dto.CommentTreeDTO)
+	fill = func(nodes []*dto.CommentTreeDTO) {
+		for _, node := range nodes {
+			if likeCount, exists := likeCountMap[strconv.Itoa(node.ID)]; exists {
+				node.LikeCount, _ = strconv.Atoi(likeCount)
+			}
+			fill(node.Children)
+		}
+	}
+	fill(nodes)
+	return nil
 }
 
 // SaveComment saves a new comment.
@@ -158,12 +477,30 @@ func (s *commentServiceImpl) SaveComment(ctx context.Context, commentVO vo.Comme
 		return dto.CommentDTO{}, fmt.Errorf("failed to get website config: %w", err)
 	}
 
+	// 校验评论类型，友链评论不关联主题
+	commentEnum := enums.GetCommentEnum(commentVO.Type)
+	if commentEnum == nil {
+		return dto.CommentDTO{}, exception.NewBizError(enums.VALID_ERROR.Code, "评论类型不存在")
+	}
+	commentVO.TopicID = commentTopicId(commentVO.Type, commentVO.TopicID)
+	if commentEnum.HasTopic && commentVO.TopicID == 0 {
+		return dto.CommentDTO{}, exception.NewBizError(enums.VALID_ERROR.Code, "评论主题不能为空")
+	}
+
 	// 判断是否需要审核
 	isReview := websiteConfig.IsCommentReview
-	// 过滤标签
-	htmlUtils := utils.HTMLUtils{}
-	commentContent := htmlUtils.Filter(commentVO.CommentContent)
-	log.Printf("过滤后的评论内容: %s", commentContent)
+
+	// 敏感词检查，命中审核类敏感词时转为人工审核
+	commentSource, needReview, err := checkSensitiveContent(s.sensitiveWordService, commentVO.CommentContent)
+	if err != nil {
+		return dto.CommentDTO{}, err
+	}
+	// 渲染 markdown，只保留允许的标签
+	commentContent, err := renderCommentContent(commentSource)
+	if err != nil {
+		return dto.CommentDTO{}, err
+	}
+	log.Printf("渲染后的评论内容: %s", commentContent)
 
 	user, ok := utils.GetLoginUser(ctx)
 	if !ok {
@@ -172,6 +509,16 @@ func (s *commentServiceImpl) SaveComment(ctx context.Context, commentVO vo.Comme
 	}
 	log.Printf("登录用户: %+v\n", user)
 
+	// 垃圾内容评分，评分较高时转为人工审核
+	spamScore, spamReview, err := checkSpamContent(ctx, s.spamService, dto.SpamCheckDTO{
+		Content:   commentSource,
+		UserID:    user.UserInfoID,
+		IPAddress: utils.GetIPAddressFromContext(ctx),
+	})
+	if err != nil {
+		return dto.CommentDTO{}, err
+	}
+
 	var replyUserID, parentID *int
 	if commentVO.ReplyUserID != 0 {
 		replyUserID = &commentVO.ReplyUserID
@@ -185,14 +532,16 @@ func (s *commentServiceImpl) SaveComment(ctx context.Context, commentVO vo.Comme
 		ReplyUserID:    replyUserID,
 		TopicID:        commentVO.TopicID,
 		CommentContent: commentContent,
+		CommentSource:  commentSource,
 		ParentID:       parentID,
 		Type:           commentVO.Type,
 		IsReview:       constants.False,
 		CreateTime:     time.Now(),
 		UpdateTime:     time.Now(),
+		SpamScore:      spamScore,
 	}
 
-	if isReview == constants.False {
+	if isReview == constants.False && !needReview && !spamReview {
 		comment.IsReview = constants.True
 	}
 
@@ -205,6 +554,7 @@ func (s *commentServiceImpl) SaveComment(ctx context.Context, commentVO vo.Comme
 	}
 
 	// 在插入后打印
+	comment.ID = insertedID
 	log.Printf("After insert - Comment: %+v", comment)
 
 	// 获取插入后的评论数据
@@ -215,35 +565,116 @@ func (s *commentServiceImpl) SaveComment(ctx context.Context, commentVO vo.Comme
 	}
 	log.Printf("获取插入后的评论数据是: %+v\n", savedComment)
 
-	// 判断是否开启邮箱通知, 通知用户
-	if websiteConfig.IsEmailNotice == constants.True {
-		log.Println("发送邮件通知")
-		err = s.sendNotification(ctx, comment)
-		if err != nil {
-			log.Printf("发送通知失败，但评论已保存: %v", err)
-			// 此处记录通知发送失败的日志，但不返回错误，确保评论保存的事务独立完成。
-		}
+	// 审核通过的评论通知被回复的用户，待审核的评论在开启邮箱通知时提醒管理员审核
+	if comment.IsReview == constants.True {
+		err = s.noticeReply(ctx, comment)
+	} else if websiteConfig.IsEmailNotice == constants.True {
+		err = s.noticeReview(ctx)
+	}
+	if err != nil {
+		log.Printf("发送通知失败，但评论已保存: %v", err)
+		// 此处记录通知发送失败的日志，但不返回错误，确保评论保存的事务独立完成。
 	}
 
 	return savedComment, nil
 }
 
-func (s *commentServiceImpl) sendNotification(ctx context.Context, comment model.Comment) error {
-	eg, _ := errgroup.WithContext(ctx)
-	eg.Go(func() error {
-		return s.Notice(ctx, comment)
+// UpdateComment 作者在可编辑时间内修改评论，内容重新过滤并检查，网站开启评论审核或内容需要审核时重新进入审核
+func (s *commentServiceImpl) UpdateComment(ctx context.Context, commentId int, commentEditVO vo.CommentEditVO) (dto.CommentDTO, error) {
+	comment, err := s.getOwnComment(ctx, commentId)
+	if err != nil {
+		return dto.CommentDTO{}, err
+	}
+	editWindow := time.Duration(s.commentConfig.EditWindow) * time.Second
+	if editWindow <= 0 || time.Since(comment.CreateTime) > editWindow {
+		return dto.CommentDTO{}, exception.NewBizError(enums.VALID_ERROR.Code, "评论已超过可编辑时间")
+	}
+
+	websiteConfig, err := s.blogInfoService.GetWebsiteConfig(ctx)
+	if err != nil {
+		return dto.CommentDTO{}, fmt.Errorf("failed to get website config: %w", err)
+	}
+
+	// 与发表评论相同，检查敏感词后渲染 markdown，再检查垃圾内容
+	commentSource, needReview, err := checkSensitiveContent(s.sensitiveWordService, commentEditVO.CommentContent)
+	if err != nil {
+		return dto.CommentDTO{}, err
+	}
+	commentContent, err := renderCommentContent(commentSource)
+	if err != nil {
+		return dto.CommentDTO{}, err
+	}
+	if commentContent == comment.CommentContent {
+		return s.commentDao.GetCommentByID(ctx, comment.ID)
+	}
+	spamScore, spamReview, err := checkSpamContent(ctx, s.spamService, dto.SpamCheckDTO{
+		Content:   commentSource,
+		UserID:    comment.UserID,
+		IPAddress: utils.GetIPAddressFromContext(ctx),
 	})
-	if err := eg.Wait(); err != nil {
-		return fmt.Errorf("failed to send notification: %w", err)
+	if err != nil {
+		return dto.CommentDTO{}, err
+	}
+
+	editTime := time.Now()
+	previousReview := comment.IsReview
+	comment.CommentContent = commentContent
+	comment.CommentSource = commentSource
+	comment.SpamScore = spamScore
+	comment.EditTime = &editTime
+	if websiteConfig.IsCommentReview == constants.True || needReview || spamReview {
+		comment.IsReview = constants.False
+	}
+	// 保存修改前的内容和更新评论在同一事务中完成
+	if err := s.commentDao.UpdateContentWithRevision(ctx, *comment); err != nil {
+		return dto.CommentDTO{}, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	// 已通过审核的评论重新进入审核时提醒管理员
+	if previousReview == constants.True && comment.IsReview == constants.False && websiteConfig.IsEmailNotice == constants.True {
+		if err := s.noticeReview(ctx); err != nil {
+			log.Printf("发送审核提醒失败，但评论已修改: %v", err)
+		}
+	}
+
+	return s.commentDao.GetCommentByID(ctx, comment.ID)
+}
+
+// RemoveOwnComment 作者逻辑删除评论，删除后不再在前台展示，后台仍可查看
+func (s *commentServiceImpl) RemoveOwnComment(ctx context.Context, commentId int) error {
+	comment, err := s.getOwnComment(ctx, commentId)
+	if err != nil {
+		return err
+	}
+	if err := s.commentDao.UpdateDeleteById(ctx, comment.ID, constants.True); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
 	}
 	return nil
 }
 
-// Notice 通知评论用户
-func (s *commentServiceImpl) Notice(ctx context.Context, comment model.Comment) error {
+// getOwnComment 查询当前用户发表的未删除评论
+func (s *commentServiceImpl) getOwnComment(ctx context.Context, commentId int) (*model.Comment, error) {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user not logged in")
+	}
+	comment, err := s.commentDao.GetById(ctx, commentId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment by ID: %w", err)
+	}
+	if comment == nil || comment.IsDelete == constants.True {
+		return nil, exception.NewBizError(enums.VALID_ERROR.Code, "评论不存在")
+	}
+	if comment.UserID != user.UserInfoID {
+		return nil, exception.NewBizError(enums.VALID_ERROR.Code, "只能操作自己的评论")
+	}
+	return comment, nil
+}
+
+// noticeReply 通知被回复的用户，没有回复对象时通知文章或说说的作者
+func (s *commentServiceImpl) noticeReply(ctx context.Context, comment model.Comment) error {
 	// 初始化 userID 为博主ID作为默认值
 	userID := constants.BloggerID
-	log.Println("Initial userID set to BloggerID:", userID)
 
 	// 如果有回复用户ID，优先使用
 	if comment.ReplyUserID != nil && *comment.ReplyUserID != 0 {
@@ -273,6 +704,10 @@ func (s *commentServiceImpl) Notice(ctx context.Context, comment model.Comment)
 			userID = talk.UserID
 			log.Println("Talk found, userID set to:", userID)
 
+		case enums.LINK.Type:
+			// 友链评论通知博主
+			userID = constants.BloggerID
+
 		default:
 			return fmt.Errorf("unsupported comment type: %d", comment.Type)
 		}
@@ -280,57 +715,71 @@ func (s *commentServiceImpl) Notice(ctx context.Context, comment model.Comment)
 
 	// 检查 userID 是否有效
 	if userID == 0 {
-		log.Println("Error: userID is 0, aborting Notice method")
+		log.Println("Error: userID is 0, aborting noticeReply method")
 		return fmt.Errorf("invalid userID: %d", userID)
 	}
 
-	// 获取用户信息
-	user, err := s.userInfoDao.GetUserInfoById(ctx, userID)
+	return s.notificationService.Notify(ctx, dto.NotificationEventDTO{
+		UserID:     userID,
+		FromUserID: comment.UserID,
+		Type:       enums.COMMENT_REPLY.Type,
+		TargetID:   comment.ID,
+		Content:    comment.CommentContent,
+		Path:       enums.GetCommentPath(comment.Type, comment.TopicID),
+	})
+}
+
+// noticeReview 发送邮件提醒管理员审核评论
+func (s *commentServiceImpl) noticeReview(ctx context.Context) error {
+	adminUser, err := s.userInfoDao.GetUserInfoById(ctx, constants.BloggerID)
 	if err != nil {
-		return fmt.Errorf("failed to get user info by ID: %w", err)
+		return fmt.Errorf("failed to get admin user info: %w", err)
+	}
+	if adminUser.Email == "" {
+		log.Println("No email found for admin user")
+		return fmt.Errorf("email is empty for userID: %d", constants.BloggerID)
 	}
 
-	email := user.Email
-	log.Println("User email found:", email)
+	emailDTO := dto.EmailDTO{
+		Email:    adminUser.Email,
+		Template: enums.REVIEW_EMAIL.Name,
+	}
+	log.Println("Preparing to send review notification email to:", adminUser.Email)
 
-	if email != "" {
-		// 发送消息
-		emailDTO := dto.EmailDTO{}
-		if comment.IsReview == constants.True {
-			// 评论提醒
-			emailDTO.Email = email
-			emailDTO.Subject = "评论提醒"
-			url := s.websiteUrl + enums.GetCommentPath(comment.Type) + strconv.Itoa(comment.TopicID)
-			emailDTO.Content = fmt.Sprintf("您收到了一条新的回复，请前往 %s 页面查看", url)
-			log.Println("Preparing to send comment notification email to:", email)
-		} else {
-			// 管理员审核提醒
-			adminUser, err := s.userInfoDao.GetUserInfoById(ctx, constants.BloggerID)
-			if err != nil {
-				return fmt.Errorf("failed to get admin user info: %w", err)
-			}
-			emailDTO.Email = adminUser.Email
-			emailDTO.Subject = "审核提醒"
-			emailDTO.Content = "您收到了一条新的回复，请前往后台管理页面审核"
-			log.Println("Preparing to send review notification email to:", adminUser.Email)
-		}
+	messageBytes, err := json.Marshal(emailDTO)
+	if err != nil {
+		return fmt.Errorf("failed to marshal emailDTO: %w", err)
+	}
+	if err := s.rabbitMQClient.Publish(constants.EmailExchange, "", messageBytes); err != nil {
+		return fmt.Errorf("failed to send message to RabbitMQ: %w", err)
+	}
+	log.Println("Email notification sent successfully")
+	return nil
+}
 
-		messageBytes, err := json.Marshal(emailDTO)
-		if err != nil {
-			return fmt.Errorf("failed to marshal emailDTO: %w", err)
-		}
+// renderCommentContent 将评论原文渲染为 HTML，渲染后没有内容时返回业务错误
+func renderCommentContent(source string) (string, error) {
+	content := utils.CommentMarkdownToHTML(source)
+	if utils.IsCommentHTMLEmpty(content) {
+		return "", exception.NewBizError(enums.VALID_ERROR.Code, "内容不能为空")
+	}
+	return content, nil
+}
 
-		err = s.rabbitMQClient.Publish("EMAIL_EXCHANGE", "*", messageBytes)
-		if err != nil {
-			return fmt.Errorf("failed to send message to RabbitMQ: %w", err)
-		}
-		log.Println("Email notification sent successfully")
-	} else {
-		// 如果邮箱为空，记录日志或返回错误
-		log.Println("No email found for userID:", userID)
-		return fmt.Errorf("email is empty for userID: %d", userID)
+// commentTopicId 不关联主题的评论忽略请求中的主题id
+func commentTopicId(commentType int, topicId int) int {
+	if commentEnum := enums.GetCommentEnum(commentType); commentEnum != nil && !commentEnum.HasTopic {
+		return 0
 	}
+	return topicId
+}
 
+// checkCommentTopic 校验查询评论的主题，关联主题的评论类型必须指定主题id，避免查出所有主题的评论
+func checkCommentTopic(commentVO *vo.CommentVO) error {
+	commentVO.TopicID = commentTopicId(commentVO.Type, commentVO.TopicID)
+	if commentEnum := enums.GetCommentEnum(commentVO.Type); commentEnum != nil && commentEnum.HasTopic && commentVO.TopicID == 0 {
+		return exception.NewBizError(enums.VALID_ERROR.Code, "评论主题不能为空")
+	}
 	return nil
 }
 
@@ -427,6 +876,10 @@ func (s *commentServiceImpl) SaveCommentLike(ctx context.Context, commentId int)
 			return 0, fmt.Errorf("failed to increment comment like count: %w", err)
 		}
 		log.Printf("Incremented like count for comment %d by user %d, new count: %d", commentId, userId, incr)
+
+		if err := s.noticeCommentLike(ctx, commentId, userId); err != nil {
+			log.Printf("Error sending like notification for comment %d: %v", commentId, err)
+		}
 	}
 
 	// 获取更新后的点赞数量
@@ -447,7 +900,33 @@ func (s *commentServiceImpl) SaveCommentLike(ctx context.Context, commentId int)
 	return likeCount, nil
 }
 
+// noticeCommentLike 通知评论用户评论被点赞
+func (s *commentServiceImpl) noticeCommentLike(ctx context.Context, commentId int, userId int) error {
+	comments, err := s.commentDao.ListCommentsByIds(ctx, []int{commentId})
+	if err != nil {
+		return fmt.Errorf("failed to get comment by ID: %w", err)
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+	comment := comments[0]
+	return s.notificationService.Notify(ctx, dto.NotificationEventDTO{
+		UserID:     comment.UserID,
+		FromUserID: userId,
+		Type:       enums.COMMENT_LIKE.Type,
+		TargetID:   comment.ID,
+		Content:    comment.CommentContent,
+		Path:       enums.GetCommentPath(comment.Type, comment.TopicID),
+	})
+}
+
 func (s *commentServiceImpl) UpdateCommentsReview(ctx context.Context, reviewVO vo.ReviewVO) error {
+	// 更新前查询评论，用于训练分类器和通知审核状态发生变化的评论
+	reviewedComments, err := s.commentDao.ListCommentsByIds(ctx, reviewVO.IDList)
+	if err != nil {
+		log.Printf("Error listing comments for review: %v", err)
+	}
+
 	comments := make([]model.Comment, len(reviewVO.IDList))
 	for i, id := range reviewVO.IDList {
 		comments[i] = model.Comment{
@@ -455,7 +934,46 @@ func (s *commentServiceImpl) UpdateCommentsReview(ctx context.Context, reviewVO
 			IsReview: reviewVO.IsReview,
 		}
 	}
-	return s.commentDao.UpdateBatchByID(ctx, comments)
+	if err := s.commentDao.UpdateBatchByID(ctx, comments); err != nil {
+		return err
+	}
+
+	// 审核通过的评论作为正常样本训练分类器
+	if reviewVO.IsReview == constants.True {
+		s.trainCommentSamples(ctx, reviewedComments, false)
+	}
+	for _, comment := range reviewedComments {
+		if comment.IsReview == reviewVO.IsReview {
+			continue
+		}
+		comment.IsReview = reviewVO.IsReview
+		s.noticeReviewResult(ctx, comment)
+	}
+	return nil
+}
+
+// noticeReviewResult 通知评论用户审核结果，审核通过时再通知被回复的用户，通知失败只记录日志
+func (s *commentServiceImpl) noticeReviewResult(ctx context.Context, comment model.Comment) {
+	result := "您的评论未通过审核："
+	if comment.IsReview == constants.True {
+		result = "您的评论已通过审核："
+	}
+	err := s.notificationService.Notify(ctx, dto.NotificationEventDTO{
+		UserID:   comment.UserID,
+		Type:     enums.REVIEW_RESULT.Type,
+		TargetID: comment.ID,
+		Content:  result + comment.CommentContent,
+		Path:     enums.GetCommentPath(comment.Type, comment.TopicID),
+	})
+	if err != nil {
+		log.Printf("Error sending review notification for comment %d: %v", comment.ID, err)
+	}
+
+	if comment.IsReview == constants.True {
+		if err := s.noticeReply(ctx, comment); err != nil {
+			log.Printf("Error sending reply notification for comment %d: %v", comment.ID, err)
+		}
+	}
 }
 
 func (s *commentServiceImpl) ListCommentBackDTO(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.CommentBackDTO], error) {
@@ -480,14 +998,79 @@ func (s *commentServiceImpl) ListCommentBackDTO(ctx context.Context, condition v
 		log.Printf("Error listing comments: %v", err)
 		return vo.PageResult[dto.CommentBackDTO]{}, fmt.Errorf("failed to list comments: %w", err)
 	}
+	if err := s.fillCommentRevisions(ctx, comments); err != nil {
+		return vo.PageResult[dto.CommentBackDTO]{}, err
+	}
 
 	return vo.NewPageResult(comments, count), nil
 }
 
+// fillCommentRevisions 查询后台评论的编辑历史
+func (s *commentServiceImpl) fillCommentRevisions(ctx context.Context, comments []dto.CommentBackDTO) error {
+	var commentIdList []int
+	for _, comment := range comments {
+		if comment.EditTime != nil {
+			commentIdList = append(commentIdList, comment.ID)
+		}
+	}
+	revisions, err := s.commentRevisionDao.ListByCommentIds(ctx, commentIdList)
+	if err != nil {
+		log.Printf("Error listing comment revisions: %v", err)
+		return fmt.Errorf("failed to list comment revisions: %w", err)
+	}
+
+	revisionMap := make(map[int][]dto.CommentRevisionDTO)
+	for _, revision := range revisions {
+		revisionMap[revision.CommentID] = append(revisionMap[revision.CommentID], dto.CommentRevisionDTO{
+			ID:             revision.ID,
+			CommentContent: revision.CommentContent,
+			CreateTime:     revision.CreateTime,
+		})
+	}
+	for i := range comments {
+		comments[i].RevisionList = revisionMap[comments[i].ID]
+		if comments[i].RevisionList == nil {
+			comments[i].RevisionList = []dto.CommentRevisionDTO{}
+		}
+	}
+	return nil
+}
+
 func (s *commentServiceImpl) RemoveComments(ctx context.Context, commentIdList []int) error {
+	// 删除前查询评论内容，用于训练分类器
+	comments, err := s.commentDao.ListCommentsByIds(ctx, commentIdList)
+	if err != nil {
+		log.Printf("Error listing comments for spam training: %v", err)
+	}
 	if err := s.commentDao.RemoveByIds(ctx, commentIdList); err != nil {
 		log.Printf("Error removing comments: %v", err)
 		return err
 	}
+	if err := s.commentRevisionDao.DeleteByCommentIds(ctx, commentIdList); err != nil {
+		log.Printf("Error removing comment revisions: %v", err)
+	}
+
+	// 待审核时被删除的评论作为垃圾样本，已审核通过的评论被删除不代表是垃圾内容
+	var pendingComments []model.Comment
+	for _, comment := range comments {
+		if comment.IsReview == constants.False {
+			pendingComments = append(pendingComments, comment)
+		}
+	}
+	s.trainCommentSamples(ctx, pendingComments, true)
 	return nil
 }
+
+// trainCommentSamples 使用评论训练分类器，训练失败只记录日志
+func (s *commentServiceImpl) trainCommentSamples(ctx context.Context, comments []model.Comment, isSpam bool) {
+	for _, comment := range comments {
+		// 使用与评分时相同的原文训练，旧数据没有原文时使用评论内容
+		content := comment.CommentSource
+		if content == "" {
+			content = comment.CommentContent
+		}
+		if err := s.spamService.TrainSample(ctx, spamSampleKey("comment", comment.ID), content, isSpam); err != nil {
+			log.Printf("Error training spam classifier with comment %d: %v", comment.ID, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package Impl
+
+import (
+	"goBolg/dto"
+	"goBolg/utils"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestCommentThread 两棵评论树，1 下为旧版本挂在顶层评论下的回复和新版本的嵌套回复
+func newTestCommentThread() *commentThread {
+	base := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+	link := func(id, parentId, rootId, userId, replyUserId, minute int) dto.CommentTreeLinkDTO {
+		return dto.CommentTreeLinkDTO{
+			ID:          id,
+			ParentID:    parentId,
+			RootID:      rootId,
+			UserID:      userId,
+			ReplyUserID: replyUserId,
+			CreateTime:  base.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+	// 顺序打乱，组装时按创建时间排序
+	return newCommentThread([]dto.CommentTreeLinkDTO{
+		link(5, 1, 1, 20, 30, 5),
+		link(1, 0, 1, 10, 0, 1),
+		link(3, 1, 1, 10, 20, 3),
+		link(7, 1, 1, 50, 99, 7),
+		link(2, 1, 1, 20, 10, 2),
+		link(6, 2, 1, 40, 20, 6),
+		link(4, 1, 1, 30, 10, 4),
+		link(100, 0, 100, 20, 0, 1),
+		link(101, 100, 100, 10, 20, 2),
+		link(102, 100, 100, 30, 10, 3),
+	})
+}
+
+func TestNewCommentThread(t *testing.T) {
+	thread := newTestCommentThread()
+	tests := []struct {
+		name           string
+		commentId      int
+		wantChildren   []int
+		wantDepth      int
+		wantReplyCount int
+	}{
+		{"回复顶层评论作者的留在顶层评论下", 1, []int{2, 4, 7}, 0, 6},
+		{"旧数据按被回复用户挂到此前最近的评论下", 2, []int{3, 6}, 1, 2},
+		{"旧数据的回复可以继续被回复", 4, []int{5}, 1, 1},
+		{"被回复用户的评论层级", 5, nil, 2, 0},
+		{"新版本的回复按父评论组装", 6, nil, 2, 0},
+		{"找不到被回复用户时留在顶层评论下", 7, nil, 1, 0},
+		{"只在同一棵树中查找被回复用户", 101, []int{102}, 1, 1},
+		{"其他树的顶层评论", 100, []int{101}, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := thread.children[tt.commentId]; !reflect.DeepEqual(got, tt.wantChildren) {
+				t.Errorf("children of %d = %v, want %v", tt.commentId, got, tt.wantChildren)
+			}
+			if got := thread.depth[tt.commentId]; got != tt.wantDepth {
+				t.Errorf("depth of %d = %d, want %d", tt.commentId, got, tt.wantDepth)
+			}
+			if got := thread.replyCount[tt.commentId]; got != tt.wantReplyCount {
+				t.Errorf("replyCount of %d = %d, want %d", tt.commentId, got, tt.wantReplyCount)
+			}
+		})
+	}
+}
+
+func TestCommentThreadPageChildren(t *testing.T) {
+	thread := newTestCommentThread()
+
+	first := thread.pageChildren(1, nil, 2)
+	if !reflect.DeepEqual(first.idList, []int{2, 4}) || !first.hasMore || first.nextCursor == "" {
+		t.Fatalf("first page = %+v, want [2 4] with next cursor", first)
+	}
+
+	cursor, err := utils.DecodeCursor(first.nextCursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor returned error: %v", err)
+	}
+	second := thread.pageChildren(1, cursor, 2)
+	if !reflect.DeepEqual(second.idList, []int{7}) || second.hasMore || second.nextCursor != "" {
+		t.Errorf("second page = %+v, want [7] without next cursor", second)
+	}
+
+	if empty := thread.pageChildren(7, nil, 2); len(empty.idList) != 0 || empty.hasMore {
+		t.Errorf("page of comment without replies = %+v, want empty", empty)
+	}
+}
@@ -0,0 +1,148 @@
+package Impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/streadway/amqp"
+	constants "goBolg/constant"
+	"goBolg/dto"
+	"goBolg/rabbitmq"
+	"goBolg/service"
+	"goBolg/utils"
+	"goBolg/vo"
+)
+
+// emailQueueServiceImpl 实现 EmailQueueService 接口
+type emailQueueServiceImpl struct {
+	connection *amqp.Connection
+}
+
+// NewEmailQueueService 创建新的 EmailQueueService 实例
+func NewEmailQueueService(connection *amqp.Connection) service.EmailQueueService {
+	return &emailQueueServiceImpl{connection: connection}
+}
+
+// ListDeadLetters 逐条取出死信但不确认，关闭通道后消息回到死信队列
+func (s *emailQueueServiceImpl) ListDeadLetters(ctx context.Context) (vo.PageResult[dto.EmailDeadLetterDTO], error) {
+	channel, err := s.openChannel()
+	if err != nil {
+		return vo.PageResult[dto.EmailDeadLetterDTO]{}, err
+	}
+	defer channel.Close()
+
+	queue, err := channel.QueueInspect(constants.EmailDeadQueue)
+	if err != nil {
+		return vo.PageResult[dto.EmailDeadLetterDTO]{}, fmt.Errorf("failed to inspect queue %s: %w", constants.EmailDeadQueue, err)
+	}
+
+	offset, size := utils.GetLimitCurrent(ctx), utils.GetSize(ctx)
+	deadLetters := make([]dto.EmailDeadLetterDTO, 0, size)
+	for i := 0; i < offset+size && i < queue.Messages; i++ {
+		delivery, ok, err := channel.Get(constants.EmailDeadQueue, false)
+		if err != nil {
+			return vo.PageResult[dto.EmailDeadLetterDTO]{}, fmt.Errorf("failed to get dead letter: %w", err)
+		}
+		if !ok {
+			break
+		}
+		if i >= offset {
+			deadLetters = append(deadLetters, toEmailDeadLetterDTO(delivery))
+		}
+	}
+	return vo.NewPageResult(deadLetters, queue.Messages), nil
+}
+
+// RetryDeadLetters 清除重试次数后将死信重新投递到邮件交换机，只处理开始时已在队列中的消息，避免再次失败的邮件被反复投递
+func (s *emailQueueServiceImpl) RetryDeadLetters(ctx context.Context) (int, error) {
+	channel, err := s.openChannel()
+	if err != nil {
+		return 0, err
+	}
+	defer channel.Close()
+
+	queue, err := channel.QueueInspect(constants.EmailDeadQueue)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect queue %s: %w", constants.EmailDeadQueue, err)
+	}
+
+	retried := 0
+	for retried < queue.Messages {
+		delivery, ok, err := channel.Get(constants.EmailDeadQueue, false)
+		if err != nil {
+			return retried, fmt.Errorf("failed to get dead letter: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		headers := amqp.Table{}
+		for key, value := range delivery.Headers {
+			headers[key] = value
+		}
+		delete(headers, constants.EmailRetryCountHeader)
+		delete(headers, constants.EmailErrorHeader)
+		err = channel.Publish(constants.EmailExchange, "", false, false, amqp.Publishing{
+			Headers:      headers,
+			ContentType:  delivery.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         delivery.Body,
+		})
+		if err != nil {
+			return retried, fmt.Errorf("failed to republish dead letter: %w", err)
+		}
+		if err := delivery.Ack(false); err != nil {
+			return retried, fmt.Errorf("failed to ack dead letter: %w", err)
+		}
+		retried++
+	}
+	return retried, nil
+}
+
+// PurgeDeadLetters 清空死信队列
+func (s *emailQueueServiceImpl) PurgeDeadLetters(ctx context.Context) (int, error) {
+	channel, err := s.openChannel()
+	if err != nil {
+		return 0, err
+	}
+	defer channel.Close()
+
+	count, err := channel.QueuePurge(constants.EmailDeadQueue, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge queue %s: %w", constants.EmailDeadQueue, err)
+	}
+	return count, nil
+}
+
+// openChannel 打开通道并确保死信队列已声明，避免操作不存在的队列导致通道关闭
+func (s *emailQueueServiceImpl) openChannel() (*amqp.Channel, error) {
+	channel, err := s.connection.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	if err := rabbitmq.DeclareEmailQueue(channel); err != nil {
+		channel.Close()
+		return nil, err
+	}
+	return channel, nil
+}
+
+// toEmailDeadLetterDTO 解析死信，无法解析时返回原始消息
+func toEmailDeadLetterDTO(delivery amqp.Delivery) dto.EmailDeadLetterDTO {
+	deadLetter := dto.EmailDeadLetterDTO{
+		RetryCount: rabbitmq.EmailRetryCount(delivery.Headers),
+		Error:      rabbitmq.EmailError(delivery.Headers),
+		DeadTime:   delivery.Timestamp,
+	}
+	var emailDTO dto.EmailDTO
+	if err := json.Unmarshal(delivery.Body, &emailDTO); err != nil {
+		deadLetter.Body = string(delivery.Body)
+		return deadLetter
+	}
+	deadLetter.Email = emailDTO.Email
+	deadLetter.Subject = emailDTO.Subject
+	deadLetter.Content = emailDTO.Content
+	deadLetter.Template = emailDTO.Template
+	deadLetter.Data = emailDTO.Data
+	return deadLetter
+}
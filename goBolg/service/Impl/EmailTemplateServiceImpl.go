@@ -0,0 +1,235 @@
+package Impl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/exception"
+	"goBolg/model"
+	"goBolg/service"
+	"goBolg/templates"
+	"goBolg/vo"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+)
+
+// emailLayoutFile 内置的邮件布局，HTML 正文模板可以通过 {{template "header" .}} 和 {{template "footer" .}} 使用
+const emailLayoutFile = "email/layout.html"
+
+// emailTemplateServiceImpl 实现 EmailTemplateService 接口
+type emailTemplateServiceImpl struct {
+	emailTemplateDao dao.EmailTemplateDao
+	blogInfoService  service.BlogInfoService
+	websiteUrl       string
+}
+
+// NewEmailTemplateService 创建新的 EmailTemplateService 实例，websiteUrl 在网站配置未设置地址时使用
+func NewEmailTemplateService(emailTemplateDao dao.EmailTemplateDao, blogInfoService service.BlogInfoService, websiteUrl string) service.EmailTemplateService {
+	return &emailTemplateServiceImpl{
+		emailTemplateDao: emailTemplateDao,
+		blogInfoService:  blogInfoService,
+		websiteUrl:       websiteUrl,
+	}
+}
+
+// RenderEmail 使用自定义模板或内置模板渲染邮件，邮件指定了主题时覆盖模板的主题
+func (s *emailTemplateServiceImpl) RenderEmail(ctx context.Context, emailDTO dto.EmailDTO) (dto.EmailContentDTO, error) {
+	if emailDTO.Template == "" {
+		return dto.EmailContentDTO{Subject: emailDTO.Subject, Text: emailDTO.Content}, nil
+	}
+
+	templateEnum := enums.GetEmailTemplateEnum(emailDTO.Template)
+	if templateEnum == nil {
+		return dto.EmailContentDTO{}, fmt.Errorf("unknown email template: %s", emailDTO.Template)
+	}
+	emailTemplate, err := s.getEmailTemplate(ctx, *templateEnum)
+	if err != nil {
+		return dto.EmailContentDTO{}, err
+	}
+	site, err := s.getEmailSite(ctx)
+	if err != nil {
+		return dto.EmailContentDTO{}, err
+	}
+
+	content, err := renderEmailTemplate(emailTemplate, dto.EmailTemplateDataDTO{Site: site, Data: emailDTO.Data})
+	if err != nil {
+		return dto.EmailContentDTO{}, fmt.Errorf("failed to render email template %s: %w", emailDTO.Template, err)
+	}
+	if emailDTO.Subject != "" {
+		content.Subject = emailDTO.Subject
+	}
+	return content, nil
+}
+
+// ListEmailTemplates 查询全部邮件模板
+func (s *emailTemplateServiceImpl) ListEmailTemplates(ctx context.Context) ([]dto.EmailTemplateDTO, error) {
+	customTemplates, err := s.emailTemplateDao.ListEmailTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	customTemplateMap := make(map[string]model.EmailTemplate)
+	for _, customTemplate := range customTemplates {
+		customTemplateMap[customTemplate.Name] = customTemplate
+	}
+
+	emailTemplateDTOList := make([]dto.EmailTemplateDTO, 0, len(enums.GetEmailTemplateEnums()))
+	for _, templateEnum := range enums.GetEmailTemplateEnums() {
+		emailTemplate, err := defaultEmailTemplate(templateEnum)
+		if err != nil {
+			return nil, err
+		}
+		if customTemplate, ok := customTemplateMap[templateEnum.Name]; ok {
+			applyCustomEmailTemplate(&emailTemplate, customTemplate)
+		}
+		emailTemplateDTOList = append(emailTemplateDTOList, emailTemplate)
+	}
+	return emailTemplateDTOList, nil
+}
+
+// SaveEmailTemplate 校验模板能够正常渲染后保存
+func (s *emailTemplateServiceImpl) SaveEmailTemplate(ctx context.Context, emailTemplateVO vo.EmailTemplateVO) error {
+	templateEnum := enums.GetEmailTemplateEnum(emailTemplateVO.Name)
+	if templateEnum == nil {
+		return exception.NewBizError(enums.VALID_ERROR.Code, "邮件模板不存在")
+	}
+
+	// 使用空的变量试渲染，提前发现语法错误和不存在的字段
+	emailTemplate := dto.EmailTemplateDTO{
+		Name:        emailTemplateVO.Name,
+		Subject:     emailTemplateVO.Subject,
+		HTMLContent: emailTemplateVO.HTMLContent,
+		TextContent: emailTemplateVO.TextContent,
+	}
+	sampleData := make(map[string]interface{})
+	for _, variable := range templateEnum.Variables {
+		sampleData[variable] = ""
+	}
+	if _, err := renderEmailTemplate(emailTemplate, dto.EmailTemplateDataDTO{Data: sampleData}); err != nil {
+		return exception.NewBizError(enums.VALID_ERROR.Code, "邮件模板格式错误："+err.Error())
+	}
+
+	return s.emailTemplateDao.SaveOrUpdateEmailTemplate(ctx, &model.EmailTemplate{
+		Name:        emailTemplateVO.Name,
+		Subject:     emailTemplateVO.Subject,
+		HTMLContent: emailTemplateVO.HTMLContent,
+		TextContent: emailTemplateVO.TextContent,
+	})
+}
+
+// DeleteEmailTemplate 删除自定义模板
+func (s *emailTemplateServiceImpl) DeleteEmailTemplate(ctx context.Context, name string) error {
+	if enums.GetEmailTemplateEnum(name) == nil {
+		return exception.NewBizError(enums.VALID_ERROR.Code, "邮件模板不存在")
+	}
+	return s.emailTemplateDao.DeleteEmailTemplateByName(ctx, name)
+}
+
+// getEmailTemplate 获取模板内容，存在自定义模板时使用自定义模板
+func (s *emailTemplateServiceImpl) getEmailTemplate(ctx context.Context, templateEnum enums.EmailTemplateEnum) (dto.EmailTemplateDTO, error) {
+	emailTemplate, err := defaultEmailTemplate(templateEnum)
+	if err != nil {
+		return dto.EmailTemplateDTO{}, err
+	}
+	customTemplate, err := s.emailTemplateDao.GetEmailTemplateByName(ctx, templateEnum.Name)
+	if err != nil {
+		return dto.EmailTemplateDTO{}, err
+	}
+	if customTemplate != nil {
+		applyCustomEmailTemplate(&emailTemplate, *customTemplate)
+	}
+	return emailTemplate, nil
+}
+
+// getEmailSite 从网站配置中获取站点信息
+func (s *emailTemplateServiceImpl) getEmailSite(ctx context.Context) (dto.EmailSiteDTO, error) {
+	websiteConfig, err := s.blogInfoService.GetWebsiteConfig(ctx)
+	if err != nil {
+		return dto.EmailSiteDTO{}, fmt.Errorf("failed to get website config: %w", err)
+	}
+	site := dto.EmailSiteDTO{
+		Name:   websiteConfig.WebsiteName,
+		Avatar: websiteConfig.WebsiteAvatar,
+		URL:    websiteConfig.WebsiteUrl,
+	}
+	if site.URL == "" {
+		site.URL = s.websiteUrl
+	}
+	return site, nil
+}
+
+// defaultEmailTemplate 读取内置模板
+func defaultEmailTemplate(templateEnum enums.EmailTemplateEnum) (dto.EmailTemplateDTO, error) {
+	htmlContent, err := fs.ReadFile(templates.EmailFS, "email/"+templateEnum.Name+".html")
+	if err != nil {
+		return dto.EmailTemplateDTO{}, err
+	}
+	textContent, err := fs.ReadFile(templates.EmailFS, "email/"+templateEnum.Name+".txt")
+	if err != nil {
+		return dto.EmailTemplateDTO{}, err
+	}
+	return dto.EmailTemplateDTO{
+		Name:        templateEnum.Name,
+		Desc:        templateEnum.Desc,
+		Variables:   templateEnum.Variables,
+		Subject:     templateEnum.Subject,
+		HTMLContent: string(htmlContent),
+		TextContent: string(textContent),
+	}, nil
+}
+
+// applyCustomEmailTemplate 使用自定义模板覆盖内置模板
+func applyCustomEmailTemplate(emailTemplate *dto.EmailTemplateDTO, customTemplate model.EmailTemplate) {
+	emailTemplate.Subject = customTemplate.Subject
+	emailTemplate.HTMLContent = customTemplate.HTMLContent
+	emailTemplate.TextContent = customTemplate.TextContent
+	emailTemplate.IsCustom = true
+}
+
+// renderEmailTemplate 渲染主题和两种格式的正文，HTML 正文中的变量会按上下文转义
+func renderEmailTemplate(emailTemplate dto.EmailTemplateDTO, data dto.EmailTemplateDataDTO) (dto.EmailContentDTO, error) {
+	subject, err := executeTextTemplate("subject", emailTemplate.Subject, data)
+	if err != nil {
+		return dto.EmailContentDTO{}, err
+	}
+	text, err := executeTextTemplate("text", emailTemplate.TextContent, data)
+	if err != nil {
+		return dto.EmailContentDTO{}, err
+	}
+
+	htmlTemplate, err := htmltemplate.ParseFS(templates.EmailFS, emailLayoutFile)
+	if err != nil {
+		return dto.EmailContentDTO{}, err
+	}
+	if htmlTemplate, err = htmlTemplate.New("html").Parse(emailTemplate.HTMLContent); err != nil {
+		return dto.EmailContentDTO{}, err
+	}
+	var html bytes.Buffer
+	if err := htmlTemplate.ExecuteTemplate(&html, "html", data); err != nil {
+		return dto.EmailContentDTO{}, err
+	}
+
+	// 主题中不能出现换行
+	return dto.EmailContentDTO{
+		Subject: strings.Join(strings.Fields(subject), " "),
+		HTML:    html.String(),
+		Text:    text,
+	}, nil
+}
+
+// executeTextTemplate 渲染纯文本模板
+func executeTextTemplate(name string, source string, data dto.EmailTemplateDataDTO) (string, error) {
+	textTemplate, err := texttemplate.New(name).Parse(source)
+	if err != nil {
+		return "", err
+	}
+	var text bytes.Buffer
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return "", err
+	}
+	return text.String(), nil
+}
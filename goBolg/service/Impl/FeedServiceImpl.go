@@ -0,0 +1,172 @@
+package Impl
+
+import (
+	"context"
+	"fmt"
+	"goBolg/config"
+	"goBolg/constant"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/service"
+	"goBolg/utils"
+	"goBolg/vo"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 订阅源默认配置
+const (
+	defaultFeedSize          = 20
+	defaultFeedExcerptLength = 200
+	defaultFeedCacheTTL      = 10 * time.Minute
+)
+
+// feedServiceImpl 实现 FeedService 接口
+type feedServiceImpl struct {
+	articleDao      dao.ArticleDao
+	categoryDao     dao.CategoryDao
+	tagDao          dao.TagDao
+	blogInfoService service.BlogInfoService
+	redisService    service.RedisService
+	websiteURL      string
+	feedConfig      config.FeedConfig
+}
+
+// NewFeedService 创建新的 FeedService 实例
+func NewFeedService(articleDao dao.ArticleDao, categoryDao dao.CategoryDao, tagDao dao.TagDao, blogInfoService service.BlogInfoService, redisService service.RedisService, websiteURL string, feedConfig config.FeedConfig) service.FeedService {
+	if feedConfig.Size <= 0 {
+		feedConfig.Size = defaultFeedSize
+	}
+	if feedConfig.ExcerptLength <= 0 {
+		feedConfig.ExcerptLength = defaultFeedExcerptLength
+	}
+	if feedConfig.Mode != constants.FeedModeFull {
+		feedConfig.Mode = constants.FeedModeExcerpt
+	}
+	return &feedServiceImpl{
+		articleDao:      articleDao,
+		categoryDao:     categoryDao,
+		tagDao:          tagDao,
+		blogInfoService: blogInfoService,
+		redisService:    redisService,
+		websiteURL:      strings.TrimRight(websiteURL, "/"),
+		feedConfig:      feedConfig,
+	}
+}
+
+// GetFeed 优先读取缓存的订阅源，缓存失效后重新生成
+func (s *feedServiceImpl) GetFeed(ctx context.Context, feedType enums.FeedTypeEnum, condition vo.ConditionVO, mode string) (*dto.FeedDTO, error) {
+	if mode != constants.FeedModeFull && mode != constants.FeedModeExcerpt {
+		mode = s.feedConfig.Mode
+	}
+
+	feedPath := s.feedPath(feedType, condition)
+	ttl := defaultFeedCacheTTL
+	if s.feedConfig.CacheTTL > 0 {
+		ttl = time.Duration(s.feedConfig.CacheTTL) * time.Second
+	}
+	content, err := getCachedContent(ctx, s.redisService, constants.FeedCache+mode+":"+feedPath, ttl, func() ([]byte, time.Time, bool, error) {
+		return s.buildFeed(ctx, feedType, condition, mode, feedPath)
+	})
+	if err != nil || content == nil {
+		return nil, err
+	}
+	return &dto.FeedDTO{CachedContentDTO: *content, ContentType: feedType.ContentType}, nil
+}
+
+// buildFeed 查询文章并生成订阅源，返回内容和最后修改时间，分类或标签不存在时返回 false
+func (s *feedServiceImpl) buildFeed(ctx context.Context, feedType enums.FeedTypeEnum, condition vo.ConditionVO, mode string, feedPath string) ([]byte, time.Time, bool, error) {
+	websiteConfig, err := s.blogInfoService.GetWebsiteConfig(ctx)
+	if err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("failed to get website config: %w", err)
+	}
+
+	channel := utils.FeedChannel{
+		Title:       websiteConfig.WebsiteName,
+		Link:        s.websiteURL,
+		FeedLink:    s.websiteURL + feedPath,
+		Description: websiteConfig.WebsiteIntro,
+		Author:      websiteConfig.WebsiteAuthor,
+		Icon:        websiteConfig.WebsiteAvatar,
+	}
+
+	// 分类和标签订阅源使用对应的名称和页面地址
+	if condition.CategoryID != nil {
+		categoryName, err := s.categoryDao.GetCategoryNameByID(ctx, *condition.CategoryID)
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+		if categoryName == "" {
+			return nil, time.Time{}, false, nil
+		}
+		channel.Title = categoryName + " - " + channel.Title
+		channel.Link = s.websiteURL + "/categories/" + strconv.Itoa(*condition.CategoryID)
+	} else if condition.TagID != nil {
+		tagName, err := s.tagDao.GetTagNameByID(ctx, *condition.TagID)
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+		if tagName == "" {
+			return nil, time.Time{}, false, nil
+		}
+		channel.Title = tagName + " - " + channel.Title
+		channel.Link = s.websiteURL + "/tags/" + strconv.Itoa(*condition.TagID)
+	}
+
+	articles, err := s.articleDao.ListFeedArticles(ctx, condition, s.feedConfig.Size)
+	if err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("failed to list feed articles: %w", err)
+	}
+
+	items := make([]utils.FeedItem, 0, len(articles))
+	for _, article := range articles {
+		item := utils.FeedItem{
+			ID:        article.ID,
+			Title:     article.ArticleTitle,
+			Link:      s.websiteURL + enums.ARTICLE.Path + strconv.Itoa(article.ID),
+			Summary:   utils.MarkdownToText(article.ArticleContent, s.feedConfig.ExcerptLength),
+			Image:     article.ArticleCover,
+			Category:  article.CategoryName,
+			Tags:      article.TagNameList,
+			Published: article.CreateTime,
+			Updated:   article.CreateTime,
+		}
+		if article.UpdateTime != nil && article.UpdateTime.After(item.Updated) {
+			item.Updated = *article.UpdateTime
+		}
+		if mode == constants.FeedModeFull {
+			item.Content = utils.MarkdownToHTML(article.ArticleContent)
+		}
+		if item.Updated.After(channel.Updated) {
+			channel.Updated = item.Updated
+		}
+		items = append(items, item)
+	}
+	if channel.Updated.IsZero() {
+		channel.Updated = time.Now()
+	}
+
+	var content []byte
+	switch feedType {
+	case enums.ATOM:
+		content, err = utils.BuildAtom(channel, items)
+	case enums.JSON_FEED:
+		content, err = utils.BuildJSONFeed(channel, items)
+	default:
+		content, err = utils.BuildRSS(channel, items)
+	}
+	return content, channel.Updated, true, err
+}
+
+// feedPath 订阅源的访问路径
+func (s *feedServiceImpl) feedPath(feedType enums.FeedTypeEnum, condition vo.ConditionVO) string {
+	if condition.CategoryID != nil {
+		return "/categories/" + strconv.Itoa(*condition.CategoryID) + "/" + feedType.FileName
+	}
+	if condition.TagID != nil {
+		return "/tags/" + strconv.Itoa(*condition.TagID) + "/" + feedType.FileName
+	}
+	return "/" + feedType.FileName
+}
@@ -2,28 +2,31 @@ package Impl
 
 import (
 	"context"
+	constants "goBolg/constant"
 	"goBolg/dao"
 	"goBolg/dto"
 	"goBolg/model"
 	"goBolg/service"
 	"goBolg/utils"
 	"goBolg/vo"
-	"net/http"
+	"log"
 )
 
 // messageServiceImpl implements MessageService
 type messageServiceImpl struct {
-	messageDao      dao.MessageDao
-	blogInfoService service.BlogInfoService
-	request         *http.Request
+	messageDao           dao.MessageDao
+	blogInfoService      service.BlogInfoService
+	sensitiveWordService service.SensitiveWordService
+	spamService          service.SpamService
 }
 
 // NewMessageService creates a new MessageService
-func NewMessageService(messageDao dao.MessageDao, blogInfoService service.BlogInfoService, request *http.Request) service.MessageService {
+func NewMessageService(messageDao dao.MessageDao, blogInfoService service.BlogInfoService, sensitiveWordService service.SensitiveWordService, spamService service.SpamService) service.MessageService {
 	return &messageServiceImpl{
-		messageDao:      messageDao,
-		blogInfoService: blogInfoService,
-		request:         request,
+		messageDao:           messageDao,
+		blogInfoService:      blogInfoService,
+		sensitiveWordService: sensitiveWordService,
+		spamService:          spamService,
 	}
 }
 
@@ -38,11 +41,37 @@ func (s *messageServiceImpl) SaveMessage(ctx context.Context, messageVO vo.Messa
 	var isReview int = websiteConfig.IsMessageReview
 
 	// 获取用户ip
-	ipAddress := utils.GetIPAddress(s.request)
+	ipAddress := utils.GetIPAddressFromContext(ctx)
 	ipSource := utils.GetIPSource(ipAddress)
 	message := model.Message{}
 	utils.BeanCopyObject(messageVO, &message)
-	message.MessageContent = utils.HTMLFilter(message.MessageContent)
+
+	// 敏感词检查，命中审核类敏感词时转为人工审核
+	messageSource, needReview, err := checkSensitiveContent(s.sensitiveWordService, messageVO.MessageContent)
+	if err != nil {
+		return err
+	}
+	// 与评论相同，渲染 markdown 并只保留允许的标签
+	messageContent, err := renderCommentContent(messageSource)
+	if err != nil {
+		return err
+	}
+
+	// 垃圾内容评分，评分较高时转为人工审核
+	spamCheck := dto.SpamCheckDTO{Content: messageSource, IPAddress: ipAddress}
+	if user, ok := utils.GetLoginUser(ctx); ok {
+		spamCheck.UserID = user.UserInfoID
+	}
+	spamScore, spamReview, err := checkSpamContent(ctx, s.spamService, spamCheck)
+	if err != nil {
+		return err
+	}
+	if needReview || spamReview {
+		isReview = constants.False
+	}
+	message.MessageContent = messageContent
+	message.MessageSource = messageSource
+	message.SpamScore = spamScore
 	message.IPAddress = ipAddress
 	message.IsReview = isReview
 	message.IPSource = ipSource
@@ -92,10 +121,58 @@ func (s *messageServiceImpl) UpdateMessagesReview(ctx context.Context, reviewVO
 			IsReview: reviewVO.IsReview,
 		})
 	}
-	return s.messageDao.UpdateBatchById(ctx, messageList)
+	if err := s.messageDao.UpdateBatchById(ctx, messageList); err != nil {
+		return err
+	}
+
+	// 审核通过的留言作为正常样本训练分类器
+	if reviewVO.IsReview == constants.True {
+		reviewedMessages, err := s.messageDao.ListMessagesByIds(ctx, reviewVO.IDList)
+		if err != nil {
+			log.Printf("Error listing messages for spam training: %v", err)
+			return nil
+		}
+		s.trainMessageSamples(ctx, reviewedMessages, false)
+	}
+	return nil
 }
 
 // DeleteMessages 删除留言
 func (s *messageServiceImpl) DeleteMessages(ctx context.Context, messageIdList []uint) error {
-	return s.messageDao.RemoveByIds(ctx, messageIdList)
+	// 删除前查询留言内容，用于训练分类器
+	idList := make([]int, len(messageIdList))
+	for i, id := range messageIdList {
+		idList[i] = int(id)
+	}
+	messages, err := s.messageDao.ListMessagesByIds(ctx, idList)
+	if err != nil {
+		log.Printf("Error listing messages for spam training: %v", err)
+	}
+	if err := s.messageDao.RemoveByIds(ctx, messageIdList); err != nil {
+		return err
+	}
+
+	// 待审核时被删除的留言作为垃圾样本，已审核通过的留言被删除不代表是垃圾内容
+	var pendingMessages []model.Message
+	for _, message := range messages {
+		if message.IsReview == constants.False {
+			pendingMessages = append(pendingMessages, message)
+		}
+	}
+	s.trainMessageSamples(ctx, pendingMessages, true)
+	return nil
+}
+
+// trainMessageSamples 使用留言训练分类器，训练失败只记录日志
+func (s *messageServiceImpl) trainMessageSamples(ctx context.Context, messages []model.Message, isSpam bool) {
+	for _, message := range messages {
+		// 使用与评分时相同的原文训练，旧数据没有原文时使用留言内容
+		content := message.MessageSource
+		if content == "" {
+			content = message.MessageContent
+		}
+		if err := s.spamService.TrainSample(ctx, spamSampleKey("message", message.ID), content, isSpam); err != nil {
+			log.Printf("Error training spam classifier with message %d: %v", message.ID, err)
+		}
+	}
 }
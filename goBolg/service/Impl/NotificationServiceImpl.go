@@ -0,0 +1,254 @@
+package Impl
+
+import (
+	"context"
+	"fmt"
+	constants "goBolg/constant"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/exception"
+	"goBolg/model"
+	"goBolg/rabbitmq/rabbitService"
+	"goBolg/service"
+	"goBolg/utils"
+	"goBolg/vo"
+	"log"
+)
+
+// notificationContentLength 通知内容的最大长度
+const notificationContentLength = 100
+
+// notificationServiceImpl 实现 NotificationService 接口
+type notificationServiceImpl struct {
+	notificationDao        dao.NotificationDao
+	notificationSettingDao dao.NotificationSettingDao
+	userInfoDao            dao.UserInfoDao
+	blogInfoService        service.BlogInfoService
+	rabbitService          rabbitService.RabbitService
+	websiteUrl             string
+}
+
+// NewNotificationService 创建新的 NotificationService 实例
+func NewNotificationService(notificationDao dao.NotificationDao, notificationSettingDao dao.NotificationSettingDao, userInfoDao dao.UserInfoDao, blogInfoService service.BlogInfoService, rabbitService rabbitService.RabbitService, websiteUrl string) service.NotificationService {
+	return &notificationServiceImpl{
+		notificationDao:        notificationDao,
+		notificationSettingDao: notificationSettingDao,
+		userInfoDao:            userInfoDao,
+		blogInfoService:        blogInfoService,
+		rabbitService:          rabbitService,
+		websiteUrl:             websiteUrl,
+	}
+}
+
+// Notify 写入站内通知，网站开启邮箱通知时按用户设置发送邮件
+func (s *notificationServiceImpl) Notify(ctx context.Context, event dto.NotificationEventDTO) error {
+	if event.UserID == 0 || event.UserID == event.FromUserID {
+		return nil
+	}
+	typeEnum := enums.GetNotificationTypeEnum(event.Type)
+	if typeEnum == nil {
+		return fmt.Errorf("unknown notification type: %d", event.Type)
+	}
+	isEmail, isInApp, err := s.getReceiveSetting(ctx, event.UserID, *typeEnum)
+	if err != nil {
+		return err
+	}
+	content := utils.MarkdownToText(event.Content, notificationContentLength)
+
+	if isInApp {
+		if err := s.saveNotification(ctx, event, *typeEnum, content); err != nil {
+			return err
+		}
+	}
+	if isEmail {
+		if err := s.sendNotificationEmail(ctx, event, *typeEnum, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveNotification 写入站内通知，可合并的通知存在未读记录时不再重复写入
+func (s *notificationServiceImpl) saveNotification(ctx context.Context, event dto.NotificationEventDTO, typeEnum enums.NotificationTypeEnum, content string) error {
+	notification := model.Notification{
+		UserID:     event.UserID,
+		FromUserID: event.FromUserID,
+		Type:       event.Type,
+		TargetID:   event.TargetID,
+		Content:    content,
+		Path:       event.Path,
+		IsRead:     constants.False,
+	}
+	if typeEnum.Merge {
+		exists, err := s.notificationDao.ExistsUnreadNotification(ctx, notification)
+		if err != nil {
+			return fmt.Errorf("failed to check unread notification: %w", err)
+		}
+		if exists {
+			return nil
+		}
+	}
+	if err := s.notificationDao.SaveNotification(ctx, &notification); err != nil {
+		return fmt.Errorf("failed to save notification: %w", err)
+	}
+	return nil
+}
+
+// sendNotificationEmail 网站开启邮箱通知且用户绑定了邮箱时发送通知邮件
+func (s *notificationServiceImpl) sendNotificationEmail(ctx context.Context, event dto.NotificationEventDTO, typeEnum enums.NotificationTypeEnum, content string) error {
+	websiteConfig, err := s.blogInfoService.GetWebsiteConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get website config: %w", err)
+	}
+	if websiteConfig.IsEmailNotice != constants.True {
+		return nil
+	}
+	user, err := s.userInfoDao.GetUserInfoById(ctx, event.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user info by ID: %w", err)
+	}
+	if user == nil || user.Email == "" {
+		log.Println("No email found for userID:", event.UserID)
+		return nil
+	}
+
+	emailDTO := dto.EmailDTO{
+		Email:    user.Email,
+		Template: typeEnum.EmailTemplate,
+		Data: map[string]interface{}{
+			"title":   typeEnum.Desc,
+			"content": content,
+			"url":     s.websiteUrl + event.Path,
+		},
+	}
+	if err := s.rabbitService.SendEmail(emailDTO); err != nil {
+		return fmt.Errorf("failed to send message to RabbitMQ: %w", err)
+	}
+	return nil
+}
+
+// getReceiveSetting 获取用户对某类通知的接收方式，未设置时使用默认接收方式
+func (s *notificationServiceImpl) getReceiveSetting(ctx context.Context, userId int, typeEnum enums.NotificationTypeEnum) (bool, bool, error) {
+	notificationSetting, err := s.notificationSettingDao.GetNotificationSetting(ctx, userId, typeEnum.Type)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get notification setting: %w", err)
+	}
+	if notificationSetting == nil {
+		return typeEnum.Email, typeEnum.InApp, nil
+	}
+	return notificationSetting.IsEmail == constants.True, notificationSetting.IsInApp == constants.True, nil
+}
+
+// ListNotifications 分页查询当前用户的通知
+func (s *notificationServiceImpl) ListNotifications(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.NotificationDTO], error) {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return vo.PageResult[dto.NotificationDTO]{}, fmt.Errorf("user not logged in")
+	}
+
+	count, err := s.notificationDao.CountNotifications(ctx, user.UserInfoID, condition)
+	if err != nil {
+		return vo.PageResult[dto.NotificationDTO]{}, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	if count == 0 {
+		return vo.NewPageResult([]dto.NotificationDTO{}, 0), nil
+	}
+	notifications, err := s.notificationDao.ListNotifications(ctx, user.UserInfoID, utils.GetLimitCurrent(ctx), utils.GetSize(ctx), condition)
+	if err != nil {
+		return vo.PageResult[dto.NotificationDTO]{}, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	return vo.NewPageResult(notifications, int(count)), nil
+}
+
+// CountUnreadNotifications 统计当前用户的未读通知数量
+func (s *notificationServiceImpl) CountUnreadNotifications(ctx context.Context) (int, error) {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return 0, fmt.Errorf("user not logged in")
+	}
+	count, err := s.notificationDao.CountUnreadNotifications(ctx, user.UserInfoID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return int(count), nil
+}
+
+// ReadNotifications 将当前用户的通知标记为已读
+func (s *notificationServiceImpl) ReadNotifications(ctx context.Context, notificationIdList []int) error {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return fmt.Errorf("user not logged in")
+	}
+	return s.notificationDao.ReadNotifications(ctx, user.UserInfoID, notificationIdList)
+}
+
+// ReadAllNotifications 将当前用户的全部通知标记为已读
+func (s *notificationServiceImpl) ReadAllNotifications(ctx context.Context) error {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return fmt.Errorf("user not logged in")
+	}
+	return s.notificationDao.ReadAllNotifications(ctx, user.UserInfoID)
+}
+
+// ListNotificationSettings 查询当前用户的通知设置，按通知类型顺序返回
+func (s *notificationServiceImpl) ListNotificationSettings(ctx context.Context) ([]dto.NotificationSettingDTO, error) {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user not logged in")
+	}
+	notificationSettings, err := s.notificationSettingDao.ListNotificationSettings(ctx, user.UserInfoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification settings: %w", err)
+	}
+	notificationSettingMap := make(map[int]model.NotificationSetting)
+	for _, notificationSetting := range notificationSettings {
+		notificationSettingMap[notificationSetting.Type] = notificationSetting
+	}
+
+	notificationSettingDTOList := make([]dto.NotificationSettingDTO, 0, len(enums.GetNotificationTypeEnums()))
+	for _, typeEnum := range enums.GetNotificationTypeEnums() {
+		notificationSettingDTO := dto.NotificationSettingDTO{
+			Type:    typeEnum.Type,
+			Desc:    typeEnum.Desc,
+			IsEmail: boolToFlag(typeEnum.Email),
+			IsInApp: boolToFlag(typeEnum.InApp),
+		}
+		if notificationSetting, ok := notificationSettingMap[typeEnum.Type]; ok {
+			notificationSettingDTO.IsEmail = notificationSetting.IsEmail
+			notificationSettingDTO.IsInApp = notificationSetting.IsInApp
+		}
+		notificationSettingDTOList = append(notificationSettingDTOList, notificationSettingDTO)
+	}
+	return notificationSettingDTOList, nil
+}
+
+// SaveNotificationSettings 保存当前用户的通知设置
+func (s *notificationServiceImpl) SaveNotificationSettings(ctx context.Context, notificationSettingVOList []vo.NotificationSettingVO) error {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return fmt.Errorf("user not logged in")
+	}
+	notificationSettings := make([]model.NotificationSetting, 0, len(notificationSettingVOList))
+	for _, notificationSettingVO := range notificationSettingVOList {
+		if enums.GetNotificationTypeEnum(notificationSettingVO.Type) == nil {
+			return exception.NewBizError(enums.VALID_ERROR.Code, "通知类型不存在")
+		}
+		notificationSettings = append(notificationSettings, model.NotificationSetting{
+			UserID:  user.UserInfoID,
+			Type:    notificationSettingVO.Type,
+			IsEmail: notificationSettingVO.IsEmail,
+			IsInApp: notificationSettingVO.IsInApp,
+		})
+	}
+	return s.notificationSettingDao.SaveNotificationSettings(ctx, notificationSettings)
+}
+
+// boolToFlag 将布尔值转换为数据库中的 0 和 1
+func boolToFlag(value bool) int {
+	if value {
+		return constants.True
+	}
+	return constants.False
+}
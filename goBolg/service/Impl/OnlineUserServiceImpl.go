@@ -0,0 +1,133 @@
+package Impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	constants "goBolg/constant"
+	"goBolg/dto"
+	"goBolg/service"
+	"goBolg/utils"
+	"goBolg/vo"
+	"log"
+	"strings"
+	"time"
+)
+
+// onlineUserTimeout 超过该时间没有请求的会话不再视为在线，记录自动过期
+const onlineUserTimeout = 30 * time.Minute
+
+// onlineUserRefreshInterval 同一会话两次记录在线信息的最小间隔，避免每个请求都写 Redis
+const onlineUserRefreshInterval = time.Minute
+
+// refreshOnlineUserScript 会话已注销时不再记录，否则保存在线信息并更新索引中的活跃时间
+var refreshOnlineUserScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[3]) == 1 then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[4])
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return 1
+`)
+
+// listOnlineUserScript 清理索引中超时和已删除的会话，按最近活跃时间倒序返回在线信息
+var listOnlineUserScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+local sessionIds = redis.call('ZREVRANGE', KEYS[1], 0, -1)
+local result = {}
+for _, sessionId in ipairs(sessionIds) do
+	local value = redis.call('GET', ARGV[2] .. sessionId)
+	if value then
+		table.insert(result, value)
+	else
+		redis.call('ZREM', KEYS[1], sessionId)
+	end
+end
+return result
+`)
+
+// onlineUserServiceImpl 实现 OnlineUserService 接口，在线信息按登录会话保存在 Redis 中
+type onlineUserServiceImpl struct {
+	redisService service.RedisService
+}
+
+// NewOnlineUserService 创建新的 OnlineUserService 实例
+func NewOnlineUserService(redisService service.RedisService) service.OnlineUserService {
+	return &onlineUserServiceImpl{
+		redisService: redisService,
+	}
+}
+
+// RefreshOnlineUser 记录登录会话的最近活跃信息，距离上次记录不到一分钟时跳过，记录在超时后自动过期，
+// 未指定 IP 来源时按 IP 查询
+func (s *onlineUserServiceImpl) RefreshOnlineUser(ctx context.Context, sessionId string, onlineUser dto.UserOnlineDTO) error {
+	refreshed, err := s.redisService.SetNX(ctx, constants.OnlineUserRefresh+sessionId, constants.True, onlineUserRefreshInterval)
+	if err != nil {
+		return fmt.Errorf("failed to check online user refresh: %w", err)
+	}
+	if !refreshed {
+		return nil
+	}
+	if onlineUser.IPSource == "" {
+		onlineUser.IPSource = s.ipSource(ctx, sessionId, onlineUser.IPAddress)
+	}
+
+	value, err := json.Marshal(onlineUser)
+	if err != nil {
+		return fmt.Errorf("failed to marshal online user: %w", err)
+	}
+	keys := []string{constants.OnlineUser + sessionId, constants.OnlineUserIndex, constants.TokenRevokedSession + sessionId}
+	_, err = s.redisService.RunScript(ctx, refreshOnlineUserScript, keys, value, onlineUserTimeout.Milliseconds(), onlineUser.LastLoginTime.UnixMilli(), sessionId)
+	if err != nil {
+		return fmt.Errorf("failed to refresh online user: %w", err)
+	}
+	return nil
+}
+
+// ListOnlineUsers 分页查询在线用户，同一用户在多个设备上登录时每个会话单独列出
+func (s *onlineUserServiceImpl) ListOnlineUsers(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.UserOnlineDTO], error) {
+	expired := time.Now().Add(-onlineUserTimeout).UnixMilli()
+	result, err := s.redisService.RunScript(ctx, listOnlineUserScript, []string{constants.OnlineUserIndex}, expired, constants.OnlineUser)
+	if err != nil {
+		return vo.PageResult[dto.UserOnlineDTO]{}, fmt.Errorf("failed to list online users: %w", err)
+	}
+	values, _ := result.([]interface{})
+
+	onlineUsers := make([]dto.UserOnlineDTO, 0, len(values))
+	for _, value := range values {
+		text, _ := value.(string)
+		var onlineUser dto.UserOnlineDTO
+		if err := json.Unmarshal([]byte(text), &onlineUser); err != nil {
+			log.Printf("Error unmarshaling online user: %v", err)
+			continue
+		}
+		if condition.Keywords != nil && !strings.Contains(onlineUser.Nickname, *condition.Keywords) {
+			continue
+		}
+		onlineUsers = append(onlineUsers, onlineUser)
+	}
+
+	count := len(onlineUsers)
+	start := utils.GetLimitCurrent(ctx)
+	if start > count {
+		start = count
+	}
+	end := start + utils.GetSize(ctx)
+	if end > count {
+		end = count
+	}
+	return vo.NewPageResult(onlineUsers[start:end], count), nil
+}
+
+// ipSource IP 与上次记录的相同时沿用已查询的来源，否则重新查询
+func (s *onlineUserServiceImpl) ipSource(ctx context.Context, sessionId string, ipAddress string) string {
+	if value, err := s.redisService.Get(ctx, constants.OnlineUser+sessionId); err == nil {
+		var previous dto.UserOnlineDTO
+		if json.Unmarshal([]byte(value), &previous) == nil && previous.IPAddress == ipAddress && previous.IPSource != "" {
+			return previous.IPSource
+		}
+	}
+	return utils.GetIPSource(ipAddress)
+}
@@ -0,0 +1,97 @@
+package Impl
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	constants "goBolg/constant"
+	"goBolg/dto"
+	"goBolg/service"
+	"testing"
+	"time"
+)
+
+// newTestOnlineUserService 使用 miniredis 创建 OnlineUserService
+func newTestOnlineUserService(t *testing.T) (service.OnlineUserService, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewOnlineUserService(NewRedisServiceImpl(client)), server
+}
+
+func TestRefreshOnlineUser(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		// 第二次记录前经过的时间
+		elapsed      time.Duration
+		second       dto.UserOnlineDTO
+		wantIP       string
+		wantIPSource string
+	}{
+		{
+			name:         "一分钟内不重复记录",
+			elapsed:      30 * time.Second,
+			second:       dto.UserOnlineDTO{UserInfoID: 1, IPAddress: "10.0.0.2", IPSource: "上海"},
+			wantIP:       "10.0.0.1",
+			wantIPSource: "北京",
+		},
+		{
+			name:         "超过一分钟重新记录",
+			elapsed:      2 * time.Minute,
+			second:       dto.UserOnlineDTO{UserInfoID: 1, IPAddress: "10.0.0.2", IPSource: "上海"},
+			wantIP:       "10.0.0.2",
+			wantIPSource: "上海",
+		},
+		{
+			name:         "IP 未变化时沿用已查询的来源",
+			elapsed:      2 * time.Minute,
+			second:       dto.UserOnlineDTO{UserInfoID: 1, IPAddress: "10.0.0.1"},
+			wantIP:       "10.0.0.1",
+			wantIPSource: "北京",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, server := newTestOnlineUserService(t)
+			first := dto.UserOnlineDTO{UserInfoID: 1, IPAddress: "10.0.0.1", IPSource: "北京", LastLoginTime: time.Now()}
+			if err := s.RefreshOnlineUser(ctx, "session", first); err != nil {
+				t.Fatalf("RefreshOnlineUser returned error: %v", err)
+			}
+
+			server.FastForward(tt.elapsed)
+			tt.second.LastLoginTime = time.Now()
+			if err := s.RefreshOnlineUser(ctx, "session", tt.second); err != nil {
+				t.Fatalf("RefreshOnlineUser returned error: %v", err)
+			}
+
+			value, err := server.Get(constants.OnlineUser + "session")
+			if err != nil {
+				t.Fatalf("online user not found: %v", err)
+			}
+			var onlineUser dto.UserOnlineDTO
+			if err := json.Unmarshal([]byte(value), &onlineUser); err != nil {
+				t.Fatalf("failed to unmarshal online user: %v", err)
+			}
+			if onlineUser.IPAddress != tt.wantIP || onlineUser.IPSource != tt.wantIPSource {
+				t.Errorf("online user ip = %s %s, want %s %s", onlineUser.IPAddress, onlineUser.IPSource, tt.wantIP, tt.wantIPSource)
+			}
+		})
+	}
+}
+
+func TestRefreshOnlineUserRevoked(t *testing.T) {
+	ctx := context.Background()
+	s, server := newTestOnlineUserService(t)
+	server.Set(constants.TokenRevokedSession+"session", "1")
+
+	onlineUser := dto.UserOnlineDTO{UserInfoID: 1, IPAddress: "10.0.0.1", IPSource: "北京", LastLoginTime: time.Now()}
+	if err := s.RefreshOnlineUser(ctx, "session", onlineUser); err != nil {
+		t.Fatalf("RefreshOnlineUser returned error: %v", err)
+	}
+	if server.Exists(constants.OnlineUser + "session") {
+		t.Errorf("online user of revoked session is recorded")
+	}
+}
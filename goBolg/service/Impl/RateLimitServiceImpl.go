@@ -0,0 +1,112 @@
+package Impl
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"goBolg/config"
+	constants "goBolg/constant"
+	"goBolg/service"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// rateLimitScript 滑动窗口限流，先移除每个键窗口外的请求记录，全部未超出限制时才为每个键记录本次请求并返回 0，
+// 任意一个超出限制时不记录，返回最早的请求移出窗口前需要等待的最长毫秒数
+var rateLimitScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local wait = 0
+for _, key in ipairs(KEYS) do
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+	if redis.call('ZCARD', key) >= limit then
+		local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+		wait = math.max(wait, tonumber(oldest[2]) + window - now, 1)
+	end
+end
+if wait > 0 then
+	return wait
+end
+for _, key in ipairs(KEYS) do
+	redis.call('ZADD', key, now, ARGV[4])
+	redis.call('PEXPIRE', key, window)
+end
+return 0
+`)
+
+// rateLimitServiceImpl 实现 RateLimitService 接口
+type rateLimitServiceImpl struct {
+	redisService service.RedisService
+	rules        map[string]config.RateLimitRule
+	instance     int64  // 区分不同实例写入的请求记录
+	sequence     uint64 // 区分同一毫秒内的请求记录
+}
+
+// NewRateLimitService 创建新的 RateLimitService 实例，忽略次数或时间窗口无效的规则
+func NewRateLimitService(redisService service.RedisService, rateLimitConfig config.RateLimitConfig) service.RateLimitService {
+	rules := make(map[string]config.RateLimitRule)
+	for _, rule := range rateLimitConfig.Rules {
+		if rule.Limit <= 0 || rule.Window <= 0 {
+			log.Printf("Ignoring invalid rate limit rule for %s %s", rule.Method, rule.Path)
+			continue
+		}
+		rules[rateLimitRuleKey(rule.Method, rule.Path)] = rule
+	}
+	return &rateLimitServiceImpl{
+		redisService: redisService,
+		rules:        rules,
+		instance:     rand.Int63(),
+	}
+}
+
+// Acquire 按规则的限流维度检查，任意一个维度超出限制即被限流，被限流的请求不占用任何维度的次数
+func (s *rateLimitServiceImpl) Acquire(ctx context.Context, method string, path string, userId int, ipAddress string) (time.Duration, error) {
+	ruleKey := rateLimitRuleKey(method, path)
+	rule, ok := s.rules[ruleKey]
+	if !ok {
+		return 0, nil
+	}
+
+	prefix := constants.RateLimit + ruleKey + ":"
+	ipKey := prefix + "ip:" + ipAddress
+	userKey := prefix + "user:" + strconv.Itoa(userId)
+	var keys []string
+	switch rule.KeyBy {
+	case constants.RateLimitKeyIP:
+		keys = []string{ipKey}
+	case constants.RateLimitKeyBoth:
+		keys = []string{ipKey}
+		if userId > 0 {
+			keys = append(keys, userKey)
+		}
+	default:
+		keys = []string{ipKey}
+		if userId > 0 {
+			keys = []string{userKey}
+		}
+	}
+
+	return s.acquireKeys(ctx, keys, rule)
+}
+
+// acquireKeys 在每个键的滑动窗口中记录一次请求，超出限制时返回需要等待的时间
+func (s *rateLimitServiceImpl) acquireKeys(ctx context.Context, keys []string, rule config.RateLimitRule) (time.Duration, error) {
+	now := time.Now().UnixMilli()
+	member := fmt.Sprintf("%d-%d-%d", now, s.instance, atomic.AddUint64(&s.sequence, 1))
+	result, err := s.redisService.RunScript(ctx, rateLimitScript, keys, now, rule.Window*1000, rule.Limit, member)
+	if err != nil {
+		return 0, err
+	}
+	wait, _ := result.(int64)
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+// rateLimitRuleKey 生成限流规则的唯一标识
+func rateLimitRuleKey(method string, path string) string {
+	return strings.ToUpper(method) + ":" + path
+}
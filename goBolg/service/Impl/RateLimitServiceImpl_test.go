@@ -0,0 +1,70 @@
+package Impl
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"goBolg/config"
+	constants "goBolg/constant"
+	"testing"
+)
+
+// rateLimitRequest 测试中依次发出的请求
+type rateLimitRequest struct {
+	userId int
+	ip     string
+}
+
+func TestRateLimitAcquire(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		requests    []rateLimitRequest
+		keyBy       string
+		wantLimited []bool
+	}{
+		{
+			name:        "按用户限流时游客按 IP",
+			keyBy:       constants.RateLimitKeyUser,
+			requests:    []rateLimitRequest{{0, "1.1.1.1"}, {0, "1.1.1.1"}, {0, "2.2.2.2"}},
+			wantLimited: []bool{false, true, false},
+		},
+		{
+			name:        "按用户限流时更换 IP 无效",
+			keyBy:       constants.RateLimitKeyUser,
+			requests:    []rateLimitRequest{{1, "1.1.1.1"}, {1, "2.2.2.2"}},
+			wantLimited: []bool{false, true},
+		},
+		{
+			name:        "同时按用户和 IP 限流",
+			keyBy:       constants.RateLimitKeyBoth,
+			requests:    []rateLimitRequest{{1, "1.1.1.1"}, {2, "1.1.1.1"}, {1, "2.2.2.2"}},
+			wantLimited: []bool{false, true, true},
+		},
+		{
+			name:        "被用户维度拒绝的请求不占用 IP 次数",
+			keyBy:       constants.RateLimitKeyBoth,
+			requests:    []rateLimitRequest{{1, "1.1.1.1"}, {1, "2.2.2.2"}, {2, "2.2.2.2"}},
+			wantLimited: []bool{false, true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			defer client.Close()
+			s := NewRateLimitService(NewRedisServiceImpl(client), config.RateLimitConfig{
+				Rules: []config.RateLimitRule{{Method: "POST", Path: "/messages", Limit: 1, Window: 60, KeyBy: tt.keyBy}},
+			})
+			for i, request := range tt.requests {
+				retryAfter, err := s.Acquire(ctx, "POST", "/messages", request.userId, request.ip)
+				if err != nil {
+					t.Fatalf("Acquire returned error: %v", err)
+				}
+				if limited := retryAfter > 0; limited != tt.wantLimited[i] {
+					t.Errorf("request %d limited = %v, want %v", i, limited, tt.wantLimited[i])
+				}
+			}
+		})
+	}
+}
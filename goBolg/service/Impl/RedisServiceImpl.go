@@ -22,6 +22,11 @@ func (r *RedisServiceImpl) Set(ctx context.Context, key string, value interface{
 	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
+// SetNX 键不存在时存储键值对并设置过期时间，返回是否存储成功
+func (r *RedisServiceImpl) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
 // Get 从 Redis 中获取键对应的值
 func (r *RedisServiceImpl) Get(ctx context.Context, key string) (string, error) {
 	result, err := r.client.Get(ctx, key).Result()
@@ -36,6 +41,35 @@ func (r *RedisServiceImpl) Del(ctx context.Context, keys ...string) (int64, erro
 	return r.client.Del(ctx, keys...).Result()
 }
 
+// DelByPrefix 使用 SCAN 遍历并删除指定前缀的全部键，返回删除的数量
+func (r *RedisServiceImpl) DelByPrefix(ctx context.Context, prefix string) (int64, error) {
+	var deleted int64
+	iter := r.client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	keys := make([]string, 0, 100)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 100 {
+			n, err := r.client.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, err
+			}
+			deleted += n
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return deleted, err
+	}
+	if len(keys) > 0 {
+		n, err := r.client.Del(ctx, keys...).Result()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
+
 // Increment a key
 func (r *RedisServiceImpl) Incr(ctx context.Context, key string, delta int64) (int64, error) {
 	return r.client.IncrBy(ctx, key, delta).Result()
@@ -81,6 +115,11 @@ func (r *RedisServiceImpl) HGetAll(ctx context.Context, key string) (map[string]
 	return result, nil
 }
 
+// HMGet 批量获取哈希字段的值，不存在的字段为 nil
+func (r *RedisServiceImpl) HMGet(ctx context.Context, key string, fields ...string) ([]interface{}, error) {
+	return r.client.HMGet(ctx, key, fields...).Result()
+}
+
 func (r *RedisServiceImpl) ZAdd(ctx context.Context, key string, members ...*redis.Z) (int64, error) {
 	return r.client.ZAdd(ctx, key, members...).Result()
 }
@@ -261,6 +300,21 @@ func (r *RedisServiceImpl) HDecr(ctx context.Context, key string, hashKey string
 	return r.client.HIncrBy(ctx, key, hashKey, -delta).Result()
 }
 
+// Publish 向频道发布消息，返回收到消息的订阅者数量
+func (r *RedisServiceImpl) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	return r.client.Publish(ctx, channel, message).Result()
+}
+
+// Subscribe 订阅频道，断线后自动重新订阅
+func (r *RedisServiceImpl) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return r.client.Subscribe(ctx, channels...)
+}
+
+// RunScript 执行 Lua 脚本，脚本已缓存时使用 EVALSHA
+func (r *RedisServiceImpl) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	return script.Run(ctx, r.client, keys, args...).Result()
+}
+
 type Point struct {
 	X, Y float64
 }
@@ -35,7 +35,7 @@ func (s *ResourceServiceImpl) SaveOrUpdateResource(ctx context.Context, resource
 	}
 
 	// 重新加载角色资源信息
-	s.filterInvocationSecurityMetadataSource.ClearDataSource()
+	s.filterInvocationSecurityMetadataSource.ClearDataSource(ctx)
 	return nil
 }
 
@@ -57,7 +57,13 @@ func (s *ResourceServiceImpl) DeleteResource(ctx context.Context, resourceId uin
 	resourceIds = append(resourceIds, resourceId)
 
 	// 删除子资源及当前资源
-	return s.resourceDao.DeleteResources(ctx, resourceIds)
+	if err := s.resourceDao.DeleteResources(ctx, resourceIds); err != nil {
+		return err
+	}
+
+	// 重新加载角色资源信息
+	s.filterInvocationSecurityMetadataSource.ClearDataSource(ctx)
+	return nil
 }
 
 func (s *ResourceServiceImpl) ListResources(ctx context.Context, conditionVO vo.ConditionVO) ([]dto.ResourceDTO, error) {
@@ -76,7 +76,11 @@ func (service *RoleServiceImpl) DeleteRoles(ctx context.Context, roleIdList []in
 	if count > 0 {
 		return fmt.Errorf("该角色下存在用户")
 	}
-	return service.roleDao.DeleteRoles(ctx, roleIdList)
+	if err := service.roleDao.DeleteRoles(ctx, roleIdList); err != nil {
+		return err
+	}
+	service.filterInvocationSecurityMetadataSource.ClearDataSource(ctx) // 重新加载角色资源信息
+	return nil
 }
 
 // 在 rabbitService/role_service_impl.go 中
@@ -118,8 +122,9 @@ func (s *RoleServiceImpl) SaveOrUpdateRole(ctx context.Context, roleVO *vo.RoleV
 		if err := s.roleResourceDao.SaveBatch(ctx, roleResources); err != nil {
 			return err
 		}
-		s.filterInvocationSecurityMetadataSource.ClearDataSource() // 重新加载角色资源信息
 	}
+	// 角色标识可能已修改，重新加载角色资源信息
+	s.filterInvocationSecurityMetadataSource.ClearDataSource(ctx)
 
 	// 更新角色菜单关系
 	if roleVO.MenuIDList != nil {
@@ -0,0 +1,89 @@
+package Impl
+
+import (
+	"context"
+	"fmt"
+	constants "goBolg/constant"
+	"goBolg/dao"
+	"goBolg/service"
+	"log"
+	"sync"
+	"time"
+)
+
+// defaultPublishInterval 未配置时检查定时发布的间隔
+const defaultPublishInterval = time.Minute
+
+// scheduleServiceImpl 实现 ScheduleService 接口
+type scheduleServiceImpl struct {
+	articleDao   dao.ArticleDao
+	talkDao      dao.TalkDao
+	redisService service.RedisService
+	interval     time.Duration
+	stop         chan struct{}
+	stopOnce     sync.Once
+}
+
+// NewScheduleService 创建新的 ScheduleService 实例，interval 为检查定时发布的间隔
+func NewScheduleService(articleDao dao.ArticleDao, talkDao dao.TalkDao, redisService service.RedisService, interval time.Duration) service.ScheduleService {
+	if interval <= 0 {
+		interval = defaultPublishInterval
+	}
+	return &scheduleServiceImpl{
+		articleDao:   articleDao,
+		talkDao:      talkDao,
+		redisService: redisService,
+		interval:     interval,
+		stop:         make(chan struct{}),
+	}
+}
+
+// Start 在后台定期发布到期的文章和说说，启动时先执行一次
+func (s *scheduleServiceImpl) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			if err := s.PublishDueItems(context.Background()); err != nil {
+				log.Printf("Error publishing scheduled items: %v", err)
+			}
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定时任务
+func (s *scheduleServiceImpl) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+}
+
+// PublishDueItems 将发布时间已到的草稿文章和说说改为公开
+func (s *scheduleServiceImpl) PublishDueItems(ctx context.Context) error {
+	now := time.Now()
+
+	articleCount, err := s.articleDao.PublishDueArticles(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to publish scheduled articles: %w", err)
+	}
+	if articleCount > 0 {
+		// 新发布的文章需要出现在订阅源中
+		if _, err := s.redisService.DelByPrefix(ctx, constants.FeedCache); err != nil {
+			log.Printf("Error clearing feed cache: %v", err)
+		}
+	}
+	talkCount, err := s.talkDao.PublishDueTalks(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to publish scheduled talks: %w", err)
+	}
+
+	if articleCount > 0 || talkCount > 0 {
+		log.Printf("Published %d scheduled articles and %d scheduled talks", articleCount, talkCount)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package Impl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	constants "goBolg/constant"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/exception"
+	"goBolg/model"
+	"goBolg/service"
+	"goBolg/utils"
+	"goBolg/vo"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// sensitiveWordReloadInterval 定期重新加载词库的间隔，用于补偿断线期间丢失的变更通知
+const sensitiveWordReloadInterval = 5 * time.Minute
+
+// sensitiveWordMaxLength 敏感词的最大长度，与表字段长度一致
+const sensitiveWordMaxLength = 50
+
+// sensitiveWordServiceImpl 实现 SensitiveWordService 接口
+type sensitiveWordServiceImpl struct {
+	sensitiveWordDao dao.SensitiveWordDao
+	redisService     service.RedisService
+	filter           *utils.SensitiveFilter
+	stop             chan struct{}
+	stopOnce         sync.Once
+}
+
+// NewSensitiveWordService 创建新的 SensitiveWordService 实例
+func NewSensitiveWordService(sensitiveWordDao dao.SensitiveWordDao, redisService service.RedisService) service.SensitiveWordService {
+	return &sensitiveWordServiceImpl{
+		sensitiveWordDao: sensitiveWordDao,
+		redisService:     redisService,
+		filter:           utils.NewSensitiveFilter(),
+		stop:             make(chan struct{}),
+	}
+}
+
+// ListBackSensitiveWords 分页查询后台敏感词，类型条件表示处理方式
+func (s *sensitiveWordServiceImpl) ListBackSensitiveWords(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.SensitiveWordDTO], error) {
+	count, err := s.sensitiveWordDao.CountBackSensitiveWords(ctx, condition)
+	if err != nil || count == 0 {
+		return vo.NewPageResult([]dto.SensitiveWordDTO{}, 0), err
+	}
+
+	sensitiveWords, err := s.sensitiveWordDao.ListBackSensitiveWords(ctx, utils.GetLimitCurrent(ctx), utils.GetSize(ctx), condition)
+	if err != nil {
+		return vo.PageResult[dto.SensitiveWordDTO]{}, err
+	}
+
+	sensitiveWordDTOList := make([]dto.SensitiveWordDTO, 0, len(sensitiveWords))
+	for _, sensitiveWord := range sensitiveWords {
+		sensitiveWordDTOList = append(sensitiveWordDTOList, dto.SensitiveWordDTO{
+			ID:         sensitiveWord.ID,
+			Word:       sensitiveWord.Word,
+			Action:     sensitiveWord.Action,
+			CreateTime: sensitiveWord.CreateTime,
+			UpdateTime: sensitiveWord.UpdateTime,
+		})
+	}
+	return vo.NewPageResult(sensitiveWordDTOList, int(count)), nil
+}
+
+// SaveOrUpdateSensitiveWord 保存或更新敏感词，敏感词不能重复
+func (s *sensitiveWordServiceImpl) SaveOrUpdateSensitiveWord(ctx context.Context, sensitiveWordVO vo.SensitiveWordVO) error {
+	word := strings.TrimSpace(sensitiveWordVO.Word)
+	if word == "" {
+		return exception.NewBizError(enums.VALID_ERROR.Code, "敏感词不能为空")
+	}
+
+	existWord, err := s.sensitiveWordDao.GetSensitiveWordByWord(ctx, word)
+	if err != nil {
+		return err
+	}
+	if existWord != nil && existWord.ID != sensitiveWordVO.ID {
+		return exception.NewBizError(enums.VALID_ERROR.Code, "敏感词已存在")
+	}
+
+	sensitiveWord := &model.SensitiveWord{
+		ID:     sensitiveWordVO.ID,
+		Word:   word,
+		Action: sensitiveWordVO.Action,
+	}
+	if err := s.sensitiveWordDao.SaveOrUpdateSensitiveWord(ctx, sensitiveWord); err != nil {
+		return err
+	}
+	return s.notifyChanged(ctx)
+}
+
+// DeleteSensitiveWords 删除敏感词
+func (s *sensitiveWordServiceImpl) DeleteSensitiveWords(ctx context.Context, sensitiveWordIdList []int) error {
+	if len(sensitiveWordIdList) == 0 {
+		return nil
+	}
+	if err := s.sensitiveWordDao.DeleteSensitiveWordByIds(ctx, sensitiveWordIdList); err != nil {
+		return err
+	}
+	return s.notifyChanged(ctx)
+}
+
+// ImportSensitiveWords 导入敏感词，跳过空行、重复和过长的敏感词
+func (s *sensitiveWordServiceImpl) ImportSensitiveWords(ctx context.Context, reader io.Reader, action int) (dto.SensitiveWordImportDTO, error) {
+	var result dto.SensitiveWordImportDTO
+	if enums.GetSensitiveActionEnum(action) == nil {
+		return result, exception.NewBizError(enums.VALID_ERROR.Code, "不支持的处理方式")
+	}
+
+	var sensitiveWords []model.SensitiveWord
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		word := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if word == "" || seen[word] || utf8.RuneCountInString(word) > sensitiveWordMaxLength {
+			result.SkipCount++
+			continue
+		}
+		seen[word] = true
+		sensitiveWords = append(sensitiveWords, model.SensitiveWord{Word: word, Action: action})
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read sensitive words: %w", err)
+	}
+
+	if err := s.sensitiveWordDao.UpsertSensitiveWords(ctx, sensitiveWords); err != nil {
+		return result, err
+	}
+	result.SuccessCount = len(sensitiveWords)
+	return result, s.notifyChanged(ctx)
+}
+
+// CheckText 检查文本中的敏感词
+func (s *sensitiveWordServiceImpl) CheckText(text string) utils.SensitiveResult {
+	return s.filter.Check(text)
+}
+
+// ReloadSensitiveWords 从数据库加载全部敏感词并替换内存中的词库
+func (s *sensitiveWordServiceImpl) ReloadSensitiveWords(ctx context.Context) error {
+	sensitiveWords, err := s.sensitiveWordDao.ListSensitiveWords(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load sensitive words: %w", err)
+	}
+
+	wordActions := make(map[string]int, len(sensitiveWords))
+	for _, sensitiveWord := range sensitiveWords {
+		wordActions[sensitiveWord.Word] = sensitiveWord.Action
+	}
+	s.filter.Load(wordActions)
+	return nil
+}
+
+// Start 加载词库，并在后台监听变更通知，同时定期重新加载
+func (s *sensitiveWordServiceImpl) Start() {
+	if err := s.ReloadSensitiveWords(context.Background()); err != nil {
+		log.Printf("Error loading sensitive words: %v", err)
+	}
+
+	go func() {
+		pubSub := s.redisService.Subscribe(context.Background(), constants.SensitiveWordChannel)
+		defer pubSub.Close()
+		ticker := time.NewTicker(sensitiveWordReloadInterval)
+		defer ticker.Stop()
+
+		messages := pubSub.Channel()
+		for {
+			select {
+			case <-messages:
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+			if err := s.ReloadSensitiveWords(context.Background()); err != nil {
+				log.Printf("Error reloading sensitive words: %v", err)
+			}
+		}
+	}()
+}
+
+// Stop 停止监听变更通知
+func (s *sensitiveWordServiceImpl) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+}
+
+// notifyChanged 重新加载当前实例的词库，并通知其他实例重新加载
+func (s *sensitiveWordServiceImpl) notifyChanged(ctx context.Context) error {
+	if err := s.ReloadSensitiveWords(ctx); err != nil {
+		return err
+	}
+	if _, err := s.redisService.Publish(ctx, constants.SensitiveWordChannel, time.Now().UnixNano()); err != nil {
+		log.Printf("Error publishing sensitive word change: %v", err)
+	}
+	return nil
+}
+
+// checkSensitiveContent 检查用户提交的内容，命中拒绝类敏感词时返回错误，返回替换后的内容和是否需要审核
+func checkSensitiveContent(sensitiveWordService service.SensitiveWordService, content string) (string, bool, error) {
+	result := sensitiveWordService.CheckText(content)
+	if result.Rejected() {
+		return "", false, exception.NewBizError(enums.VALID_ERROR.Code, "内容包含违规词，请修改后重新提交")
+	}
+	return result.Text, result.NeedReview(), nil
+}
@@ -0,0 +1,221 @@
+package Impl
+
+import (
+	"context"
+	"errors"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/model"
+	"goBolg/service"
+	"goBolg/utils"
+	"goBolg/vo"
+)
+
+// seriesServiceImpl 实现 SeriesService 接口
+type seriesServiceImpl struct {
+	seriesDao  dao.SeriesDao
+	articleDao dao.ArticleDao
+}
+
+// NewSeriesService 创建新的 SeriesService 实例
+func NewSeriesService(seriesDao dao.SeriesDao, articleDao dao.ArticleDao) service.SeriesService {
+	return &seriesServiceImpl{
+		seriesDao:  seriesDao,
+		articleDao: articleDao,
+	}
+}
+
+// ListSeries 查询所有系列，只展示已发布的公开文章
+func (s *seriesServiceImpl) ListSeries(ctx context.Context) ([]dto.SeriesDTO, error) {
+	seriesList, err := s.seriesDao.ListSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	articleMap, err := s.listSeriesArticles(ctx, seriesList, true)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesDTOList := make([]dto.SeriesDTO, 0, len(seriesList))
+	for _, series := range seriesList {
+		articles := articleMap[series.ID]
+		seriesDTOList = append(seriesDTOList, dto.SeriesDTO{
+			ID:           series.ID,
+			SeriesTitle:  series.SeriesTitle,
+			SeriesDesc:   series.SeriesDesc,
+			SeriesCover:  series.SeriesCover,
+			ArticleCount: len(articles),
+			ArticleList:  articles,
+		})
+	}
+	return seriesDTOList, nil
+}
+
+// ListBackSeries 分页查询后台系列，包含系列中的全部文章
+func (s *seriesServiceImpl) ListBackSeries(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.SeriesBackDTO], error) {
+	count, err := s.seriesDao.CountBackSeries(ctx, condition)
+	if err != nil || count == 0 {
+		return vo.NewPageResult([]dto.SeriesBackDTO{}, 0), err
+	}
+
+	seriesList, err := s.seriesDao.ListBackSeries(ctx, utils.GetLimitCurrent(ctx), utils.GetSize(ctx), condition)
+	if err != nil {
+		return vo.NewPageResult([]dto.SeriesBackDTO{}, 0), err
+	}
+
+	articleMap, err := s.listSeriesArticles(ctx, seriesList, false)
+	if err != nil {
+		return vo.NewPageResult([]dto.SeriesBackDTO{}, 0), err
+	}
+
+	seriesBackDTOList := make([]dto.SeriesBackDTO, 0, len(seriesList))
+	for _, series := range seriesList {
+		seriesBackDTOList = append(seriesBackDTOList, toSeriesBackDTO(series, articleMap[series.ID]))
+	}
+	return vo.NewPageResult(seriesBackDTOList, int(count)), nil
+}
+
+// GetBackSeriesById 查询后台系列详情
+func (s *seriesServiceImpl) GetBackSeriesById(ctx context.Context, seriesId int) (*dto.SeriesBackDTO, error) {
+	series, err := s.seriesDao.GetSeriesById(ctx, seriesId)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, errors.New("系列不存在")
+	}
+
+	articleMap, err := s.listSeriesArticles(ctx, []model.Series{*series}, false)
+	if err != nil {
+		return nil, err
+	}
+	seriesBackDTO := toSeriesBackDTO(*series, articleMap[series.ID])
+	return &seriesBackDTO, nil
+}
+
+// SaveOrUpdateSeries 保存或更新系列，文章按 ArticleIdList 的顺序排列
+func (s *seriesServiceImpl) SaveOrUpdateSeries(ctx context.Context, seriesVO vo.SeriesVO) error {
+	if seriesVO.ID != 0 {
+		existing, err := s.seriesDao.GetSeriesById(ctx, seriesVO.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return errors.New("系列不存在")
+		}
+	}
+
+	// 去掉重复的文章id并保持顺序
+	articleIdList := make([]int, 0, len(seriesVO.ArticleIdList))
+	seen := make(map[int]bool, len(seriesVO.ArticleIdList))
+	for _, articleId := range seriesVO.ArticleIdList {
+		if !seen[articleId] {
+			seen[articleId] = true
+			articleIdList = append(articleIdList, articleId)
+		}
+	}
+
+	if len(articleIdList) > 0 {
+		articles, err := s.articleDao.ListArticlesByIds(ctx, articleIdList)
+		if err != nil {
+			return err
+		}
+		if len(articles) != len(articleIdList) {
+			return errors.New("系列中包含不存在的文章")
+		}
+	}
+
+	series := &model.Series{
+		ID:          seriesVO.ID,
+		SeriesTitle: seriesVO.SeriesTitle,
+		SeriesDesc:  seriesVO.SeriesDesc,
+		SeriesCover: seriesVO.SeriesCover,
+	}
+	return s.seriesDao.SaveOrUpdateSeries(ctx, series, articleIdList)
+}
+
+// DeleteSeries 删除系列
+func (s *seriesServiceImpl) DeleteSeries(ctx context.Context, seriesIdList []int) error {
+	if len(seriesIdList) == 0 {
+		return nil
+	}
+	return s.seriesDao.DeleteSeriesByIds(ctx, seriesIdList)
+}
+
+// GetArticleSeries 按系列中已发布的公开文章计算当前文章的位置和前后篇
+func (s *seriesServiceImpl) GetArticleSeries(ctx context.Context, articleId int) (*dto.ArticleSeriesDTO, error) {
+	seriesId, err := s.seriesDao.GetSeriesIdByArticleId(ctx, articleId)
+	if err != nil || seriesId == 0 {
+		return nil, err
+	}
+	series, err := s.seriesDao.GetSeriesById(ctx, seriesId)
+	if err != nil || series == nil {
+		return nil, err
+	}
+
+	articleMap, err := s.listSeriesArticles(ctx, []model.Series{*series}, true)
+	if err != nil {
+		return nil, err
+	}
+	articles := articleMap[series.ID]
+	for i, article := range articles {
+		if article.ID != articleId {
+			continue
+		}
+		articleSeries := &dto.ArticleSeriesDTO{
+			ID:          series.ID,
+			SeriesTitle: series.SeriesTitle,
+			Position:    i + 1,
+			Total:       len(articles),
+		}
+		if i > 0 {
+			articleSeries.LastArticle = &articles[i-1]
+		}
+		if i < len(articles)-1 {
+			articleSeries.NextArticle = &articles[i+1]
+		}
+		return articleSeries, nil
+	}
+	return nil, nil
+}
+
+// listSeriesArticles 查询系列中的文章并按系列id分组
+func (s *seriesServiceImpl) listSeriesArticles(ctx context.Context, seriesList []model.Series, publicOnly bool) (map[int][]dto.ArticlePaginationDTO, error) {
+	seriesIdList := make([]int, 0, len(seriesList))
+	for _, series := range seriesList {
+		seriesIdList = append(seriesIdList, series.ID)
+	}
+
+	seriesArticles, err := s.seriesDao.ListSeriesArticles(ctx, seriesIdList, publicOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	articleMap := make(map[int][]dto.ArticlePaginationDTO, len(seriesList))
+	for _, article := range seriesArticles {
+		articleMap[article.SeriesID] = append(articleMap[article.SeriesID], dto.ArticlePaginationDTO{
+			ID:           article.ID,
+			ArticleTitle: article.ArticleTitle,
+			ArticleCover: article.ArticleCover,
+			CreateTime:   article.CreateTime,
+		})
+	}
+	return articleMap, nil
+}
+
+// toSeriesBackDTO 转换为后台系列 DTO
+func toSeriesBackDTO(series model.Series, articles []dto.ArticlePaginationDTO) dto.SeriesBackDTO {
+	if articles == nil {
+		articles = []dto.ArticlePaginationDTO{}
+	}
+	return dto.SeriesBackDTO{
+		ID:          series.ID,
+		SeriesTitle: series.SeriesTitle,
+		SeriesDesc:  series.SeriesDesc,
+		SeriesCover: series.SeriesCover,
+		ArticleList: articles,
+		CreateTime:  series.CreateTime,
+		UpdateTime:  series.UpdateTime,
+	}
+}
@@ -0,0 +1,219 @@
+package Impl
+
+import (
+	"context"
+	"fmt"
+	"goBolg/config"
+	"goBolg/constant"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/service"
+	"goBolg/utils"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 站点地图默认配置
+const (
+	defaultSitemapPageSize = 5000
+	maxSitemapPageSize     = 50000 // 协议规定单个站点地图最多 50000 个地址
+	defaultSitemapCacheTTL = time.Hour
+)
+
+// sitemapPagePaths 前台固定页面标签对应的访问路径，其余页面使用 /{标签}
+var sitemapPagePaths = map[string]string{
+	"home":     "/",
+	"archive":  "/archives",
+	"category": "/categories",
+	"tag":      "/tags",
+	"album":    "/albums",
+	"talk":     "/talks",
+	"link":     "/links",
+	"about":    "/about",
+	"message":  "/message",
+}
+
+// sitemapServiceImpl 实现 SitemapService 接口
+type sitemapServiceImpl struct {
+	sitemapDao    dao.SitemapDao
+	redisService  service.RedisService
+	websiteURL    string
+	sitemapConfig config.SitemapConfig
+	robotsConfig  config.RobotsConfig
+}
+
+// NewSitemapService 创建新的 SitemapService 实例
+func NewSitemapService(sitemapDao dao.SitemapDao, redisService service.RedisService, websiteURL string, sitemapConfig config.SitemapConfig, robotsConfig config.RobotsConfig) service.SitemapService {
+	if sitemapConfig.PageSize <= 0 {
+		sitemapConfig.PageSize = defaultSitemapPageSize
+	}
+	if sitemapConfig.PageSize > maxSitemapPageSize {
+		sitemapConfig.PageSize = maxSitemapPageSize
+	}
+	return &sitemapServiceImpl{
+		sitemapDao:    sitemapDao,
+		redisService:  redisService,
+		websiteURL:    strings.TrimRight(websiteURL, "/"),
+		sitemapConfig: sitemapConfig,
+		robotsConfig:  robotsConfig,
+	}
+}
+
+// GetSitemap 地址较少时直接输出站点地图，否则输出指向各个子站点地图的索引
+func (s *sitemapServiceImpl) GetSitemap(ctx context.Context) (*dto.SitemapDTO, error) {
+	return s.cachedSitemap(ctx, constants.SitemapCache+"index", func(urls []utils.SitemapURL) ([]byte, time.Time, bool, error) {
+		if len(urls) <= s.sitemapConfig.PageSize {
+			content, err := utils.BuildSitemap(urls)
+			return content, latestLastMod(urls), true, err
+		}
+
+		var sitemaps []utils.SitemapURL
+		for page := 1; (page-1)*s.sitemapConfig.PageSize < len(urls); page++ {
+			sitemaps = append(sitemaps, utils.SitemapURL{
+				Loc:     s.websiteURL + "/sitemaps/" + strconv.Itoa(page) + ".xml",
+				LastMod: latestLastMod(s.pageURLs(urls, page)),
+			})
+		}
+		content, err := utils.BuildSitemapIndex(sitemaps)
+		return content, latestLastMod(urls), true, err
+	})
+}
+
+// GetSitemapPage 生成索引中的子站点地图
+func (s *sitemapServiceImpl) GetSitemapPage(ctx context.Context, page int) (*dto.SitemapDTO, error) {
+	if page < 1 {
+		return nil, nil
+	}
+	return s.cachedSitemap(ctx, constants.SitemapCache+strconv.Itoa(page), func(urls []utils.SitemapURL) ([]byte, time.Time, bool, error) {
+		pageURLs := s.pageURLs(urls, page)
+		if len(pageURLs) == 0 {
+			return nil, time.Time{}, false, nil
+		}
+		content, err := utils.BuildSitemap(pageURLs)
+		return content, latestLastMod(pageURLs), true, err
+	})
+}
+
+// GetRobots 按配置生成 robots.txt 并指向站点地图
+func (s *sitemapServiceImpl) GetRobots() string {
+	rules := s.robotsConfig.Rules
+	if len(rules) == 0 {
+		rules = []config.RobotsRule{{UserAgent: "*"}}
+	}
+
+	var builder strings.Builder
+	for _, rule := range rules {
+		userAgent := rule.UserAgent
+		if userAgent == "" {
+			userAgent = "*"
+		}
+		builder.WriteString("User-agent: " + userAgent + "\n")
+		for _, path := range rule.Allow {
+			builder.WriteString("Allow: " + path + "\n")
+		}
+		for _, path := range rule.Disallow {
+			builder.WriteString("Disallow: " + path + "\n")
+		}
+		// 没有任何规则时表示允许抓取全部内容
+		if len(rule.Allow) == 0 && len(rule.Disallow) == 0 {
+			builder.WriteString("Disallow:\n")
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString("Sitemap: " + s.websiteURL + "/sitemap.xml\n")
+	return builder.String()
+}
+
+// cachedSitemap 优先读取缓存，缓存失效后查询全部地址并交给 build 生成内容，build 返回 false 表示不存在
+func (s *sitemapServiceImpl) cachedSitemap(ctx context.Context, cacheKey string, build func(urls []utils.SitemapURL) ([]byte, time.Time, bool, error)) (*dto.SitemapDTO, error) {
+	ttl := defaultSitemapCacheTTL
+	if s.sitemapConfig.CacheTTL > 0 {
+		ttl = time.Duration(s.sitemapConfig.CacheTTL) * time.Second
+	}
+	content, err := getCachedContent(ctx, s.redisService, cacheKey, ttl, func() ([]byte, time.Time, bool, error) {
+		urls, err := s.listURLs(ctx)
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+		return build(urls)
+	})
+	if err != nil || content == nil {
+		return nil, err
+	}
+	return &dto.SitemapDTO{CachedContentDTO: *content}, nil
+}
+
+// listURLs 按页面、文章、分类、标签、说说、相册的顺序收集所有公开地址
+func (s *sitemapServiceImpl) listURLs(ctx context.Context) ([]utils.SitemapURL, error) {
+	var urls []utils.SitemapURL
+
+	pages, err := s.sitemapDao.ListPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sitemap pages: %w", err)
+	}
+	excluded := make(map[string]bool, len(s.sitemapConfig.ExcludePages))
+	for _, label := range s.sitemapConfig.ExcludePages {
+		excluded[label] = true
+	}
+	for _, page := range pages {
+		if page.Label == "" || excluded[page.Label] {
+			continue
+		}
+		path, ok := sitemapPagePaths[page.Label]
+		if !ok {
+			path = "/" + url.PathEscape(page.Label)
+		}
+		urls = append(urls, utils.SitemapURL{Loc: s.websiteURL + path, LastMod: page.LastMod()})
+	}
+
+	sections := []struct {
+		name string
+		path string
+		list func(ctx context.Context) ([]dto.SitemapEntryDTO, error)
+	}{
+		{"articles", "/articles/", s.sitemapDao.ListArticles},
+		{"categories", "/categories/", s.sitemapDao.ListCategories},
+		{"tags", "/tags/", s.sitemapDao.ListTags},
+		{"talks", "/talks/", s.sitemapDao.ListTalks},
+		{"albums", "/albums/", s.sitemapDao.ListPhotoAlbums},
+	}
+	for _, section := range sections {
+		entries, err := section.list(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sitemap %s: %w", section.name, err)
+		}
+		for _, entry := range entries {
+			urls = append(urls, utils.SitemapURL{
+				Loc:     s.websiteURL + section.path + strconv.Itoa(entry.ID),
+				LastMod: entry.LastMod(),
+			})
+		}
+	}
+	return urls, nil
+}
+
+// pageURLs 截取第 page 个子站点地图包含的地址
+func (s *sitemapServiceImpl) pageURLs(urls []utils.SitemapURL, page int) []utils.SitemapURL {
+	start := (page - 1) * s.sitemapConfig.PageSize
+	if start >= len(urls) {
+		return nil
+	}
+	end := start + s.sitemapConfig.PageSize
+	if end > len(urls) {
+		end = len(urls)
+	}
+	return urls[start:end]
+}
+
+// latestLastMod 返回地址中最晚的修改时间
+func latestLastMod(urls []utils.SitemapURL) time.Time {
+	var latest time.Time
+	for _, u := range urls {
+		if u.LastMod.After(latest) {
+			latest = u.LastMod
+		}
+	}
+	return latest
+}
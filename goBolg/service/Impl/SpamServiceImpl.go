@@ -0,0 +1,278 @@
+package Impl
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"goBolg/config"
+	constants "goBolg/constant"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/exception"
+	"goBolg/service"
+	"goBolg/utils"
+	"log"
+	"math"
+	"strconv"
+	"time"
+)
+
+// 垃圾内容评分中各信号的分值
+const (
+	spamLinkScore       = 15  // 超出数量的每个链接
+	spamLinkMaxScore    = 45  // 链接得分上限
+	spamRepeatScore     = 20  // 时间窗口内每次重复提交
+	spamRepeatMaxScore  = 40  // 重复内容得分上限
+	spamRateScore       = 10  // 超出频率限制的每次提交
+	spamRateMaxScore    = 30  // 发布频率得分上限
+	spamNewAccountScore = 15  // 新注册账号
+	spamBayesMaxScore   = 40  // 分类器加分或减分的上限
+	spamMaxScore        = 100 // 评分上限
+)
+
+// 分类器的样本分类
+const (
+	spamLabel = "spam" // 垃圾内容
+	hamLabel  = "ham"  // 正常内容
+)
+
+// trainSampleScript 训练分类器样本，内容已按相同分类训练过时返回 0，改判时先撤销另一分类的计数，
+// KEYS 依次为已训练样本、本次分类的词频、另一分类的词频和样本数量，ARGV 依次为样本标识、本次分类和全部词
+var trainSampleScript = redis.NewScript(`
+local previous = redis.call('HGET', KEYS[1], ARGV[1])
+if previous == ARGV[2] then
+	return 0
+end
+for i = 3, #ARGV do
+	if previous then
+		redis.call('HINCRBY', KEYS[3], ARGV[i], -1)
+	end
+	redis.call('HINCRBY', KEYS[2], ARGV[i], 1)
+end
+if previous then
+	redis.call('HINCRBY', KEYS[4], previous, -1)
+end
+redis.call('HINCRBY', KEYS[4], ARGV[2], 1)
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+
+// spamServiceImpl 实现 SpamService 接口
+type spamServiceImpl struct {
+	redisService service.RedisService
+	userInfoDao  dao.UserInfoDao
+	spamConfig   config.SpamConfig
+}
+
+// NewSpamService 创建新的 SpamService 实例
+func NewSpamService(redisService service.RedisService, userInfoDao dao.UserInfoDao, spamConfig config.SpamConfig) service.SpamService {
+	return &spamServiceImpl{
+		redisService: redisService,
+		userInfoDao:  userInfoDao,
+		spamConfig:   spamConfig,
+	}
+}
+
+// ScoreContent 累加各信号的得分，单个信号出错时只记录日志，不影响内容发布
+func (s *spamServiceImpl) ScoreContent(ctx context.Context, check dto.SpamCheckDTO) dto.SpamScoreDTO {
+	result := dto.SpamScoreDTO{Signals: []string{}}
+	score := 0
+	addSignal := func(name string, value int) {
+		if value != 0 {
+			score += value
+			result.Signals = append(result.Signals, fmt.Sprintf("%s:%+d", name, value))
+		}
+	}
+	addSignal("links", s.linkScore(check.Content))
+	addSignal("repeat", s.repeatScore(ctx, check.Content))
+	addSignal("rate", s.rateScore(ctx, check))
+	addSignal("newAccount", s.newAccountScore(ctx, check.UserID))
+	addSignal("bayes", s.bayesScore(ctx, check.Content))
+
+	result.Score = min(max(score, 0), spamMaxScore)
+	result.Rejected = s.spamConfig.RejectScore > 0 && result.Score >= s.spamConfig.RejectScore
+	result.NeedReview = !result.Rejected && s.spamConfig.ReviewScore > 0 && result.Score >= s.spamConfig.ReviewScore
+	return result
+}
+
+// RecordPost 内容发布后计入用户和 IP 的发布频率，被拒绝的内容不计入
+func (s *spamServiceImpl) RecordPost(ctx context.Context, check dto.SpamCheckDTO) {
+	if s.spamConfig.RateLimit <= 0 {
+		return
+	}
+	for _, key := range s.rateKeys(check) {
+		if _, err := s.countInWindow(ctx, key, s.spamConfig.RateWindow); err != nil {
+			log.Printf("Error recording posting rate: %v", err)
+		}
+	}
+}
+
+// TrainSample 按分类累加内容中每个词的出现次数，同一内容再次以相同分类训练时忽略，整个过程在脚本中原子执行
+func (s *spamServiceImpl) TrainSample(ctx context.Context, sampleKey string, content string, isSpam bool) error {
+	label, other := hamLabel, spamLabel
+	if isSpam {
+		label, other = spamLabel, hamLabel
+	}
+	keys := []string{constants.SpamBayesTrained, constants.SpamBayesToken + label, constants.SpamBayesToken + other, constants.SpamBayesSampleCount}
+	args := []interface{}{sampleKey, label}
+	for _, token := range utils.SpamTokens(content) {
+		args = append(args, token)
+	}
+	_, err := s.redisService.RunScript(ctx, trainSampleScript, keys, args...)
+	return err
+}
+
+// linkScore 链接数量超过配置值后，每多一个链接加分
+func (s *spamServiceImpl) linkScore(content string) int {
+	excess := utils.CountLinks(content) - s.spamConfig.MaxLinks
+	if excess <= 0 {
+		return 0
+	}
+	return min(excess*spamLinkScore, spamLinkMaxScore)
+}
+
+// repeatScore 时间窗口内每出现一次相同的内容加分
+func (s *spamServiceImpl) repeatScore(ctx context.Context, content string) int {
+	digest := utils.ContentDigest(content)
+	if digest == "" {
+		return 0
+	}
+	count, err := s.countInWindow(ctx, constants.SpamContentDigest+digest, s.spamConfig.RepeatWindow)
+	if err != nil {
+		log.Printf("Error counting repeated content: %v", err)
+		return 0
+	}
+	return min(int(count-1)*spamRepeatScore, spamRepeatMaxScore)
+}
+
+// rateScore 同一用户或同一 IP 在时间窗口内已发布的次数加上本次超过限制后，每多发布一次加分
+func (s *spamServiceImpl) rateScore(ctx context.Context, check dto.SpamCheckDTO) int {
+	if s.spamConfig.RateLimit <= 0 {
+		return 0
+	}
+
+	var peak int64
+	for _, key := range s.rateKeys(check) {
+		value, err := s.redisService.Get(ctx, key)
+		if err != nil && err != redis.Nil {
+			log.Printf("Error getting posting rate: %v", err)
+			continue
+		}
+		count, _ := strconv.ParseInt(value, 10, 64)
+		peak = max(peak, count+1)
+	}
+	excess := int(peak) - s.spamConfig.RateLimit
+	if excess <= 0 {
+		return 0
+	}
+	return min(excess*spamRateScore, spamRateMaxScore)
+}
+
+// rateKeys 发布频率的计数键，游客只按 IP 计数
+func (s *spamServiceImpl) rateKeys(check dto.SpamCheckDTO) []string {
+	var keys []string
+	if check.UserID > 0 {
+		keys = append(keys, constants.SpamUserRate+strconv.Itoa(check.UserID))
+	}
+	if check.IPAddress != "" && check.IPAddress != "Unknown IP" {
+		keys = append(keys, constants.SpamIPRate+check.IPAddress)
+	}
+	return keys
+}
+
+// newAccountScore 注册时间较短的账号加分，游客不计分
+func (s *spamServiceImpl) newAccountScore(ctx context.Context, userId int) int {
+	if userId <= 0 || s.spamConfig.NewAccountHours <= 0 {
+		return 0
+	}
+	userInfo, err := s.userInfoDao.GetUserInfoById(ctx, userId)
+	if err != nil || userInfo == nil {
+		log.Printf("Error getting user info %d for spam check: %v", userId, err)
+		return 0
+	}
+	if time.Since(userInfo.CreateTime) < time.Duration(s.spamConfig.NewAccountHours)*time.Hour {
+		return spamNewAccountScore
+	}
+	return 0
+}
+
+// bayesScore 两类样本都足够时，按分类器给出的垃圾概率加分或减分
+func (s *spamServiceImpl) bayesScore(ctx context.Context, content string) int {
+	tokens := utils.SpamTokens(content)
+	if len(tokens) == 0 {
+		return 0
+	}
+	sampleCounts, err := s.redisService.HGetAll(ctx, constants.SpamBayesSampleCount)
+	if err != nil {
+		log.Printf("Error getting spam sample count: %v", err)
+		return 0
+	}
+	spamSamples, _ := strconv.Atoi(sampleCounts[spamLabel])
+	hamSamples, _ := strconv.Atoi(sampleCounts[hamLabel])
+	minSamples := max(s.spamConfig.BayesMinSamples, 1)
+	if spamSamples < minSamples || hamSamples < minSamples {
+		return 0
+	}
+
+	spamCounts, err := s.tokenCounts(ctx, spamLabel, tokens)
+	if err != nil {
+		log.Printf("Error getting spam token count: %v", err)
+		return 0
+	}
+	hamCounts, err := s.tokenCounts(ctx, hamLabel, tokens)
+	if err != nil {
+		log.Printf("Error getting ham token count: %v", err)
+		return 0
+	}
+	probability := utils.BayesSpamProbability(spamCounts, hamCounts, spamSamples, hamSamples)
+	return int(math.Round((probability - 0.5) * 2 * spamBayesMaxScore))
+}
+
+// tokenCounts 查询每个词在指定分类的样本中出现的次数
+func (s *spamServiceImpl) tokenCounts(ctx context.Context, label string, tokens []string) ([]int, error) {
+	values, err := s.redisService.HMGet(ctx, constants.SpamBayesToken+label, tokens...)
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]int, len(tokens))
+	for i, value := range values {
+		if text, ok := value.(string); ok {
+			count, _ := strconv.Atoi(text)
+			counts[i] = max(count, 0)
+		}
+	}
+	return counts, nil
+}
+
+// countInWindow 计数加一并返回时间窗口内的次数，窗口从第一次计数开始
+func (s *spamServiceImpl) countInWindow(ctx context.Context, key string, window int) (int64, error) {
+	count, err := s.redisService.Incr(ctx, key, 1)
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 && window > 0 {
+		if _, err := s.redisService.Expire(ctx, key, time.Duration(window)*time.Second); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// checkSpamContent 计算用户提交内容的垃圾评分，评分达到拒绝分数时返回错误，否则计入发布频率，返回评分和是否需要审核
+func checkSpamContent(ctx context.Context, spamService service.SpamService, check dto.SpamCheckDTO) (int, bool, error) {
+	result := spamService.ScoreContent(ctx, check)
+	if len(result.Signals) > 0 {
+		log.Printf("Spam score %d for user %d ip %s: %v", result.Score, check.UserID, check.IPAddress, result.Signals)
+	}
+	if result.Rejected {
+		return result.Score, false, exception.NewBizError(enums.VALID_ERROR.Code, "内容疑似垃圾信息，请修改后重新提交")
+	}
+	spamService.RecordPost(ctx, check)
+	return result.Score, result.NeedReview, nil
+}
+
+// spamSampleKey 生成分类器样本的唯一标识
+func spamSampleKey(kind string, id int) string {
+	return kind + ":" + strconv.Itoa(id)
+}
@@ -0,0 +1,93 @@
+package Impl
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"goBolg/config"
+	constants "goBolg/constant"
+	"goBolg/dto"
+	"goBolg/exception"
+	"goBolg/service"
+	"testing"
+)
+
+// newTestSpamService 使用 miniredis 创建 SpamService
+func newTestSpamService(t *testing.T, spamConfig config.SpamConfig) (service.SpamService, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewSpamService(NewRedisServiceImpl(client), nil, spamConfig), server
+}
+
+func TestTrainSample(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		labels     []bool
+		wantSpam   string
+		wantHam    string
+		wantLabel  string
+		wantTokens string
+	}{
+		{"训练为垃圾内容", []bool{true}, "1", "", "spam", "1"},
+		{"重复训练忽略", []bool{true, true}, "1", "", "spam", "1"},
+		{"改判撤销之前的训练", []bool{true, false}, "0", "1", "ham", "0"},
+		{"改判后再改回", []bool{true, false, true}, "1", "0", "spam", "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, server := newTestSpamService(t, config.SpamConfig{})
+			for _, isSpam := range tt.labels {
+				if err := s.TrainSample(ctx, "comment:1", "buy cheap watches", isSpam); err != nil {
+					t.Fatalf("TrainSample returned error: %v", err)
+				}
+			}
+			if got := server.HGet(constants.SpamBayesSampleCount, spamLabel); got != tt.wantSpam {
+				t.Errorf("spam sample count = %q, want %q", got, tt.wantSpam)
+			}
+			if got := server.HGet(constants.SpamBayesSampleCount, hamLabel); got != tt.wantHam {
+				t.Errorf("ham sample count = %q, want %q", got, tt.wantHam)
+			}
+			if got := server.HGet(constants.SpamBayesTrained, "comment:1"); got != tt.wantLabel {
+				t.Errorf("trained label = %q, want %q", got, tt.wantLabel)
+			}
+			if got := server.HGet(constants.SpamBayesToken+spamLabel, "cheap"); got != tt.wantTokens {
+				t.Errorf("spam token count = %q, want %q", got, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestCheckSpamContentRate(t *testing.T) {
+	ctx := context.Background()
+	s, server := newTestSpamService(t, config.SpamConfig{RateLimit: 1, RateWindow: 60, RejectScore: 10})
+	check := dto.SpamCheckDTO{Content: "hello", UserID: 3, IPAddress: "10.0.0.1"}
+	userKey := constants.SpamUserRate + "3"
+
+	// 第一次发布不超过限制
+	if _, _, err := checkSpamContent(ctx, s, check); err != nil {
+		t.Fatalf("first post rejected: %v", err)
+	}
+	if got, _ := server.Get(userKey); got != "1" {
+		t.Fatalf("user rate after first post = %q, want 1", got)
+	}
+
+	// 超过限制的发布被拒绝，不计入发布频率
+	for i := 0; i < 3; i++ {
+		check.Content = "different content " + string(rune('a'+i))
+		_, _, err := checkSpamContent(ctx, s, check)
+		var bizErr *exception.BizError
+		if !errors.As(err, &bizErr) {
+			t.Fatalf("post %d error = %v, want BizError", i, err)
+		}
+	}
+	if got, _ := server.Get(userKey); got != "1" {
+		t.Errorf("user rate after rejected posts = %q, want 1", got)
+	}
+	if got, _ := server.Get(constants.SpamIPRate + "10.0.0.1"); got != "1" {
+		t.Errorf("ip rate after rejected posts = %q, want 1", got)
+	}
+}
@@ -7,23 +7,27 @@ import (
 	"goBolg/constant"
 	"goBolg/dao"
 	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/model"
 	"goBolg/service"
 	"goBolg/utils"
 	"goBolg/vo"
 	"log"
 	"strconv"
+	"time"
 )
 
 // talkService 实现 TalkService 接口
 type talkServiceImpl struct {
-	talkDao      dao.TalkDao
-	commentDao   dao.CommentDao
-	redisService service.RedisService
+	talkDao             dao.TalkDao
+	commentDao          dao.CommentDao
+	redisService        service.RedisService
+	notificationService service.NotificationService
 }
 
 // NewTalkService 创建新的 TalkService 实例
-func NewTalkService(talkDao dao.TalkDao, commentDao dao.CommentDao, redisService service.RedisService) service.TalkService {
-	return &talkServiceImpl{talkDao: talkDao, commentDao: commentDao, redisService: redisService}
+func NewTalkService(talkDao dao.TalkDao, commentDao dao.CommentDao, redisService service.RedisService, notificationService service.NotificationService) service.TalkService {
+	return &talkServiceImpl{talkDao: talkDao, commentDao: commentDao, redisService: redisService, notificationService: notificationService}
 }
 
 // ListHomeTalks 获取首页说说
@@ -62,7 +66,7 @@ func (s *talkServiceImpl) GetTalkById(ctx context.Context, talkId int) (*dto.Tal
 // ListTalks 查询所有说说，并统计评论量和点赞量
 func (s *talkServiceImpl) ListTalks(ctx context.Context) (vo.PageResult[dto.TalkDTO], error) {
 	// 查询说说总量
-	count, err := s.talkDao.CountAll(ctx)
+	count, err := s.talkDao.CountPublicTalks(ctx)
 	if err != nil {
 		return vo.PageResult[dto.TalkDTO]{}, err
 	}
@@ -155,6 +159,10 @@ func (s *talkServiceImpl) SaveTalkLike(ctx context.Context, talkId int) (int, er
 		if err != nil {
 			return 0, err
 		}
+
+		if err := s.noticeTalkLike(ctx, talkId, user.UserInfoID); err != nil {
+			log.Printf("Error sending like notification for talk %d: %v", talkId, err)
+		}
 	}
 
 	likeCountStr, err := s.redisService.HGet(ctx, constants.TalkLikeCount, talkIdStr)
@@ -164,3 +172,86 @@ func (s *talkServiceImpl) SaveTalkLike(ctx context.Context, talkId int) (int, er
 	likeCount, _ := strconv.Atoi(likeCountStr) // 这里忽略转换错误，因为前面应该已经处理过了
 	return likeCount, nil
 }
+
+// noticeTalkLike 通知说说作者说说被点赞
+func (s *talkServiceImpl) noticeTalkLike(ctx context.Context, talkId int, userId int) error {
+	talk, err := s.talkDao.GetTalkById(ctx, talkId)
+	if err != nil {
+		return fmt.Errorf("failed to get talk by ID: %w", err)
+	}
+	if talk == nil {
+		return nil
+	}
+	return s.notificationService.Notify(ctx, dto.NotificationEventDTO{
+		UserID:     talk.UserID,
+		FromUserID: userId,
+		Type:       enums.TALK_LIKE.Type,
+		TargetID:   talkId,
+		Content:    talk.Content,
+		Path:       enums.TALK.Path + strconv.Itoa(talkId),
+	})
+}
+
+// ListBackTalks 查看后台说说
+func (s *talkServiceImpl) ListBackTalks(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.TalkBackDTO], error) {
+	count, err := s.talkDao.CountTalks(ctx, condition.Status)
+	if err != nil {
+		return vo.PageResult[dto.TalkBackDTO]{}, err
+	}
+	if count == 0 {
+		return vo.PageResult[dto.TalkBackDTO]{}, nil
+	}
+
+	talkBackDTOList, err := s.talkDao.ListBackTalks(ctx, utils.GetLimitCurrent(ctx), utils.GetSize(ctx), condition)
+	if err != nil {
+		return vo.PageResult[dto.TalkBackDTO]{}, err
+	}
+	for i := range talkBackDTOList {
+		if talkBackDTOList[i].Images != "" {
+			imgList, err := utils.CastList(talkBackDTOList[i].Images, utils.StringConstructor)
+			if err == nil {
+				talkBackDTOList[i].ImgList = imgList
+			}
+		}
+	}
+
+	return vo.PageResult[dto.TalkBackDTO]{RecordList: talkBackDTOList, Count: int(count)}, nil
+}
+
+// SaveOrUpdateTalk 保存或修改说说，发布时间在未来时先保存为草稿
+func (s *talkServiceImpl) SaveOrUpdateTalk(ctx context.Context, talkVO vo.TalkVO) error {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return fmt.Errorf("用户未登录")
+	}
+
+	talk := &model.Talk{
+		ID:          talkVO.ID,
+		UserID:      user.UserInfoID,
+		Content:     talkVO.Content,
+		Images:      talkVO.Images,
+		IsTop:       talkVO.IsTop,
+		Status:      talkVO.Status,
+		PublishTime: talkVO.PublishTime,
+	}
+	if talk.PublishTime != nil {
+		if talk.PublishTime.After(time.Now()) {
+			talk.Status = int(enums.Draft)
+		} else {
+			talk.PublishTime = nil
+		}
+	}
+	return s.talkDao.SaveOrUpdateTalk(ctx, talk)
+}
+
+// CancelTalkSchedule 取消说说的定时发布，说说保留为草稿
+func (s *talkServiceImpl) CancelTalkSchedule(ctx context.Context, talkId int) error {
+	rows, err := s.talkDao.CancelTalkSchedule(ctx, talkId, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to cancel talk schedule: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("说说没有待发布的定时任务")
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package Impl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	constants "goBolg/constant"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/exception"
+	"goBolg/service"
+	"goBolg/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRefreshTokenDuration 未配置有效期时刷新令牌的过期时间
+const defaultRefreshTokenDuration = time.Hour * 24 * 7
+
+// rotateRefreshTokenScript 轮换刷新令牌，会话不存在时返回 -1，摘要与会话中保存的不一致时返回 0，
+// 一致时替换为新令牌的摘要、重新计算会话有效期并返回 1
+var rotateRefreshTokenScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'tokenHash')
+if not current then
+	return -1
+end
+if current ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'tokenHash', ARGV[2])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
+// tokenServiceImpl 实现 TokenService 接口，登录会话保存在 Redis 中，只保存刷新令牌的摘要
+type tokenServiceImpl struct {
+	redisService    service.RedisService
+	userAuthDao     dao.UserAuthDao
+	refreshDuration time.Duration
+}
+
+// NewTokenService 创建新的 TokenService 实例
+func NewTokenService(redisService service.RedisService, userAuthDao dao.UserAuthDao, refreshDuration time.Duration) service.TokenService {
+	if refreshDuration <= 0 {
+		refreshDuration = defaultRefreshTokenDuration
+	}
+	return &tokenServiceImpl{
+		redisService:    redisService,
+		userAuthDao:     userAuthDao,
+		refreshDuration: refreshDuration,
+	}
+}
+
+// CreateToken 创建登录会话，签发访问令牌和刷新令牌
+func (s *tokenServiceImpl) CreateToken(ctx context.Context, userId int, username string) (dto.TokenDTO, error) {
+	sessionId, err := utils.NewTokenId()
+	if err != nil {
+		return dto.TokenDTO{}, err
+	}
+	secret, err := utils.NewTokenId()
+	if err != nil {
+		return dto.TokenDTO{}, err
+	}
+
+	sessionKey := constants.TokenSession + sessionId
+	if _, err := s.redisService.HSet(ctx, sessionKey, "userId", userId, "username", username, "tokenHash", hashRefreshToken(secret)); err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("failed to save session: %w", err)
+	}
+	if _, err := s.redisService.Expire(ctx, sessionKey, s.refreshDuration); err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("failed to set session expiration: %w", err)
+	}
+	userSessionsKey := constants.TokenUserSessions + strconv.Itoa(userId)
+	if _, err := s.redisService.SAdd(ctx, userSessionsKey, sessionId); err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("failed to save user session: %w", err)
+	}
+	if _, err := s.redisService.Expire(ctx, userSessionsKey, s.refreshDuration); err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("failed to set user sessions expiration: %w", err)
+	}
+	return s.generateToken(userId, username, sessionId, secret)
+}
+
+// RefreshToken 使用刷新令牌签发新的访问令牌并轮换刷新令牌，已使用过的刷新令牌可能已被盗用，再次出现时注销整个会话
+func (s *tokenServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (dto.TokenDTO, error) {
+	sessionId, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionId == "" || secret == "" {
+		return dto.TokenDTO{}, exception.NewBizError(enums.NO_LOGIN.Code, "登录已过期，请重新登录")
+	}
+	newSecret, err := utils.NewTokenId()
+	if err != nil {
+		return dto.TokenDTO{}, err
+	}
+
+	sessionKey := constants.TokenSession + sessionId
+	result, err := s.redisService.RunScript(ctx, rotateRefreshTokenScript, []string{sessionKey}, hashRefreshToken(secret), hashRefreshToken(newSecret), s.refreshDuration.Milliseconds())
+	if err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	status, _ := result.(int64)
+	if status < 0 {
+		return dto.TokenDTO{}, exception.NewBizError(enums.NO_LOGIN.Code, "登录已过期，请重新登录")
+	}
+
+	values, err := s.redisService.HMGet(ctx, sessionKey, "userId", "username")
+	if err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("failed to get session: %w", err)
+	}
+	userIdValue, _ := values[0].(string)
+	username, _ := values[1].(string)
+	userId, err := strconv.Atoi(userIdValue)
+	if err != nil {
+		return dto.TokenDTO{}, exception.NewBizError(enums.NO_LOGIN.Code, "登录已过期，请重新登录")
+	}
+	if status == 0 {
+		if err := s.RemoveSession(ctx, userId, sessionId); err != nil {
+			return dto.TokenDTO{}, err
+		}
+		return dto.TokenDTO{}, exception.NewBizError(enums.NO_LOGIN.Code, "登录状态异常，请重新登录")
+	}
+
+	if _, err := s.redisService.Expire(ctx, constants.TokenUserSessions+userIdValue, s.refreshDuration); err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("failed to set user sessions expiration: %w", err)
+	}
+	return s.generateToken(userId, username, sessionId, newSecret)
+}
+
+// IsRevoked 判断登录会话是否已注销
+func (s *tokenServiceImpl) IsRevoked(ctx context.Context, sessionId string) (bool, error) {
+	_, err := s.redisService.Get(ctx, constants.TokenRevokedSession+sessionId)
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get revoked session: %w", err)
+	}
+	return true, nil
+}
+
+// RemoveSession 删除登录会话使刷新令牌失效，并将会话加入注销列表使已签发的访问令牌失效，
+// 注销记录保留到访问令牌过期
+func (s *tokenServiceImpl) RemoveSession(ctx context.Context, userId int, sessionId string) error {
+	if err := s.revokeSession(ctx, sessionId); err != nil {
+		return err
+	}
+	if _, err := s.redisService.SRemove(ctx, constants.TokenUserSessions+strconv.Itoa(userId), sessionId); err != nil {
+		return fmt.Errorf("failed to remove user session: %w", err)
+	}
+	return nil
+}
+
+// RemoveSessionByRefreshToken 校验刷新令牌后注销其所属的登录会话，会话不存在或令牌不匹配时视为已登出
+func (s *tokenServiceImpl) RemoveSessionByRefreshToken(ctx context.Context, refreshToken string) error {
+	sessionId, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionId == "" || secret == "" {
+		return nil
+	}
+	values, err := s.redisService.HMGet(ctx, constants.TokenSession+sessionId, "userId", "tokenHash")
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	userIdValue, _ := values[0].(string)
+	tokenHash, _ := values[1].(string)
+	userId, err := strconv.Atoi(userIdValue)
+	if err != nil || tokenHash != hashRefreshToken(secret) {
+		return nil
+	}
+	return s.RemoveSession(ctx, userId, sessionId)
+}
+
+// RemoveUserSessions 注销用户的全部登录会话
+func (s *tokenServiceImpl) RemoveUserSessions(ctx context.Context, userId int) error {
+	userSessionsKey := constants.TokenUserSessions + strconv.Itoa(userId)
+	sessionIds, err := s.redisService.SMembers(ctx, userSessionsKey)
+	if err != nil {
+		return fmt.Errorf("failed to list user sessions: %w", err)
+	}
+	for _, sessionId := range sessionIds {
+		if err := s.revokeSession(ctx, sessionId); err != nil {
+			return err
+		}
+	}
+	if _, err := s.redisService.Del(ctx, userSessionsKey); err != nil {
+		return fmt.Errorf("failed to remove user sessions: %w", err)
+	}
+	return nil
+}
+
+// RemoveUserInfoSessions 注销用户信息下全部账号的登录会话，已签发的 token 立即失效，在线记录随会话一起删除
+func (s *tokenServiceImpl) RemoveUserInfoSessions(ctx context.Context, userInfoId int) error {
+	userAuthIdList, err := s.userAuthDao.ListUserAuthIdsByUserInfoId(ctx, userInfoId)
+	if err != nil {
+		return fmt.Errorf("failed to list user auth ids: %w", err)
+	}
+	for _, userAuthId := range userAuthIdList {
+		if err := s.RemoveUserSessions(ctx, userAuthId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeSession 删除会话及其在线记录，并记录到注销列表
+func (s *tokenServiceImpl) revokeSession(ctx context.Context, sessionId string) error {
+	if err := s.redisService.Set(ctx, constants.TokenRevokedSession+sessionId, constants.True, utils.JWTTokenDuration()); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if _, err := s.redisService.Del(ctx, constants.TokenSession+sessionId, constants.OnlineUser+sessionId); err != nil {
+		return fmt.Errorf("failed to remove session: %w", err)
+	}
+	return nil
+}
+
+// generateToken 签发访问令牌，刷新令牌由会话 id 和随机密钥组成
+func (s *tokenServiceImpl) generateToken(userId int, username string, sessionId string, secret string) (dto.TokenDTO, error) {
+	token, err := utils.GenerateJWT(userId, username, sessionId)
+	if err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+	return dto.TokenDTO{
+		Token:        token,
+		RefreshToken: sessionId + "." + secret,
+		ExpiresIn:    int(utils.JWTTokenDuration().Seconds()),
+	}, nil
+}
+
+// hashRefreshToken 计算刷新令牌密钥的摘要，Redis 中不保存明文
+func hashRefreshToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
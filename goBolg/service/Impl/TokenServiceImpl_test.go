@@ -0,0 +1,203 @@
+package Impl
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	constants "goBolg/constant"
+	"goBolg/exception"
+	"goBolg/service"
+	"goBolg/utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestTokenService 使用 miniredis 创建 TokenService
+func newTestTokenService(t *testing.T) (service.TokenService, *miniredis.Miniredis) {
+	t.Helper()
+	err := utils.InitJWT(utils.JWTOptions{
+		Keys: []utils.JWTKey{{ID: "test", Algorithm: utils.JWTAlgorithmHS256, Secret: strings.Repeat("s", 32)}},
+	})
+	if err != nil {
+		t.Fatalf("InitJWT returned error: %v", err)
+	}
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewTokenService(NewRedisServiceImpl(client), nil, time.Hour), server
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		refresh func(t *testing.T, s service.TokenService, refreshToken string) (string, error)
+		wantErr bool
+		// 会话在刷新后是否仍然有效
+		wantSession bool
+	}{
+		{
+			name: "使用当前刷新令牌轮换",
+			refresh: func(t *testing.T, s service.TokenService, refreshToken string) (string, error) {
+				token, err := s.RefreshToken(ctx, refreshToken)
+				return token.RefreshToken, err
+			},
+			wantSession: true,
+		},
+		{
+			name: "连续轮换",
+			refresh: func(t *testing.T, s service.TokenService, refreshToken string) (string, error) {
+				token, err := s.RefreshToken(ctx, refreshToken)
+				if err != nil {
+					return "", err
+				}
+				token, err = s.RefreshToken(ctx, token.RefreshToken)
+				return token.RefreshToken, err
+			},
+			wantSession: true,
+		},
+		{
+			name: "重复使用旧刷新令牌注销会话",
+			refresh: func(t *testing.T, s service.TokenService, refreshToken string) (string, error) {
+				if _, err := s.RefreshToken(ctx, refreshToken); err != nil {
+					t.Fatalf("first refresh returned error: %v", err)
+				}
+				return "", errorOnly(s.RefreshToken(ctx, refreshToken))
+			},
+			wantErr: true,
+		},
+		{
+			name: "伪造的密钥",
+			refresh: func(t *testing.T, s service.TokenService, refreshToken string) (string, error) {
+				sessionId, _, _ := strings.Cut(refreshToken, ".")
+				return "", errorOnly(s.RefreshToken(ctx, sessionId+".forged"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, server := newTestTokenService(t)
+			token, err := s.CreateToken(ctx, 7, "admin")
+			if err != nil {
+				t.Fatalf("CreateToken returned error: %v", err)
+			}
+			sessionId, _, _ := strings.Cut(token.RefreshToken, ".")
+
+			newRefreshToken, err := tt.refresh(t, s, token.RefreshToken)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("refresh error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				var bizErr *exception.BizError
+				if !errors.As(err, &bizErr) {
+					t.Errorf("refresh error = %v, want BizError", err)
+				}
+			} else if !strings.HasPrefix(newRefreshToken, sessionId+".") || newRefreshToken == token.RefreshToken {
+				t.Errorf("rotated refresh token = %q, want a new secret for session %s", newRefreshToken, sessionId)
+			}
+
+			if got := server.Exists(constants.TokenSession + sessionId); got != tt.wantSession {
+				t.Errorf("session exists = %v, want %v", got, tt.wantSession)
+			}
+			revoked, err := s.IsRevoked(ctx, sessionId)
+			if err != nil {
+				t.Fatalf("IsRevoked returned error: %v", err)
+			}
+			if revoked == tt.wantSession {
+				t.Errorf("IsRevoked = %v, want %v", revoked, !tt.wantSession)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenInvalid(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestTokenService(t)
+	for _, refreshToken := range []string{"", "nodot", ".secret", "session.", "missing.secret"} {
+		t.Run(refreshToken, func(t *testing.T) {
+			_, err := s.RefreshToken(ctx, refreshToken)
+			var bizErr *exception.BizError
+			if !errors.As(err, &bizErr) {
+				t.Errorf("RefreshToken(%q) error = %v, want BizError", refreshToken, err)
+			}
+		})
+	}
+}
+
+func TestRemoveUserSessions(t *testing.T) {
+	ctx := context.Background()
+	s, server := newTestTokenService(t)
+	var sessionIds []string
+	for i := 0; i < 2; i++ {
+		token, err := s.CreateToken(ctx, 7, "admin")
+		if err != nil {
+			t.Fatalf("CreateToken returned error: %v", err)
+		}
+		sessionId, _, _ := strings.Cut(token.RefreshToken, ".")
+		sessionIds = append(sessionIds, sessionId)
+	}
+	other, err := s.CreateToken(ctx, 8, "user")
+	if err != nil {
+		t.Fatalf("CreateToken returned error: %v", err)
+	}
+
+	if err := s.RemoveUserSessions(ctx, 7); err != nil {
+		t.Fatalf("RemoveUserSessions returned error: %v", err)
+	}
+	for _, sessionId := range sessionIds {
+		if revoked, _ := s.IsRevoked(ctx, sessionId); !revoked {
+			t.Errorf("session %s is not revoked", sessionId)
+		}
+		if server.Exists(constants.TokenSession + sessionId) {
+			t.Errorf("session %s still exists", sessionId)
+		}
+	}
+	if _, err := s.RefreshToken(ctx, other.RefreshToken); err != nil {
+		t.Errorf("other user's session was removed: %v", err)
+	}
+}
+
+func TestRemoveSessionByRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		refreshToken func(refreshToken string) string
+		wantRevoked  bool
+	}{
+		{"当前刷新令牌", func(refreshToken string) string { return refreshToken }, true},
+		{"伪造的密钥", func(refreshToken string) string {
+			sessionId, _, _ := strings.Cut(refreshToken, ".")
+			return sessionId + ".forged"
+		}, false},
+		{"格式错误", func(string) string { return "nodot" }, false},
+		{"会话不存在", func(string) string { return "missing.secret" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, server := newTestTokenService(t)
+			token, err := s.CreateToken(ctx, 7, "admin")
+			if err != nil {
+				t.Fatalf("CreateToken returned error: %v", err)
+			}
+			sessionId, _, _ := strings.Cut(token.RefreshToken, ".")
+
+			if err := s.RemoveSessionByRefreshToken(ctx, tt.refreshToken(token.RefreshToken)); err != nil {
+				t.Fatalf("RemoveSessionByRefreshToken returned error: %v", err)
+			}
+			if revoked, _ := s.IsRevoked(ctx, sessionId); revoked != tt.wantRevoked {
+				t.Errorf("IsRevoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+			if got := server.Exists(constants.TokenSession + sessionId); got == tt.wantRevoked {
+				t.Errorf("session exists = %v, want %v", got, !tt.wantRevoked)
+			}
+		})
+	}
+}
+
+// errorOnly 丢弃返回值只保留错误
+func errorOnly[T any](_ T, err error) error {
+	return err
+}
@@ -30,10 +30,12 @@ type userAuthServiceImpl struct {
 	userRoleDao     dao.UserRoleDao
 	userAuthDao     dao.UserAuthDao
 	blogInfoService service.BlogInfoService
+
+	emailTemplateService service.EmailTemplateService
 }
 
 // NewUserAuthService 创建新的 UserAuthService 实例
-func NewUserAuthService(emailService rabbitService.EmailService, redisService service.RedisService, rabbitService rabbitService.RabbitService, userInfoDao dao.UserInfoDao, userRoleDao dao.UserRoleDao, userAuthDao dao.UserAuthDao, blogInfoService service.BlogInfoService) service.UserAuthService {
+func NewUserAuthService(emailService rabbitService.EmailService, redisService service.RedisService, rabbitService rabbitService.RabbitService, userInfoDao dao.UserInfoDao, userRoleDao dao.UserRoleDao, userAuthDao dao.UserAuthDao, blogInfoService service.BlogInfoService, emailTemplateService service.EmailTemplateService) service.UserAuthService {
 	return &userAuthServiceImpl{
 		emailService:    emailService,
 		redisService:    redisService,
@@ -42,6 +44,8 @@ func NewUserAuthService(emailService rabbitService.EmailService, redisService se
 		userRoleDao:     userRoleDao,
 		userAuthDao:     userAuthDao,
 		blogInfoService: blogInfoService,
+
+		emailTemplateService: emailTemplateService,
 	}
 }
 
@@ -55,19 +59,29 @@ func (s *userAuthServiceImpl) SendCode(username string) error {
 	// 生成六位随机验证码
 	code := utils.GetRandomCode()
 
-	// 创建邮件内容
-	subject := "验证码"
-	content := fmt.Sprintf("您的验证码为 %s 有效期15分钟，请不要告诉他人哦！", code)
+	// 渲染邮件内容
+	content, err := s.emailTemplateService.RenderEmail(context.Background(), dto.EmailDTO{
+		Email:    username,
+		Template: enums.CODE_EMAIL.Name,
+		Data: map[string]interface{}{
+			"code":          code,
+			"expireMinutes": constants.CodeExpireTime / 60,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to render email: %v", err)
+		return fmt.Errorf("发送邮件失败: %w", err)
+	}
 
 	// 发送邮件
-	err := s.emailService.SendEmail(username, subject, content)
+	err = s.emailService.SendContent(username, content)
 	if err != nil {
 		log.Printf("Failed to send email: %v", err)
 		return fmt.Errorf("发送邮件失败: %w", err)
 	}
 
 	// 将验证码存入 Redis，设置过期时间为15分钟
-	err = s.redisService.Set(context.Background(), constants.UserCodeKey+username, code, 15*time.Minute)
+	err = s.redisService.Set(context.Background(), constants.UserCodeKey+username, code, constants.CodeExpireTime*time.Second)
 	if err != nil {
 		log.Printf("Failed to store code in Redis: %v", err)
 		return fmt.Errorf("存储验证码失败: %w", err)
@@ -232,3 +246,34 @@ func (s *userAuthServiceImpl) ListUserAreas(ctx context.Context, conditionVO vo.
 
 	return userAreaDTOList, nil
 }
+
+// ListUserBacks 分页查询后台用户列表，并填充每个用户的角色
+func (s *userAuthServiceImpl) ListUserBacks(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.UserBackDTO], error) {
+	count, err := s.userAuthDao.CountUser(ctx, condition)
+	if err != nil {
+		return vo.PageResult[dto.UserBackDTO]{}, fmt.Errorf("failed to count users: %w", err)
+	}
+	if count == 0 {
+		return vo.NewPageResult([]dto.UserBackDTO{}, 0), nil
+	}
+
+	users, err := s.userAuthDao.ListUsers(ctx, utils.GetLimitCurrent(ctx), utils.GetSize(ctx), condition)
+	if err != nil {
+		return vo.PageResult[dto.UserBackDTO]{}, fmt.Errorf("failed to list users: %w", err)
+	}
+	userInfoIdList := make([]int, 0, len(users))
+	for _, user := range users {
+		userInfoIdList = append(userInfoIdList, user.UserInfoID)
+	}
+	userRoleMap, err := s.userRoleDao.ListUserRolesByUserIds(ctx, userInfoIdList)
+	if err != nil {
+		return vo.PageResult[dto.UserBackDTO]{}, fmt.Errorf("failed to list user roles: %w", err)
+	}
+	for i := range users {
+		users[i].RoleList = userRoleMap[users[i].UserInfoID]
+		if users[i].RoleList == nil {
+			users[i].RoleList = []dto.UserRoleDTO{}
+		}
+	}
+	return vo.NewPageResult(users, int(count)), nil
+}
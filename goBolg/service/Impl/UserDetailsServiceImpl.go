@@ -129,6 +129,7 @@ func (s *UserDetailsServiceImpl) ConvertUserDetail(ctx context.Context, user *mo
 		Avatar:         userInfo.Avatar,
 		Intro:          userInfo.Intro,
 		WebSite:        userInfo.WebSite,
+		IsDisable:      userInfo.IsDisable,
 		ArticleLikeSet: articleLikes,
 		CommentLikeSet: commentLikes,
 		TalkLikeSet:    talkLikes,
@@ -7,6 +7,7 @@ import (
 	constants "goBolg/constant"
 	"goBolg/dao"
 	"goBolg/enums"
+	"goBolg/exception"
 	"goBolg/model"
 	"goBolg/service"
 	"goBolg/strategy/contxt"
@@ -19,16 +20,22 @@ import (
 // userInfoServiceImpl 实现了 UserInfoService 接口
 type userInfoServiceImpl struct {
 	userInfoDao           dao.UserInfoDao
+	userRoleDao           dao.UserRoleDao
 	uploadStrategyContext *contxt.UploadStrategyContext
 	redisService          service.RedisService
+	sensitiveWordService  service.SensitiveWordService
+	tokenService          service.TokenService
 }
 
 // NewUserInfoService 创建一个新的 UserInfoService 实例
-func NewUserInfoService(userInfoDao dao.UserInfoDao, uploadStrategyContext *contxt.UploadStrategyContext, redisService service.RedisService) service.UserInfoService {
+func NewUserInfoService(userInfoDao dao.UserInfoDao, userRoleDao dao.UserRoleDao, uploadStrategyContext *contxt.UploadStrategyContext, redisService service.RedisService, sensitiveWordService service.SensitiveWordService, tokenService service.TokenService) service.UserInfoService {
 	return &userInfoServiceImpl{
 		userInfoDao:           userInfoDao,
+		userRoleDao:           userRoleDao,
 		uploadStrategyContext: uploadStrategyContext,
 		redisService:          redisService,
+		sensitiveWordService:  sensitiveWordService,
+		tokenService:          tokenService,
 	}
 }
 
@@ -40,11 +47,21 @@ func (s *userInfoServiceImpl) UpdateUserInfo(ctx context.Context, userInfoVO *vo
 		return errors.New("failed to get login user from context")
 	}
 
+	// 敏感词检查，资料没有审核流程，命中审核类敏感词时同样拒绝
+	nickname, err := s.checkUserInfoText(userInfoVO.Nickname)
+	if err != nil {
+		return err
+	}
+	intro, err := s.checkUserInfoText(userInfoVO.Intro)
+	if err != nil {
+		return err
+	}
+
 	// 封装用户信息
 	userInfo := &model.UserInfo{
 		ID:       user.UserInfoID, // 使用从上下文获取的用户ID
-		Nickname: userInfoVO.Nickname,
-		Intro:    userInfoVO.Intro,
+		Nickname: nickname,
+		Intro:    intro,
 		WebSite:  userInfoVO.WebSite,
 	}
 
@@ -52,6 +69,18 @@ func (s *userInfoServiceImpl) UpdateUserInfo(ctx context.Context, userInfoVO *vo
 	return s.userInfoDao.UpdateUserInfo(ctx, userInfo)
 }
 
+// checkUserInfoText 检查昵称和简介中的敏感词，返回替换后的文本
+func (s *userInfoServiceImpl) checkUserInfoText(text string) (string, error) {
+	text, needReview, err := checkSensitiveContent(s.sensitiveWordService, text)
+	if err != nil {
+		return "", err
+	}
+	if needReview {
+		return "", exception.NewBizError(enums.VALID_ERROR.Code, "内容包含违规词，请修改后重新提交")
+	}
+	return text, nil
+}
+
 func (s *userInfoServiceImpl) UpdateUserAvatar(ctx context.Context, fileHeader *multipart.FileHeader) (string, error) {
 	// 获取用户信息
 	user, ok := utils.GetLoginUser(ctx)
@@ -110,3 +139,38 @@ func (service *userInfoServiceImpl) SaveUserEmail(ctx context.Context, emailVO v
 
 	return nil
 }
+
+// UpdateUserDisable 修改用户禁用状态，禁用时注销该用户全部账号的登录会话，已签发的 token 立即失效
+func (s *userInfoServiceImpl) UpdateUserDisable(ctx context.Context, userDisableVO vo.UserDisableVO) error {
+	user, ok := utils.GetLoginUser(ctx)
+	if !ok {
+		return errors.New("failed to get login user from context")
+	}
+	if userDisableVO.IsDisable == constants.True && user.UserInfoID == userDisableVO.ID {
+		return exception.NewBizError(enums.VALID_ERROR.Code, "不能禁用当前登录的账号")
+	}
+
+	if err := s.userInfoDao.UpdateUserDisable(ctx, userDisableVO.ID, userDisableVO.IsDisable); err != nil {
+		return fmt.Errorf("failed to update user disable: %w", err)
+	}
+	if userDisableVO.IsDisable != constants.True {
+		return nil
+	}
+
+	return s.tokenService.RemoveUserInfoSessions(ctx, userDisableVO.ID)
+}
+
+// UpdateUserRole 修改用户昵称和角色，角色在下一次请求时生效
+func (s *userInfoServiceImpl) UpdateUserRole(ctx context.Context, userRoleVO vo.UserRoleVO) error {
+	userInfo := &model.UserInfo{
+		ID:       userRoleVO.UserInfoId,
+		Nickname: userRoleVO.Nickname,
+	}
+	if err := s.userInfoDao.UpdateUserInfo(ctx, userInfo); err != nil {
+		return fmt.Errorf("failed to update user nickname: %w", err)
+	}
+	if err := s.userRoleDao.UpdateUserRoles(ctx, userRoleVO.UserInfoId, userRoleVO.RoleIdList); err != nil {
+		return fmt.Errorf("failed to update user roles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/vo"
+)
+
+// NotificationService 通知服务接口
+type NotificationService interface {
+	// 按接收用户的设置发送站内通知和邮件，接收用户就是触发用户时不发送
+	Notify(ctx context.Context, event dto.NotificationEventDTO) error
+
+	// 分页查询当前用户的通知
+	ListNotifications(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.NotificationDTO], error)
+
+	// 统计当前用户的未读通知数量
+	CountUnreadNotifications(ctx context.Context) (int, error)
+
+	// 将当前用户的通知标记为已读
+	ReadNotifications(ctx context.Context, notificationIdList []int) error
+
+	// 将当前用户的全部通知标记为已读
+	ReadAllNotifications(ctx context.Context) error
+
+	// 查询当前用户的通知设置，未设置的通知类型返回默认接收方式
+	ListNotificationSettings(ctx context.Context) ([]dto.NotificationSettingDTO, error)
+
+	// 保存当前用户的通知设置
+	SaveNotificationSettings(ctx context.Context, notificationSettingVOList []vo.NotificationSettingVO) error
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/vo"
+)
+
+// OnlineUserService 在线用户服务接口
+type OnlineUserService interface {
+	// RefreshOnlineUser 记录登录会话的最近活跃信息，同一会话一分钟内只记录一次
+	RefreshOnlineUser(ctx context.Context, sessionId string, onlineUser dto.UserOnlineDTO) error
+
+	// ListOnlineUsers 分页查询在线用户，可按昵称搜索
+	ListOnlineUsers(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.UserOnlineDTO], error)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitService 接口限流服务接口
+type RateLimitService interface {
+	// 按请求方法和路由匹配限流规则并记录一次请求，被限流时返回需要等待的时间，未被限流时返回 0
+	Acquire(ctx context.Context, method string, path string, userId int, ipAddress string) (time.Duration, error)
+}
@@ -9,9 +9,11 @@ import (
 
 type RedisService interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(ctx context.Context, key string) (string, error)
 	Del(ctx context.Context, keys ...string) (int64, error)
-	//Expire(ctx contxt.Context, key string, duration time.Duration) (bool, error)
+	DelByPrefix(ctx context.Context, prefix string) (int64, error)
+	Expire(ctx context.Context, key string, duration time.Duration) (bool, error)
 	//GetExpire(ctx contxt.Context, key string) (time.Duration, error)
 	//HasKey(ctx contxt.Context, key string) (bool, error)
 	Incr(ctx context.Context, key string, delta int64) (int64, error)
@@ -19,6 +21,7 @@ type RedisService interface {
 	HSet(ctx context.Context, key string, values ...interface{}) (int64, error)
 	HGet(ctx context.Context, key, field string) (string, error)
 	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	HMGet(ctx context.Context, key string, fields ...string) ([]interface{}, error)
 	HIncr(ctx context.Context, key string, hashKey string, delta int64) (int64, error)
 	//HDel(ctx contxt.Context, key string, fields ...string) (int64, error)
 	SAdd(ctx context.Context, key string, members ...interface{}) (int64, error)
@@ -34,4 +37,7 @@ type RedisService interface {
 	ZAllScore(ctx context.Context, key string) (map[interface{}]float64, error)
 	ZIncrBy(ctx context.Context, key string, member string, increment float64) (float64, error)
 	HDecr(ctx context.Context, key string, hashKey string, delta int64) (int64, error)
+	Publish(ctx context.Context, channel string, message interface{}) (int64, error)
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+	RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error)
 }
@@ -0,0 +1,15 @@
+package service
+
+import "context"
+
+// ScheduleService 定时任务服务接口
+type ScheduleService interface {
+	// 启动定时任务
+	Start()
+
+	// 停止定时任务
+	Stop()
+
+	// 发布已到期的定时文章和说说
+	PublishDueItems(ctx context.Context) error
+}
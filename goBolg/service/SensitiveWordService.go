@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/utils"
+	"goBolg/vo"
+	"io"
+)
+
+// SensitiveWordService 敏感词服务接口
+type SensitiveWordService interface {
+	// 分页查询后台敏感词
+	ListBackSensitiveWords(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.SensitiveWordDTO], error)
+
+	// 保存或更新敏感词
+	SaveOrUpdateSensitiveWord(ctx context.Context, sensitiveWordVO vo.SensitiveWordVO) error
+
+	// 删除敏感词
+	DeleteSensitiveWords(ctx context.Context, sensitiveWordIdList []int) error
+
+	// 导入敏感词，每行一个敏感词，已存在的敏感词更新为指定的处理方式
+	ImportSensitiveWords(ctx context.Context, reader io.Reader, action int) (dto.SensitiveWordImportDTO, error)
+
+	// 检查文本中的敏感词
+	CheckText(text string) utils.SensitiveResult
+
+	// 从数据库重新加载词库
+	ReloadSensitiveWords(ctx context.Context) error
+
+	// 加载词库并监听其他实例的变更通知
+	Start()
+
+	// 停止监听
+	Stop()
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+	"goBolg/vo"
+)
+
+// SeriesService 文章系列服务接口
+type SeriesService interface {
+	// 查询所有系列及其中已发布的公开文章
+	ListSeries(ctx context.Context) ([]dto.SeriesDTO, error)
+
+	// 分页查询后台系列
+	ListBackSeries(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.SeriesBackDTO], error)
+
+	// 查询后台系列详情
+	GetBackSeriesById(ctx context.Context, seriesId int) (*dto.SeriesBackDTO, error)
+
+	// 保存或更新系列
+	SaveOrUpdateSeries(ctx context.Context, seriesVO vo.SeriesVO) error
+
+	// 删除系列，系列中的文章不受影响
+	DeleteSeries(ctx context.Context, seriesIdList []int) error
+
+	// 查询文章的系列导航，文章不属于任何系列时返回 nil
+	GetArticleSeries(ctx context.Context, articleId int) (*dto.ArticleSeriesDTO, error)
+}
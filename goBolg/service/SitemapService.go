@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+)
+
+// SitemapService 站点地图服务接口
+type SitemapService interface {
+	// 生成站点地图，地址数量超过单个站点地图上限时返回站点地图索引
+	GetSitemap(ctx context.Context) (*dto.SitemapDTO, error)
+
+	// 生成索引中第 page 个子站点地图，页码超出范围时返回 nil
+	GetSitemapPage(ctx context.Context, page int) (*dto.SitemapDTO, error)
+
+	// 生成 robots.txt
+	GetRobots() string
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+)
+
+// SpamService 垃圾内容评分服务接口
+type SpamService interface {
+	// 根据链接数量、重复内容、发布频率、账号注册时间和分类器结果计算内容的垃圾评分
+	ScoreContent(ctx context.Context, check dto.SpamCheckDTO) dto.SpamScoreDTO
+
+	// 内容通过检查后计入用户和 IP 的发布频率
+	RecordPost(ctx context.Context, check dto.SpamCheckDTO)
+
+	// 使用管理员的审核结果训练分类器，sampleKey 唯一标识一条内容，同一内容改判时会先撤销之前的训练
+	TrainSample(ctx context.Context, sampleKey string, content string, isSpam bool) error
+}
@@ -15,4 +15,13 @@ type TalkService interface {
 	ListTalks(ctx context.Context) (vo.PageResult[dto.TalkDTO], error)
 
 	SaveTalkLike(ctx context.Context, talkId int) (int, error)
+
+	// 查看后台说说
+	ListBackTalks(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.TalkBackDTO], error)
+
+	// 保存或修改说说
+	SaveOrUpdateTalk(ctx context.Context, talkVO vo.TalkVO) error
+
+	// 取消说说定时发布
+	CancelTalkSchedule(ctx context.Context, talkId int) error
 }
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"goBolg/dto"
+)
+
+// TokenService 登录会话和令牌服务接口
+type TokenService interface {
+	// 创建登录会话，签发访问令牌和刷新令牌
+	CreateToken(ctx context.Context, userId int, username string) (dto.TokenDTO, error)
+
+	// 使用刷新令牌签发新的访问令牌并轮换刷新令牌，已使用过的刷新令牌再次出现时注销整个会话
+	RefreshToken(ctx context.Context, refreshToken string) (dto.TokenDTO, error)
+
+	// 判断登录会话是否已注销
+	IsRevoked(ctx context.Context, sessionId string) (bool, error)
+
+	// 注销用户的一个登录会话
+	RemoveSession(ctx context.Context, userId int, sessionId string) error
+
+	// 注销刷新令牌所属的登录会话，访问令牌已过期时也能登出
+	RemoveSessionByRefreshToken(ctx context.Context, refreshToken string) error
+
+	// 注销用户的全部登录会话
+	RemoveUserSessions(ctx context.Context, userId int) error
+
+	// 注销用户信息下全部账号的登录会话，用于强制下线和禁用用户
+	RemoveUserInfoSessions(ctx context.Context, userInfoId int) error
+}
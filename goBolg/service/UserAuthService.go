@@ -15,4 +15,6 @@ type UserAuthService interface {
 	UpdatePassword(ctx context.Context, user *vo.UserVO) error
 
 	ListUserAreas(ctx context.Context, conditionVO vo.ConditionVO) ([]dto.UserAreaDTO, error)
+
+	ListUserBacks(ctx context.Context, condition vo.ConditionVO) (vo.PageResult[dto.UserBackDTO], error)
 }
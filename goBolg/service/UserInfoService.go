@@ -13,4 +13,8 @@ type UserInfoService interface {
 	UpdateUserAvatar(ctx context.Context, file *multipart.FileHeader) (string, error)
 
 	SaveUserEmail(ctx context.Context, emailVO vo.EmailVO) error
+
+	UpdateUserDisable(ctx context.Context, userDisableVO vo.UserDisableVO) error
+
+	UpdateUserRole(ctx context.Context, userRoleVO vo.UserRoleVO) error
 }
@@ -74,7 +74,9 @@ CREATE TABLE `tb_article`  (
   `status` bigint NULL DEFAULT NULL,
   `create_time` datetime(3) NULL DEFAULT NULL,
   `update_time` datetime(3) NULL DEFAULT NULL,
-  PRIMARY KEY (`id`) USING BTREE
+  PRIMARY KEY (`id`) USING BTREE,
+  FULLTEXT INDEX `ft_article_title_content`(`article_title`, `article_content`) WITH PARSER `ngram`,
+  FULLTEXT INDEX `ft_article_title`(`article_title`) WITH PARSER `ngram`
 ) ENGINE = InnoDB AUTO_INCREMENT = 107 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci ROW_FORMAT = DYNAMIC;
 
 -- ----------------------------
@@ -89,6 +91,25 @@ INSERT INTO `tb_article` VALUES (70, 1006, 188, 'http://blog-felix.oss-cn-beijin
 INSERT INTO `tb_article` VALUES (71, 1006, 190, 'http://blog-felix.oss-cn-beijing.aliyuncs.com/articles/cde5bdb8466fae3bf8855307af8c7537.jpg', 'springBoot文件上传导出', '# 导包 这里使用阿里云OSS存储桶\n```xml\n  <!-- 阿里云oss-->\n        <dependency>\n            <groupId>com.aliyun.oss</groupId>\n            <artifactId>aliyun-sdk-oss</artifactId>\n            <version>3.8.0</version>\n        </dependency>\n```\n\n# 配置yml\n```yml\n  # 上传图片大小限制\n  servlet:\n    multipart:\n      max-file-size: 100MB\n      max-request-size:  100MB\n\n# 上传模式 可选 oss或local  （http://和最后的/一定不能去！！！）\n# 这里采用阿里云存储桶进行存储文件图片等。\nupload:\n  mode: oss\n  local:\n    # nginx映射本地文件路径，无域名则为 ip:83\n    url: http://你的文件上传子域名/\n    # 本地文件存储路径\n    path: /usr/local/upload/\n  oss:\n    url: http://blog-felix.oss-cn-beijing.aliyuncs.com/  # 外网访问域名\n    endpoint: oss-cn-beijing.aliyuncs.com        # 阿里云固定\n    accessKeyId: LTAI5tHmZ47AR1CgaGR8fi4z\n    accessKeySecret: ulJIX3GYn9SjUrDc2g971DqJEI6L0q\n    bucketName: blog-felix                       # 存储桶名称\n  cos:\n    url: http://Bucket域名/\n    secretId: cos的secretId\n    secretKey: cos的secretKey\n    region: cos的region\n    bucketName: cos的bucketName\n\n```\n\n# 上传策略，需要用什么模式(yml文件配置的OSS、COS等)进行上传，需要进行实现\n# 上传策略，可以分两种\n1. 第一种就是上传图片头像等\n2. 第二种就是上传文章\n```Java\n\nimport org.springframework.web.multipart.MultipartFile;\n\nimport java.io.InputStream;\n\n/**\n * 上传策略\n *\n * @author Felix\n * @date 2021/07/28\n */\npublic interface UploadStrategy {\n\n    /**\n     * 上传文件\n     *\n     * @param file 文件\n     * @param path 上传路径\n     * @return {@link String} 文件地址\n     */\n    String uploadFile(MultipartFile file, String path);\n\n    /**\n     * 上传文件\n     *\n     * @param fileName    文件名\n     * @param inputStream 输入流\n     * @param path        路径\n     * @return {@link String}\n     */\n    String uploadFile(String fileName, InputStream inputStream, String path);\n\n}\n```\n\n1. 搜索策略\n```Java\nimport lombok.AllArgsConstructor;\nimport lombok.Getter;\n\n/**\n * 上传模式枚举\n *\n * @author Felix\n * @date 2021/07/28\n */\n@Getter\n@AllArgsConstructor\npublic enum UploadModeEnum {\n    /**\n     * oss\n     */\n    OSS(\"oss\", \"ossUploadStrategyImpl\"),\n    /**\n     * 本地\n     */\n    LOCAL(\"local\", \"localUploadStrategyImpl\"),\n\n    /**\n     * cos\n     */\n    COS(\"cos\", \"cosUploadStrategyImpl\");\n\n    /**\n     * 模式\n     */\n    private final String mode;\n\n    /**\n     * 策略\n     */\n    private final String strategy;\n\n    /**\n     * 获取策略\n     *\n     * @param mode 模式\n     * @return {@link String} 搜索策略\n     */\n    public static String getStrategy(String mode) {\n        for (UploadModeEnum value : UploadModeEnum.values()) {\n            if (value.getMode().equals(mode)) {\n                return value.getStrategy();\n            }\n        }\n        return null;\n    }\n\n}\n\n```\n2. OSS的一些前期操作\n- OSS配置属性\n```Java\nimport lombok.Data;\nimport org.springframework.boot.context.properties.ConfigurationProperties;\nimport org.springframework.context.annotation.Configuration;\n\n/**\n * oss配置属性\n *\n * @author Felix\n * @date 2021/07/28\n */\n@Data\n@Configuration\n@ConfigurationProperties(prefix = \"upload.oss\")\npublic class OssConfigProperties {\n\n    /**\n     * oss域名\n     */\n    private String url;\n\n    /**\n     * 终点\n     */\n    private String endpoint;\n\n    /**\n     * 访问密钥id\n     */\n    private String accessKeyId;\n\n    /**\n     * 访问密钥密码\n     */\n    private String accessKeySecret;\n\n    /**\n     * bucket名称\n     */\n    private String bucketName;\n\n}\n\n```\n- 抽象上传模板\n```Java\nimport com.minzheng.blog.exception.BizException;\nimport com.minzheng.blog.strategy.UploadStrategy;\nimport com.minzheng.blog.util.FileUtils;\nimport org.springframework.stereotype.Service;\nimport org.springframework.web.multipart.MultipartFile;\n\nimport java.io.*;\n\n/**\n * 抽象上传模板\n *\n * @author Felix\n * @date 2021/07/28\n */\n@Service\npublic abstract class AbstractUploadStrategyImpl implements UploadStrategy {\n\n    @Override\n    public String uploadFile(MultipartFile file, String path) {\n        try {\n            // 获取文件md5值\n            String md5 = FileUtils.getMd5(file.getInputStream());\n            // 获取文件扩展名\n            String extName = FileUtils.getExtName(file.getOriginalFilename());\n            // 重新生成文件名\n            String fileName = md5 + extName;\n            // 判断文件是否已存在\n            if (!exists(path + fileName)) {\n                // 不存在则继续上传\n                upload(path, fileName, file.getInputStream());\n            }\n            // 返回文件访问路径\n            return getFileAccessUrl(path + fileName);\n        } catch (Exception e) {\n            e.printStackTrace();\n            throw new BizException(\"文件上传失败\");\n        }\n    }\n\n    @Override\n    public String uploadFile(String fileName, InputStream inputStream, String path) {\n        try {\n            // 上传文件\n            upload(path, fileName, inputStream);\n            // 返回文件访问路径\n            return getFileAccessUrl(path + fileName);\n        } catch (Exception e) {\n            e.printStackTrace();\n            throw new BizException(\"文件上传失败\");\n        }\n    }\n\n    /**\n     * 判断文件是否存在\n     *\n     * @param filePath 文件路径\n     * @return {@link Boolean}\n     */\n    public abstract Boolean exists(String filePath);\n\n    /**\n     * 上传\n     *\n     * @param path        路径\n     * @param fileName    文件名\n     * @param inputStream 输入流\n     * @throws IOException io异常\n     */\n    public abstract void upload(String path, String fileName, InputStream inputStream) throws IOException;\n\n    /**\n     * 获取文件访问url\n     *\n     * @param filePath 文件路径\n     * @return {@link String}\n     */\n    public abstract String getFileAccessUrl(String filePath);\n\n}\n\n```\n\n- OSS上传策略\n```Java\nimport com.aliyun.oss.OSS;\nimport com.aliyun.oss.OSSClientBuilder;\nimport com.minzheng.blog.config.OssConfigProperties;\nimport org.springframework.beans.factory.annotation.Autowired;\nimport org.springframework.stereotype.Service;\n\nimport java.io.InputStream;\n\n/**\n * oss上传策略\n *\n * @author Felix\n * @date 2021/07/28\n */\n@Service(\"ossUploadStrategyImpl\")\npublic class OssUploadStrategyImpl extends AbstractUploadStrategyImpl {\n    @Autowired\n    private OssConfigProperties ossConfigProperties;\n\n    @Override\n    public Boolean exists(String filePath) {\n        return getOssClient().doesObjectExist(ossConfigProperties.getBucketName(), filePath);\n    }\n\n    @Override\n    public void upload(String path, String fileName, InputStream inputStream) {\n        getOssClient().putObject(ossConfigProperties.getBucketName(), path + fileName, inputStream);\n    }\n\n    @Override\n    public String getFileAccessUrl(String filePath) {\n        return ossConfigProperties.getUrl() + filePath;\n    }\n\n    /**\n     * 获取ossClient\n     *\n     * @return {@link OSS} ossClient\n     */\n    private OSS getOssClient() {\n        return new OSSClientBuilder().build(ossConfigProperties.getEndpoint(), ossConfigProperties.getAccessKeyId(), ossConfigProperties.getAccessKeySecret());\n    }\n\n}\n\n```\n\n```Java\nimport com.minzheng.blog.strategy.UploadStrategy;\nimport org.springframework.beans.factory.annotation.Autowired;\nimport org.springframework.beans.factory.annotation.Value;\nimport org.springframework.stereotype.Service;\nimport org.springframework.web.multipart.MultipartFile;\n\nimport java.io.InputStream;\nimport java.util.Map;\n\nimport static com.minzheng.blog.enums.UploadModeEnum.getStrategy;\n\n\n/**\n * 上传策略上下文\n *\n * @author Felix\n * @date 2021/07/28\n */\n@Service\npublic class UploadStrategyContext {\n    /**\n     * 上传模式\n     */\n    @Value(\"${upload.mode}\")\n    private String uploadMode;\n\n    @Autowired\n    private Map<String, UploadStrategy> uploadStrategyMap;\n\n    /**\n     * 执行上传策略\n     * 上传图片(头像等)\n     * @param file 文件\n     * @param path 路径\n     * @return {@link String} 文件地址\n     */\n    public String executeUploadStrategy(MultipartFile file, String path) {\n        return uploadStrategyMap.get(getStrategy(uploadMode)).uploadFile(file, path);\n    }\n\n\n    /**\n     * 执行上传策略\n     * 导出文章用这个\n     * @param fileName    文件名称\n     * @param inputStream 输入流\n     * @param path        路径\n     * @return {@link String} 文件地址\n     */\n    public String executeUploadStrategy(String fileName, InputStream inputStream, String path) {\n        return uploadStrategyMap.get(getStrategy(uploadMode)).uploadFile(fileName, inputStream, path);\n    }\n\n}\n\n```\n## 导出文章\n```Java\n    @Override\n    public List<String> exportArticles(List<Integer> articleIdList) {\n        // 查询文章信息\n        List<Article> articleList = articleDao.selectList(new LambdaQueryWrapper<Article>()\n                .select(Article::getArticleTitle, Article::getArticleContent)\n                .in(Article::getId, articleIdList));\n        // 写入文件并上传\n        List<String> urlList = new ArrayList<>();\n        for (Article article : articleList) {\n            try (ByteArrayInputStream inputStream = new ByteArrayInputStream(article.getArticleContent().getBytes())) {\n                String url = uploadStrategyContext.executeUploadStrategy(article.getArticleTitle() + FileExtEnum.MD.getExtName(), inputStream, FilePathEnum.MD.getPath());\n                urlList.add(url);\n            } catch (Exception e) {\n                log.error(StrUtil.format(\"导出文章失败,堆栈:{}\", ExceptionUtil.stacktraceToString(e)));\n                throw new BizException(\"导出文章失败\");\n            }\n        }\n        return urlList;\n    }\n```\n\n```Java\n  /**\n     * 导出文章\n     *\n     * @param articleIdList 文章id列表\n     * @return {@link List<String>} 文件url列表\n     */\n    @ApiOperation(value = \"导出文章\")\n    @ApiImplicitParam(name = \"articleIdList\", value = \"文章id\", required = true, dataType = \"List<Integer>\")\n    @PostMapping(\"/admin/articles/export\")\n    public Result<List<String>> exportArticles(@RequestBody List<Integer> articleIdList) {\n        return Result.ok(articleService.exportArticles(articleIdList));\n    }\n```\n## 导入文章\n- 文章导入策略\n```Java\nimport org.springframework.web.multipart.MultipartFile;\n\n/**\n * 文章导入策略\n *\n * @author ting\n * @date 2022/07/28\n */\npublic interface ArticleImportStrategy {\n\n    /**\n     * 导入文章\n     *\n     * @param file 文件\n     */\n    void importArticles(MultipartFile file);\n}\n\n```\n- Markdown文章类型枚举\n分两种文章形式导入，第一种就是普通形式导入、第二种就是hexo形式导入\n- - 第一种普通形式导入策略\n```Java\nimport cn.hutool.core.exceptions.ExceptionUtil;\nimport cn.hutool.core.util.StrUtil;\nimport com.minzheng.blog.exception.BizException;\nimport com.minzheng.blog.service.ArticleService;\nimport com.minzheng.blog.strategy.ArticleImportStrategy;\n\nimport com.minzheng.blog.vo.ArticleVO;\nimport lombok.extern.slf4j.Slf4j;\nimport org.springframework.beans.factory.annotation.Autowired;\nimport org.springframework.stereotype.Service;\nimport org.springframework.web.multipart.MultipartFile;\n\nimport java.io.BufferedReader;\nimport java.io.IOException;\nimport java.io.InputStreamReader;\nimport java.util.Objects;\n\nimport static com.minzheng.blog.enums.ArticleStatusEnum.DRAFT;\n\n/**\n * 普通文章导入策略\n *\n * @author linweiyuan\n * @date 2022/07/28\n */\n@Slf4j\n@Service(\"normalArticleImportStrategyImpl\")\npublic class NormalArticleImportStrategyImpl implements ArticleImportStrategy {\n    @Autowired\n    private ArticleService articleService;\n\n    @Override\n    public void importArticles(MultipartFile file) {\n        // 获取文件名作为文章标题\n        String articleTitle = Objects.requireNonNull(file.getOriginalFilename()).split(\"\\\\.\")[0];\n        // 获取文章内容\n        StringBuilder articleContent = new StringBuilder();\n        try (BufferedReader reader = new BufferedReader(new InputStreamReader(file.getInputStream()))) {\n            while (reader.ready()) {\n                articleContent.append((char) reader.read());\n            }\n        } catch (IOException e) {\n            log.error(StrUtil.format(\"导入文章失败, 堆栈:{}\", ExceptionUtil.stacktraceToString(e)));\n            throw new BizException(\"导入文章失败\");\n        }\n        // 保存文章\n        ArticleVO articleVO = ArticleVO.builder()\n                .articleTitle(articleTitle)\n                .articleContent(articleContent.toString())\n                .status(DRAFT.getStatus())\n                .build();\n        articleService.saveOrUpdateArticle(articleVO);\n    }\n}\n\n```\n- - 第二种hexo形式导入\n```Java\nimport cn.hutool.core.exceptions.ExceptionUtil;\nimport cn.hutool.core.util.StrUtil;\nimport com.minzheng.blog.exception.BizException;\nimport com.minzheng.blog.service.ArticleService;\nimport com.minzheng.blog.strategy.ArticleImportStrategy;\nimport com.minzheng.blog.vo.HexoArticleVO;\n\nimport lombok.extern.slf4j.Slf4j;\nimport org.springframework.beans.factory.annotation.Autowired;\nimport org.springframework.stereotype.Service;\nimport org.springframework.util.CollectionUtils;\nimport org.springframework.web.multipart.MultipartFile;\n\nimport java.io.BufferedReader;\nimport java.io.IOException;\nimport java.io.InputStreamReader;\nimport java.nio.charset.StandardCharsets;\nimport java.time.LocalDateTime;\nimport java.time.format.DateTimeFormatter;\nimport java.util.ArrayList;\nimport java.util.List;\nimport java.util.concurrent.atomic.AtomicInteger;\n\nimport static com.minzheng.blog.constant.HexoConst.*;\nimport static com.minzheng.blog.enums.ArticleStatusEnum.DRAFT;\nimport static com.minzheng.blog.enums.ArticleStatusEnum.PUBLIC;\nimport static com.minzheng.blog.enums.ArticleTypeEnum.ORIGINAL;\n\n/**\n * Hexo文章导入策略\n *\n * @author linweiyuan\n * @date 2022/07/28\n */\n@Slf4j\n@Service(\"hexoArticleImportStrategyImpl\")\npublic class HexoArticleImportStrategyImpl implements ArticleImportStrategy {\n    @Autowired\n    private ArticleService articleService;\n\n    /**\n     * hexo最大分隔符数\n     */\n    private final int HEXO_MAX_DELIMITER_COUNT = 2;\n\n    /**\n     * hexo最小分隔符数\n     */\n    private final int HEXO_MIN_DELIMITER_COUNT = 1;\n\n    private final DateTimeFormatter formatter = DateTimeFormatter.ofPattern(\"yyyy-MM-dd HH:mm:ss\");\n\n    @Override\n    public void importArticles(MultipartFile file) {\n        try {\n            HexoArticleVO hexoArticleVO = new HexoArticleVO();\n            // 原创\n            hexoArticleVO.setType(ORIGINAL.getType());\n            // 公开 （DRAFT不保存分类）\n            hexoArticleVO.setStatus(PUBLIC.getStatus());\n\n            AtomicInteger hexoDelimiterCount = new AtomicInteger();\n            StringBuilder articleContent = new StringBuilder();\n\n            // 分类或标签标记\n            AtomicInteger flag = new AtomicInteger(NORMAL_FLAG);\n\n            List<String> tagList = new ArrayList<>();\n\n            BufferedReader reader = new BufferedReader(new InputStreamReader(file.getInputStream(), StandardCharsets.UTF_8));\n            reader.lines().forEach(line -> {\n                if (hexoDelimiterCount.get() == HEXO_MAX_DELIMITER_COUNT) {\n                    // 分隔符结束就是正文\n                    articleContent.append(line).append(NEW_LINE);\n                } else {\n                    if (line.equals(DELIMITER)) {\n                        hexoDelimiterCount.getAndIncrement();\n                    }\n                    if (hexoDelimiterCount.get() == HEXO_MIN_DELIMITER_COUNT) {\n                        if (line.startsWith(TITLE_PREFIX)) {\n                            hexoArticleVO.setArticleTitle(line.replace(TITLE_PREFIX, \"\").trim());\n                        } else if (line.startsWith(DATE_PREFIX)) {\n                            hexoArticleVO.setCreateTime(LocalDateTime.parse(line.replace(DATE_PREFIX, \"\").trim(), formatter));\n                        } else if (line.startsWith(CATEGORIES_PREFIX)) {\n                            flag.set(CATEGORY_FLAG);\n                        } else if (line.startsWith(TAGS_PREFIX)) {\n                            flag.set(TAG_FLAG);\n                        } else if (line.startsWith(PREFIX) && flag.intValue() == CATEGORY_FLAG) {\n                            hexoArticleVO.setCategoryName(line.replace(PREFIX, \"\").trim());\n                        } else if (line.startsWith(PREFIX) && flag.intValue() == TAG_FLAG) {\n                            tagList.add(line.replace(PREFIX, \"\").trim());\n                        }\n                    }\n                }\n            });\n\n            hexoArticleVO.setTagNameList(tagList);\n            hexoArticleVO.setArticleContent(articleContent.toString());\n\n            // 如果分类或标签为空则设为草稿\n            if (CollectionUtils.isEmpty(hexoArticleVO.getTagNameList()) || StrUtil.isBlank(hexoArticleVO.getCategoryName())) {\n                hexoArticleVO.setStatus(DRAFT.getStatus());\n            }\n\n            articleService.saveOrUpdateArticle(hexoArticleVO);\n        } catch (IOException e) {\n            log.error(StrUtil.format(\"导入Hexo文章失败, 堆栈:{}\", ExceptionUtil.stacktraceToString(e)));\n            throw new BizException(\"导入Hexo文章失败\");\n        }\n    }\n\n}\n\n```\n```Java\nimport lombok.AllArgsConstructor;\nimport lombok.Getter;\n\n/**\n * Markdown文章类型枚举\n *\n * @author linweiyuan\n * @date 2022/07/28\n */\n@Getter\n@AllArgsConstructor\npublic enum MarkdownTypeEnum {\n    /**\n     * 普通文章\n     */\n    NORMAL(\"\", \"normalArticleImportStrategyImpl\"),\n    /**\n     * Hexo文章\n     */\n    HEXO(\"hexo\", \"hexoArticleImportStrategyImpl\");\n\n    /**\n     * 类型\n     */\n    private final String type;\n\n    /**\n     * 策略\n     */\n    private final String strategy;\n\n    public static String getMarkdownType(String name) {\n        if (name == null) {\n            return NORMAL.getStrategy();\n        }\n        for (MarkdownTypeEnum value : MarkdownTypeEnum.values()) {\n            if (value.getType().equalsIgnoreCase(name)) {\n                return value.getStrategy();\n            }\n        }\n        return null;\n    }\n}\n```\n\n-  文章导入策略上下文\n```Java\nimport com.minzheng.blog.enums.MarkdownTypeEnum;\nimport com.minzheng.blog.strategy.ArticleImportStrategy;\nimport org.springframework.beans.factory.annotation.Autowired;\nimport org.springframework.stereotype.Service;\nimport org.springframework.web.multipart.MultipartFile;\n\nimport java.util.Map;\n\n/**\n * 文章导入策略上下文\n *\n * @author linweiyuan\n * @date 2022/07/28\n */\n@Service\npublic class ArticleImportStrategyContext {\n    @Autowired\n    private Map<String, ArticleImportStrategy> articleImportStrategyMap;\n\n    public void importArticles(MultipartFile file, String type) {\n        articleImportStrategyMap.get(MarkdownTypeEnum.getMarkdownType(type)).importArticles(file);\n    }\n}\n\n```\n- controller层 通过markdown形式进行导入文章\n```Java\n\n    /**\n     * 导入文章\n     *\n     * @param file 文件\n     * @param type 文章类型\n     * @return {@link Result<>}\n     */\n    @ApiOperation(value = \"导入文章\")\n    @PostMapping(\"/admin/articles/import\")\n    public Result<?> importArticles(MultipartFile file, @RequestParam(required = false) String type) {\n        articleImportStrategyContext.importArticles(file, type);\n        return Result.ok();\n    }\n```\n# 文件路径枚举\n比如要上传的是什么，头像？文章图片？音频路径？照片路径等？这些都是可以创建一个枚举。\n```Java\nimport lombok.AllArgsConstructor;\nimport lombok.Getter;\n\n/**\n * 文件路径枚举\n *\n * @author Felix\n * @date 2021/08/04\n */\n@Getter\n@AllArgsConstructor\npublic enum FilePathEnum {\n    /**\n     * 头像路径\n     */\n    AVATAR(\"avatar/\", \"头像路径\"),\n    /**\n     * 文章图片路径\n     */\n    ARTICLE(\"articles/\", \"文章图片路径\"),\n    /**\n     * 音频路径\n     */\n    VOICE(\"voice/\", \"音频路径\"),\n    /**\n     * 照片路径\n     */\n    PHOTO(\"photos/\", \"相册路径\"),\n    /**\n     * 配置图片路径\n     */\n    CONFIG(\"config/\", \"配置图片路径\"),\n    /**\n     * 说说图片路径\n     */\n    TALK(\"talks/\", \"说说图片路径\"),\n    /**\n     * md文件路径\n     */\n    MD(\"markdown/\", \"md文件路径\");\n\n    /**\n     * 路径\n     */\n    private final String path;\n\n    /**\n     * 描述\n     */\n    private final String desc;\n\n}\n\n```\n# 文件扩展名枚举，比如图片jpg、png、JPEG、文件WAV、MD(markdown文件)、TXT等可以做成枚举类\n```Java\nimport lombok.AllArgsConstructor;\nimport lombok.Getter;\n\n/**\n * 文件扩展名枚举\n *\n * @author Felix\n * @date 2021/08/12\n */\n@Getter\n@AllArgsConstructor\npublic enum FileExtEnum {\n    /**\n     * jpg文件\n     */\n    JPG(\".jpg\", \"jpg文件\"),\n    /**\n     * png文件\n     */\n    PNG(\".png\", \"png文件\"),\n    /**\n     * Jpeg文件\n     */\n    JPEG(\".jpeg\", \"jpeg文件\"),\n    /**\n     * wav文件\n     */\n    WAV(\".wav\", \"wav文件\"),\n    /**\n     * md文件\n     */\n    MD(\".md\",\"markdown文件\"),\n    /**\n     * txt文件\n     */\n    TXT(\".txt\",\"txt文件\");\n\n    /**\n     * 获取文件格式\n     *\n     * @param extName 扩展名\n     * @return {@link FileExtEnum} 文件格式\n     */\n    public static FileExtEnum getFileExt(String extName) {\n        for (FileExtEnum value : FileExtEnum.values()) {\n            if (value.getExtName().equalsIgnoreCase(extName)) {\n                return value;\n            }\n        }\n        return null;\n    }\n\n    /**\n     * 扩展名\n     */\n    private final String extName;\n\n    /**\n     * 描述\n     */\n    private final String desc;\n\n}\n\n```\n# 上传图片controller层\n```Java\n  /**\n     * 上传说说图片\n     *\n     * @param file 文件\n     * @return {@link Result<String>} 说说图片地址\n     */\n    @ApiOperation(value = \"上传说说图片\")\n    @ApiImplicitParam(name = \"file\", value = \"说说图片\", required = true, dataType = \"MultipartFile\")\n    @PostMapping(\"/admin/talks/images\")\n    public Result<String> saveTalkImages(MultipartFile file) {\n        return Result.ok(uploadStrategyContext.executeUploadStrategy(file, FilePathEnum.TALK.getPath()));\n    }\n\n\n /**\n     * 上传相册封面\n     *\n     * @param file 文件\n     * @return {@link Result<String>} 相册封面地址\n     */\n    @ApiOperation(value = \"上传相册封面\")\n    @ApiImplicitParam(name = \"file\", value = \"相册封面\", required = true, dataType = \"MultipartFile\")\n    @PostMapping(\"/admin/photos/albums/cover\")\n    public Result<String> savePhotoAlbumCover(MultipartFile file) {\n        return Result.ok(uploadStrategyContext.executeUploadStrategy(file, FilePathEnum.PHOTO.getPath()));\n    }\n\n\n  /**\n     * 上传文章图片\n     *\n     * @param file 文件\n     * @return {@link Result<String>} 文章图片地址\n     */\n    @ApiOperation(value = \"上传文章图片\")\n    @ApiImplicitParam(name = \"file\", value = \"文章图片\", required = true, dataType = \"MultipartFile\")\n    @PostMapping(\"/admin/articles/images\")\n    public Result<String> saveArticleImages(MultipartFile file) {\n        return Result.ok(uploadStrategyContext.executeUploadStrategy(file, FilePathEnum.ARTICLE.getPath()));\n    }\n```\n# 编写前端进行上传\n```JavaScript\nexport default {\n  TENCENT_CAPTCHA: \"2088053498\",\n  UPLOAD_SIZE: 200 // 压缩文件大小\n};\n\n```\n\n```html\n        <el-form-item label=\"上传封面\">\n          <el-upload\n            class=\"upload-cover\"\n            drag\n            action=\"/api/admin/articles/images\"  // 这里调用后端接口\n            multiple\n            :before-upload=\"beforeUpload\"     //文件通过接口上传之前，一般用来判断规则\n            :on-success=\"uploadCover\"\n          >\n            <i class=\"el-icon-upload\" v-if=\"article.articleCover == \'\'\" />\n            <div class=\"el-upload__text\" v-if=\"article.articleCover == \'\'\">\n              将文件拖到此处，或<em>点击上传</em>\n            </div>\n            <img\n              v-else\n              :src=\"article.articleCover\"\n              width=\"360px\"\n              height=\"180px\"\n            />\n          </el-upload>\n        </el-form-item>\n\n\n\n<script>\narticle:{\narticleCover: \"\",\n}\n//压缩图片\n    beforeUpload(file) {\n      return new Promise(resolve => {\n        if (file.size / 1024 < this.config.UPLOAD_SIZE) {\n          resolve(file);\n        }\n        // 压缩到200KB,这里的200就是要压缩的大小,可自定义\n        imageConversion\n          .compressAccurately(file, this.config.UPLOAD_SIZE)\n          .then(res => {\n            resolve(res);\n          });\n      });\n    },\n\n   uploadCover(response) {\n      this.article.articleCover = response.data;\n    },\n</script>\n```\n\n**上传图片和发表文章是分开的，上传图片是存在oss阿里云存储桶里，而发表文章内容是存储在数据库里**\n下面是发表文章\n# 发表文章\n1. 把文章需要显示给客户端看的属性创建VO类进行表示\n```Java\nimport io.swagger.annotations.ApiModel;\nimport io.swagger.annotations.ApiModelProperty;\nimport lombok.*;\n\nimport javax.validation.constraints.NotBlank;\nimport java.util.List;\n\n\n/**\n * 文章\n *\n * @author Felix\n * @date 2021/08/03\n */\n@Data\n@Builder\n@AllArgsConstructor\n@NoArgsConstructor\n@ApiModel(description = \"文章\")\npublic class ArticleVO {\n\n    /**\n     * 文章id\n     */\n    @ApiModelProperty(name = \"id\", value = \"文章id\", dataType = \"Integer\")\n    private Integer id;\n\n    /**\n     * 标题\n     */\n    @NotBlank(message = \"文章标题不能为空\")\n    @ApiModelProperty(name = \"articleTitle\", value = \"文章标题\", required = true, dataType = \"String\")\n    private String articleTitle;\n\n    /**\n     * 内容\n     */\n    @NotBlank(message = \"文章内容不能为空\")\n    @ApiModelProperty(name = \"articleContent\", value = \"文章内容\", required = true, dataType = \"String\")\n    private String articleContent;\n\n    /**\n     * 文章封面\n     */\n    @ApiModelProperty(name = \"articleCover\", value = \"文章缩略图\", dataType = \"String\")\n    private String articleCover;\n\n    /**\n     * 文章分类\n     */\n    @ApiModelProperty(name = \"category\", value = \"文章分类\", dataType = \"Integer\")\n    private String categoryName;\n\n    /**\n     * 文章标签\n     */\n    @ApiModelProperty(name = \"tagNameList\", value = \"文章标签\", dataType = \"List<Integer>\")\n    private List<String> tagNameList;\n\n    /**\n     * 文章类型\n     */\n    @ApiModelProperty(name = \"type\", value = \"文章类型\", dataType = \"Integer\")\n    private Integer type;\n\n    /**\n     * 原文链接\n     */\n    @ApiModelProperty(name = \"originalUrl\", value = \"原文链接\", dataType = \"String\")\n    private String originalUrl;\n\n    /**\n     * 是否置顶\n     */\n    @ApiModelProperty(name = \"isTop\", value = \"是否置顶\", dataType = \"Integer\")\n    private Integer isTop;\n\n    /**\n     * 文章状态 1.公开 2.私密 3.评论可见\n     */\n    @ApiModelProperty(name = \"status\", value = \"文章状态\", dataType = \"String\")\n    private Integer status;\n\n}\n\n```\n2. 创建service层\n```Java\n/**\n * 文章服务\n *\n * @author Felix\n * @date 2021/07/29\n */\npublic interface ArticleService extends IService<Article> {\n    /**\n     * 添加或修改文章\n     *\n     * @param articleVO 文章信息\n     */\n    void saveOrUpdateArticle(ArticleVO articleVO);\n}\n```\n3. 创建Impl层进行业务逻辑编写\n```Java\n/**\n * 文章服务\n *\n * @author Felix\n * @date 2021/08/10\n */\n@Service\n@Slf4j\npublic class ArticleServiceImpl extends ServiceImpl<ArticleDao, Article> implements ArticleService {\n @Transactional(rollbackFor = Exception.class)\n    @Override\n    public void saveOrUpdateArticle(ArticleVO articleVO) {\n        // 查询博客配置信息\n        CompletableFuture<WebsiteConfigVO> webConfig = CompletableFuture.supplyAsync(() -> blogInfoService.getWebsiteConfig());\n\n        // 保存文章分类\n        Category category = saveArticleCategory(articleVO);\n        // 保存或修改文章\n        Article article = BeanCopyUtils.copyObject(articleVO, Article.class);\n        if (Objects.nonNull(category)) {\n            article.setCategoryId(category.getId());\n        }\n        // 设定默认文章封面\n        if (StrUtil.isBlank(article.getArticleCover())){\n            try {\n                article.setArticleCover(webConfig.get().getArticleCover());\n            } catch (Exception e) {\n                throw new BizException(\"设定默认文章封面失败\");\n            }\n        }\n        article.setUserId(UserUtils.getLoginUser().getUserInfoId());\n        this.saveOrUpdate(article);\n        // 保存文章标签\n        saveArticleTag(articleVO, article.getId());\n    }\n\n    /**\n     * 保存文章分类\n     *\n     * @param articleVO 文章信息\n     * @return {@link Category} 文章分类\n     */\n    private Category saveArticleCategory(ArticleVO articleVO) {\n        // 判断分类是否存在\n        Category category = categoryDao.selectOne(new LambdaQueryWrapper<Category>().eq(Category::getCategoryName, articleVO.getCategoryName()));\n        if (Objects.isNull(category) && !articleVO.getStatus().equals(DRAFT.getStatus())) {\n            category = Category.builder().categoryName(articleVO.getCategoryName()).build();\n            categoryDao.insert(category);\n        }\n        return category;\n    }\n\n}\n```\n4. controller\n```Java\n\n    /**\n     * 添加或修改文章\n     *\n     * @param articleVO 文章信息\n     * @return {@link Result<>}\n     */\n    @OptLog(optType = SAVE_OR_UPDATE)\n    @ApiOperation(value = \"添加或修改文章\")\n    @PostMapping(\"/admin/articles\")\n    public Result<?> saveOrUpdateArticle(@Valid @RequestBody ArticleVO articleVO) {\n        articleService.saveOrUpdateArticle(articleVO);\n        return Result.ok();\n    }\n```\n# 前端实现\n```html\n      <div slot=\"footer\">\n        <el-button @click=\"addOrEdit = false\">取 消</el-button>\n        <el-button type=\"danger\" @click=\"saveOrUpdateArticle\">\n          发 表\n        </el-button>\n      </div>\n\n<script>\nreturn{\n  addOrEdit: false,\n  article: {\n        id: null,\n        articleTitle: this.$moment(new Date()).format(\"YYYY-MM-DD\"),\n        articleContent: \"\",\n        articleCover: \"\",\n        categoryName: null,\n        tagNameList: [],\n        originalUrl: \"\",\n        isTop: 0,\n        type: 1,\n        status: 1\n      }\n},\n saveOrUpdateArticle() {\n      if (this.article.articleTitle.trim() == \"\") {\n        this.$message.error(\"文章标题不能为空\");\n        return false;\n      }\n      if (this.article.articleContent.trim() == \"\") {\n        this.$message.error(\"文章内容不能为空\");\n        return false;\n      }\n      if (this.article.categoryName == null) {\n        this.$message.error(\"文章分类不能为空\");\n        return false;\n      }\n      if (this.article.tagNameList.length == 0) {\n        this.$message.error(\"文章标签不能为空\");\n        return false;\n      }\n      if (this.article.articleCover.trim() == \"\") {\n        this.$message.error(\"文章封面不能为空\");\n        return false;\n      }\n      this.axios.post(\"/api/admin/articles\", this.article).then(({ data }) => {\n        if (data.flag) {\n          if (this.article.id === null) {\n            this.$store.commit(\"removeTab\", \"发布文章\");\n          } else {\n            this.$store.commit(\"removeTab\", \"修改文章\");\n          }\n          sessionStorage.removeItem(\"article\");\n          this.$router.push({ path: \"/article-list\" });\n          this.$notify.success({\n            title: \"成功\",\n            message: data.message\n          });\n        } else {\n          this.$notify.error({\n            title: \"失败\",\n            message: data.message\n          });\n        }\n        this.addOrEdit = false;\n      });\n      //关闭自动保存功能\n      this.autoSave = false;\n    },\n</script>\n```\n\n# 可以参考这个博主的文章，挺不错的，里面有很多种不同的上传策略方式等\n[上传策略](https://juejin.cn/post/7169845295801450526)\n\n\n\n\n\n', 1, '', 0, 0, 1, '2021-07-28 14:10:11.000', '2021-09-28 15:50:08.000');
 INSERT INTO `tb_article` VALUES (72, 1006, 187, 'http://blog-felix.oss-cn-beijing.aliyuncs.com/articles/9929ed2c9e849f619e09c1e3d084b791.jpg', '日志操作', '# 操作日志注解\n```Java\nimport java.lang.annotation.*;\n\n/**\n * 操作日志注解\n *\n * @author Felix\n * @date 2021/07/28\n */\n@Target(ElementType.METHOD)\n@Retention(RetentionPolicy.RUNTIME)\n@Documented\npublic @interface OptLog {\n\n    /**\n     * @return 操作类型\n     */\n    String optType() default \"\";\n\n}\n\n```\n# 操作日志\n1. 操作日志maaper\n```Java\n/**\n * 操作日志\n *\n * @author Felix\n * @date 2021/08/10\n */\n@Repository\npublic interface OperationLogDao extends BaseMapper<OperationLog> {\n}\n\n```\n2. 操作日志service\n- 前期工作\n- - 操作日志dto\n```Java\n/**\n * 操作日志\n *\n * @author yezhiqiu\n * @date 2021/08/01\n */\n@Data\n@Builder\n@AllArgsConstructor\n@NoArgsConstructor\npublic class OperationLogDTO {\n    /**\n     * 日志id\n     */\n    private Integer id;\n\n    /**\n     * 操作模块\n     */\n    private String optModule;\n\n    /**\n     * 操作路径\n     */\n    private String optUrl;\n\n    /**\n     * 操作类型\n     */\n    private String optType;\n\n    /**\n     * 操作方法\n     */\n    private String optMethod;\n\n    /**\n     * 操作描述\n     */\n    private String optDesc;\n\n    /**\n     * 请求方式\n     */\n    private String requestMethod;\n\n    /**\n     * 请求参数\n     */\n    private String requestParam;\n\n    /**\n     * 返回数据\n     */\n    private String responseData;\n\n    /**\n     * 用户昵称\n     */\n    private String nickname;\n\n    /**\n     * 用户登录ip\n     */\n    private String ipAddress;\n\n    /**\n     * ip来源\n     */\n    private String ipSource;\n\n    /**\n     * 创建时间\n     */\n    private LocalDateTime createTime;\n\n}\n```\n- - 对应的数据库表\n```Java\n/**\n * 操作日志\n *\n * @author Felix\n * @date 2021/08/01\n */\n@Data\n@Builder\n@AllArgsConstructor\n@NoArgsConstructor\n@TableName(\"tb_operation_log\")\npublic class OperationLog {\n\n    /**\n     * 日志id\n     */\n    @TableId(value = \"id\", type = IdType.AUTO)\n    private Integer id;\n\n    /**\n     * 操作模块\n     */\n    private String optModule;\n\n    /**\n     * 操作路径\n     */\n    private String optUrl;\n\n    /**\n     * 操作类型\n     */\n    private String optType;\n\n    /**\n     * 操作方法\n     */\n    private String optMethod;\n\n    /**\n     * 操作描述\n     */\n    private String optDesc;\n\n    /**\n     * 请求方式\n     */\n    private String requestMethod;\n\n    /**\n     * 请求参数\n     */\n    private String requestParam;\n\n    /**\n     * 返回数据\n     */\n    private String responseData;\n\n    /**\n     * 用户id\n     */\n    private Integer userId;\n\n    /**\n     * 用户昵称\n     */\n    private String nickname;\n\n    /**\n     * 用户登录ip\n     */\n    private String ipAddress;\n\n    /**\n     * ip来源\n     */\n    private String ipSource;\n\n    /**\n     * 创建时间\n     */\n    @TableField(fill = FieldFill.INSERT)\n    private LocalDateTime createTime;\n\n    /**\n     * 修改时间\n     */\n    @TableField(fill = FieldFill.UPDATE)\n    private LocalDateTime updateTime;\n\n}\n\n```\n- - 根据条件进行搜索日志消息\n```Java\n/**\n * 查询条件\n *\n * @author Felix\n * @date 2021/07/29\n */\n@Data\n@Builder\n@AllArgsConstructor\n@NoArgsConstructor\n@ApiModel(description = \"查询条件\")\npublic class ConditionVO {\n\n    /**\n     * 页码\n     */\n    @ApiModelProperty(name = \"current\", value = \"页码\", dataType = \"Long\")\n    private Long current;\n\n    /**\n     * 条数\n     */\n    @ApiModelProperty(name = \"size\", value = \"条数\", dataType = \"Long\")\n    private Long size;\n\n    /**\n     * 搜索内容\n     */\n    @ApiModelProperty(name = \"keywords\", value = \"搜索内容\", dataType = \"String\")\n    private String keywords;\n\n    /**\n     * 分类id\n     */\n    @ApiModelProperty(name = \"categoryId\", value = \"分类id\", dataType = \"Integer\")\n    private Integer categoryId;\n\n    /**\n     * 标签id\n     */\n    @ApiModelProperty(name = \"tagId\", value = \"标签id\", dataType = \"Integer\")\n    private Integer tagId;\n\n    /**\n     * 相册id\n     */\n    @ApiModelProperty(name = \"albumId\", value = \"相册id\", dataType = \"Integer\")\n    private Integer albumId;\n\n    /**\n     * 登录类型\n     */\n    @ApiModelProperty(name = \"type\", value = \"登录类型\", dataType = \"Integer\")\n    private Integer loginType;\n\n    /**\n     * 类型\n     */\n    @ApiModelProperty(name = \"type\", value = \"类型\", dataType = \"Integer\")\n    private Integer type;\n\n    /**\n     * 状态\n     */\n    @ApiModelProperty(name = \"status\", value = \"状态\", dataType = \"Integer\")\n    private Integer status;\n\n    /**\n     * 开始时间\n     */\n    @ApiModelProperty(name = \"startTime\", value = \"开始时间\", dataType = \"LocalDateTime\")\n    private LocalDateTime startTime;\n\n    /**\n     * 结束时间\n     */\n    @ApiModelProperty(name = \"endTime\", value = \"结束时间\", dataType = \"LocalDateTime\")\n    private LocalDateTime endTime;\n\n    /**\n     * 是否删除\n     */\n    @ApiModelProperty(name = \"isDelete\", value = \"是否删除\", dataType = \"Integer\")\n    private Integer isDelete;\n\n    /**\n     * 是否审核\n     */\n    @ApiModelProperty(name = \"isReview\", value = \"是否审核\", dataType = \"Integer\")\n    private Integer isReview;\n\n}\n```\n- 操作日志的Service\n```Java\n/**\n * 操作日志服务\n *\n * @author Felix\n * @date 2021/07/29\n */\npublic interface OperationLogService extends IService<OperationLog> {\n\n    /**\n     * 查询日志列表\n     *\n     * @param conditionVO 根据条件搜索文章\n     * @return 日志列表\n     */\n    PageResult<OperationLogDTO> listOperationLogs(ConditionVO conditionVO);\n\n}\n\n```\n3. 操作日志Impl\n\n```Java\n/**\n * 操作日志服务\n *  PageUtils 分页工具类\n * @author Felix\n * @date 2021/08/08\n */\n@Service\npublic class OperationLogServiceImpl extends ServiceImpl<OperationLogDao, OperationLog> implements OperationLogService {\n\n    @Override\n    public PageResult<OperationLogDTO> listOperationLogs(ConditionVO conditionVO) {\n        Page<OperationLog> page = new Page<>(PageUtils.getCurrent(), PageUtils.getSize());\n        // 查询日志列表  通过系统模块或者操作描述(关键字)进行搜索日志消息\n        Page<OperationLog> operationLogPage = this.page(page, new LambdaQueryWrapper<OperationLog>()\n                .like(StringUtils.isNotBlank(conditionVO.getKeywords()), OperationLog::getOptModule, conditionVO.getKeywords())\n                .or()\n                .like(StringUtils.isNotBlank(conditionVO.getKeywords()), OperationLog::getOptDesc, conditionVO.getKeywords())\n                .orderByDesc(OperationLog::getId));\n        List<OperationLogDTO> operationLogDTOList = BeanCopyUtils.copyList(operationLogPage.getRecords(), OperationLogDTO.class);\n        return new PageResult<>(operationLogDTOList, (int) operationLogPage.getTotal());\n    }\n\n}\n```\n4. 操作日志controller\n```Java\n/**\n * 日志控制器\n *\n * @author Felix\n * @date 2021/07/27\n */\n@Api(tags = \"日志模块\")\n@RestController\npublic class LogController {\n    @Autowired\n    private OperationLogService operationLogService;\n\n    /**\n     * 查看操作日志\n     *\n     * @param conditionVO 条件\n     * @return {@link Result<OperationLogDTO>} 日志列表\n     */\n    @ApiOperation(value = \"查看操作日志\")\n    @GetMapping(\"/admin/operation/logs\")\n    public Result<PageResult<OperationLogDTO>> listOperationLogs(ConditionVO conditionVO) {\n        return Result.ok(operationLogService.listOperationLogs(conditionVO));\n    }\n\n    /**\n     * 删除操作日志\n     *\n     * @param logIdList 日志id列表\n     * @return {@link Result<>}\n     */\n    @ApiOperation(value = \"删除操作日志\")\n    @DeleteMapping(\"/admin/operation/logs\")\n    public Result<?> deleteOperationLogs(@RequestBody List<Integer> logIdList) {\n        operationLogService.removeByIds(logIdList);\n        return Result.ok();\n    }\n\n}\n```\n# 效果\n通过系统模块或者操作描述(关键字)进行搜索日志消息\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles/35da2d7b4bf48b4a2644832bbcd42bcc.png)\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles/46aed129263c4e9dc7104860c3c2ed68.png)\n# 通过切入点进行查看日志消息\n1. 首先需要切面\n```Java\n/**\n * 操作日志切面处理\n *\n * @author Felix\n * @date 2021/07/27\n */\n@Aspect\n@Component\npublic class OptLogAspect {\n\n    @Autowired\n    private OperationLogDao operationLogDao;\n\n    /**\n     * 设置操作日志切入点 记录操作日志 在注解的位置切入代码\n     */\n    @Pointcut(\"@annotation(com.minzheng.blog.annotation.OptLog)\")\n    public void optLogPointCut() {}\n\n\n    /**\n     * 正常返回通知，拦截用户操作日志，连接点正常执行完成后执行， 如果连接点抛出异常，则不会执行\n     *\n     * @param value 就是上面切入点的方法\n     * @param joinPoint 切入点\n     * @param keys      返回结果\n     */\n    @AfterReturning(value = \"optLogPointCut()\", returning = \"keys\")\n    @SuppressWarnings(\"unchecked\")\n    public void saveOptLog(JoinPoint joinPoint, Object keys) {\n        // 获取RequestAttributes\n        RequestAttributes requestAttributes = RequestContextHolder.getRequestAttributes();\n        // 从获取RequestAttributes中获取HttpServletRequest的信息\n        HttpServletRequest request = (HttpServletRequest) Objects.requireNonNull(requestAttributes).resolveReference(RequestAttributes.REFERENCE_REQUEST);\n        OperationLog operationLog = new OperationLog();\n        // 从切面织入点处通过反射机制获取织入点处的方法\n        MethodSignature signature = (MethodSignature) joinPoint.getSignature();\n        // 获取切入点所在的方法\n        Method method = signature.getMethod();\n        // 获取操作\n        Api api = (Api) signature.getDeclaringType().getAnnotation(Api.class);\n        ApiOperation apiOperation = method.getAnnotation(ApiOperation.class);\n        OptLog optLog = method.getAnnotation(OptLog.class);\n        // 操作模块\n        operationLog.setOptModule(api.tags()[0]);\n        // 操作类型\n        operationLog.setOptType(optLog.optType());\n        // 操作描述\n        operationLog.setOptDesc(apiOperation.value());\n        // 获取请求的类名\n        String className = joinPoint.getTarget().getClass().getName();\n        // 获取请求的方法名\n        String methodName = method.getName();\n        methodName = className + \".\" + methodName;\n        // 请求方式\n        operationLog.setRequestMethod(Objects.requireNonNull(request).getMethod());\n        // 请求方法\n        operationLog.setOptMethod(methodName);\n        // 请求参数\n        operationLog.setRequestParam(JSON.toJSONString(joinPoint.getArgs()));\n        // 返回结果\n        operationLog.setResponseData(JSON.toJSONString(keys));\n        // 请求用户ID\n        operationLog.setUserId(UserUtils.getLoginUser().getId());\n        // 请求用户\n        operationLog.setNickname(UserUtils.getLoginUser().getNickname());\n        // 请求IP\n        String ipAddress = IpUtils.getIpAddress(request);\n        operationLog.setIpAddress(ipAddress);\n        operationLog.setIpSource(IpUtils.getIpSource(ipAddress));\n        // 请求URL\n        operationLog.setOptUrl(request.getRequestURI());\n        operationLogDao.insert(operationLog);\n    }\n\n}\n```\n2. 在controller中使用切点注解\n```Java\n /**\n     * 添加或修改文章\n     *\n     * @param articleVO 文章信息\n     * @return {@link Result<>}\n     */\n    @OptLog(optType = SAVE_OR_UPDATE)\n    @ApiOperation(value = \"添加或修改文章\")\n    @PostMapping(\"/admin/articles\")\n    public Result<?> saveOrUpdateArticle(@Valid @RequestBody ArticleVO articleVO) {\n        articleService.saveOrUpdateArticle(articleVO);\n        return Result.ok();\n    }\n\n    /**\n     * 修改文章置顶状态\n     *\n     * @param articleTopVO 文章置顶信息\n     * @return {@link Result<>}\n     */\n    @OptLog(optType = UPDATE)\n    @ApiOperation(value = \"修改文章置顶\")\n    @PutMapping(\"/admin/articles/top\")\n    public Result<?> updateArticleTop(@Valid @RequestBody ArticleTopVO articleTopVO) {\n        articleService.updateArticleTop(articleTopVO);\n        return Result.ok();\n    }\n```\n3. 在操作日志可以看到有关这两个接口的消息\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles/b243fa449c283dc03ef27e6600afcfab.png)', 1, '', 0, 0, 1, '2021-07-28 23:23:20.000', '2021-07-29 23:42:08.000');
 INSERT INTO `tb_article` VALUES (107, 1006, 200, 'http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2F1724551123114_1724551178.jpg', 'Windows上安装Go环境并配置环境变量', '# 下载Go环境\n[GO下载官网链接](https://golang.google.cn/dl/)\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724549940.png?t=1724549941561)\n> 解压完成之后得到的文件夹如下\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724550005.png?t=1724550006252)\n# 配置环境变量\n> 右击此电脑–>属性–>高级系统设置–>环境变量，打开环境变量设置窗口\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724550105.png?t=1724550106433)\n> 需要新建两个环境变量配置\n- 一个是 GOROOT ，这个就是 Go 环境所在目录的配置。\n- 另一个是 GOPATH ，这个是 Go 项目的工作目录，你以后开发的代码就写在这个文件夹中。\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724550330.png?t=1724550331346)\n> GOPATH和GOROOT的配置略有不同，我建议配置两个GOPATH目录，第一个用于放 Go 语言的第三方包，第二个用于放自己的开发代码。我们来新建GOPATH。点击系统变量下的新建，在变量名一栏输入GOPATH，在变量值一栏输入任意两个目录，中间用英文分号隔开。\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724550470.png?t=1724550471553)\n> 然后将新建的GOROOT配置到 Path 这个环境变量中去，在系统变量中找到 Path，点击编辑->新建，输入%GOROOT%\\bin，点击确定。并将所有母窗口的确定全部点下，确保环境变量生效。\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724550642.png?t=1724550643747)\n# 检测是否配置成功\n> windows+R 输入 cmd 打开终端，输入go version，如果输出如下图所示，则安装成功。\n```C\n#查看Go版本\ngo version\n\n#查看Go环境变量\ngo env\n```\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724550787.png?t=1724550788285)\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724550812.png?t=1724550812911)\n# 配置 GO111MODULE、GOPROXY、GOSUMDB\n> Go默认的GOPROXY的值是：GOPROXY=https://proxy.golang.org,direct。这个goproxy在使用go get安装第三方库的时候会报错，导致无法下载成功，所以必须要修改一下。\n比如 **https://goproxy.io,direct**(七牛镜像) **https://mirrors.aliyun.com/goproxy**(阿里云镜像)\n```C\n#开启mod模式（项目管理需要用到）\ngo env -w GO111MODULE=on\n#重新设置成七牛镜像源（推荐）或阿里镜像源（用原有的会比较慢）\ngo env -w GOPROXY=https://goproxy.cn,direct\ngo env -w GOPROXY=https://mirrors.aliyun.com/goproxy\n\n#关闭包的MD5校验\ngo env -w GOSUMDB=off\n\n#查看环境变量\ngo env\n\n```\n\n![image.png](http://blog-felix.oss-cn-beijing.aliyuncs.com/articles%2Fimage_1724551056.png?t=1724551057277)', 1, '', 0, 0, 1, '2024-08-25 10:01:18.366', NULL);
+ALTER TABLE `tb_article` ADD COLUMN `publish_time` datetime(3) NULL DEFAULT NULL COMMENT '定时发布时间';
+
+-- ----------------------------
+-- Table structure for tb_article_revision
+-- ----------------------------
+DROP TABLE IF EXISTS `tb_article_revision`;
+CREATE TABLE `tb_article_revision`  (
+  `id` int NOT NULL AUTO_INCREMENT COMMENT '版本id',
+  `article_id` int NOT NULL COMMENT '文章id',
+  `user_id` int NOT NULL COMMENT '修改人id',
+  `article_title` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '文章标题',
+  `article_content` longtext CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '文章内容',
+  `category_name` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NULL DEFAULT NULL COMMENT '分类名',
+  `tag_names` varchar(1000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NULL DEFAULT NULL COMMENT '标签名，json数组',
+  `status` tinyint(1) NOT NULL DEFAULT 1 COMMENT '状态值 1公开 2私密 3草稿',
+  `create_time` datetime NOT NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_article_id`(`article_id`) USING BTREE
+) ENGINE = InnoDB AUTO_INCREMENT = 1 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_unicode_ci ROW_FORMAT = DYNAMIC;
 
 -- ----------------------------
 -- Table structure for tb_article_tag
@@ -223,6 +244,7 @@ CREATE TABLE `tb_comment`  (
   `user_id` int NOT NULL COMMENT '评论用户Id',
   `topic_id` int NULL DEFAULT NULL COMMENT '评论主题id',
   `comment_content` text CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT '评论内容',
+  `comment_source` text CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NULL COMMENT '评论原文',
   `reply_user_id` int NULL DEFAULT NULL COMMENT '回复用户id',
   `parent_id` int NULL DEFAULT NULL COMMENT '父评论id',
   `type` tinyint NOT NULL COMMENT '评论类型 1.文章 2.友链 3.说说',
@@ -230,6 +252,8 @@ CREATE TABLE `tb_comment`  (
   `is_review` tinyint(1) NOT NULL DEFAULT 1 COMMENT '是否审核',
   `create_time` datetime NOT NULL COMMENT '评论时间',
   `update_time` datetime NULL DEFAULT NULL COMMENT '更新时间',
+  `spam_score` int NOT NULL DEFAULT 0 COMMENT '垃圾内容评分',
+  `edit_time` datetime NULL DEFAULT NULL COMMENT '最后编辑时间',
   PRIMARY KEY (`id`) USING BTREE,
   INDEX `fk_comment_user`(`user_id`) USING BTREE,
   INDEX `fk_comment_parent`(`parent_id`) USING BTREE
@@ -238,8 +262,37 @@ CREATE TABLE `tb_comment`  (
 -- ----------------------------
 -- Records of tb_comment
 -- ----------------------------
-INSERT INTO `tb_comment` VALUES (880, 1006, 68, '很好的文章，点赞', NULL, NULL, 1, 0, 1, '2024-08-14 11:03:35', '2024-08-14 11:03:35');
-INSERT INTO `tb_comment` VALUES (881, 1006, 68, '赞', 1006, 880, 1, 0, 1, '2024-08-14 15:25:20', '2024-08-14 15:25:20');
+INSERT INTO `tb_comment` VALUES (880, 1006, 68, '<p>很好的文章，点赞</p>', '很好的文章，点赞', NULL, NULL, 1, 0, 1, '2024-08-14 11:03:35', '2024-08-14 11:03:35', 0, NULL);
+INSERT INTO `tb_comment` VALUES (881, 1006, 68, '<p>赞</p>', '赞', 1006, 880, 1, 0, 1, '2024-08-14 15:25:20', '2024-08-14 15:25:20', 0, NULL);
+
+-- ----------------------------
+-- Table structure for tb_comment_revision
+-- ----------------------------
+DROP TABLE IF EXISTS `tb_comment_revision`;
+CREATE TABLE `tb_comment_revision`  (
+  `id` int NOT NULL AUTO_INCREMENT COMMENT '版本id',
+  `comment_id` int NOT NULL COMMENT '评论id',
+  `comment_content` text CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT '编辑前的评论内容',
+  `create_time` datetime NOT NULL COMMENT '编辑时间',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_comment_id`(`comment_id`) USING BTREE
+) ENGINE = InnoDB AUTO_INCREMENT = 1 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci COMMENT = '评论编辑历史' ROW_FORMAT = DYNAMIC;
+
+-- ----------------------------
+-- Table structure for tb_email_template
+-- ----------------------------
+DROP TABLE IF EXISTS `tb_email_template`;
+CREATE TABLE `tb_email_template`  (
+  `id` int NOT NULL AUTO_INCREMENT COMMENT '模板id',
+  `name` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT '模板名称',
+  `subject` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT '主题模板',
+  `html_content` text CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT 'HTML 正文模板',
+  `text_content` text CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT '纯文本正文模板',
+  `create_time` datetime NOT NULL COMMENT '创建时间',
+  `update_time` datetime NULL DEFAULT NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_name`(`name`) USING BTREE
+) ENGINE = InnoDB AUTO_INCREMENT = 1 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci COMMENT = '自定义邮件模板' ROW_FORMAT = DYNAMIC;
 
 -- ----------------------------
 -- Table structure for tb_friend_link
@@ -333,6 +386,8 @@ CREATE TABLE `tb_message`  (
   `create_time` datetime(3) NULL DEFAULT NULL,
   `update_time` datetime(3) NULL DEFAULT NULL,
   `deleted_at` datetime(3) NULL DEFAULT NULL,
+  `spam_score` int NOT NULL DEFAULT 0 COMMENT '垃圾内容评分',
+  `message_source` text CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NULL,
   PRIMARY KEY (`id`) USING BTREE,
   INDEX `idx_tb_message_deleted_at`(`deleted_at`) USING BTREE
 ) ENGINE = InnoDB AUTO_INCREMENT = 3946 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci ROW_FORMAT = DYNAMIC;
@@ -340,8 +395,41 @@ CREATE TABLE `tb_message`  (
 -- ----------------------------
 -- Records of tb_message
 -- ----------------------------
-INSERT INTO `tb_message` VALUES (3938, '管理员', 'https://static.talkxj.com/avatar/user.png', '测试留言', '127.0.0.1', '', 9, 1, '2022-01-24 23:34:41.000', NULL, NULL);
-INSERT INTO `tb_message` VALUES (3939, '游客', 'https://static.talkxj.com/photos/0bca52afdb2b9998132355d716390c9f.png', '大大', '127.0.0.1', '', 8, 1, '2024-05-06 22:10:54.000', NULL, NULL);
+INSERT INTO `tb_message` VALUES (3938, '管理员', 'https://static.talkxj.com/avatar/user.png', '<p>测试留言</p>', '127.0.0.1', '', 9, 1, '2022-01-24 23:34:41.000', NULL, NULL, 0, '测试留言');
+INSERT INTO `tb_message` VALUES (3939, '游客', 'https://static.talkxj.com/photos/0bca52afdb2b9998132355d716390c9f.png', '<p>大大</p>', '127.0.0.1', '', 8, 1, '2024-05-06 22:10:54.000', NULL, NULL, 0, '大大');
+
+-- ----------------------------
+-- Table structure for tb_notification
+-- ----------------------------
+DROP TABLE IF EXISTS `tb_notification`;
+CREATE TABLE `tb_notification`  (
+  `id` int NOT NULL AUTO_INCREMENT COMMENT '通知id',
+  `user_id` int NOT NULL COMMENT '接收用户id',
+  `from_user_id` int NOT NULL DEFAULT 0 COMMENT '触发通知的用户id，系统通知为 0',
+  `type` tinyint NOT NULL COMMENT '通知类型 1.评论回复 2.评论点赞 3.文章点赞 4.说说点赞 5.评论审核结果',
+  `target_id` int NOT NULL DEFAULT 0 COMMENT '关联的评论、文章或说说id',
+  `content` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NULL DEFAULT NULL COMMENT '通知内容',
+  `path` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NULL DEFAULT NULL COMMENT '站内页面路径',
+  `is_read` tinyint NOT NULL DEFAULT 0 COMMENT '是否已读',
+  `create_time` datetime NOT NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_user_read`(`user_id`, `is_read`) USING BTREE
+) ENGINE = InnoDB AUTO_INCREMENT = 1 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci COMMENT = '站内通知' ROW_FORMAT = DYNAMIC;
+
+-- ----------------------------
+-- Table structure for tb_notification_setting
+-- ----------------------------
+DROP TABLE IF EXISTS `tb_notification_setting`;
+CREATE TABLE `tb_notification_setting`  (
+  `id` int NOT NULL AUTO_INCREMENT COMMENT '设置id',
+  `user_id` int NOT NULL COMMENT '用户id',
+  `type` tinyint NOT NULL COMMENT '通知类型',
+  `is_email` tinyint NOT NULL DEFAULT 0 COMMENT '是否发送邮件',
+  `is_in_app` tinyint NOT NULL DEFAULT 1 COMMENT '是否发送站内通知',
+  `update_time` datetime NULL DEFAULT NULL COMMENT '修改时间',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_user_type`(`user_id`, `type`) USING BTREE
+) ENGINE = InnoDB AUTO_INCREMENT = 1 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci COMMENT = '用户通知设置' ROW_FORMAT = DYNAMIC;
 
 -- ----------------------------
 -- Table structure for tb_operation_log
@@ -546,7 +634,7 @@ CREATE TABLE `tb_resource`  (
   `create_time` datetime NOT NULL COMMENT '创建时间',
   `update_time` datetime NULL DEFAULT NULL COMMENT '修改时间',
   PRIMARY KEY (`id`) USING BTREE
-) ENGINE = InnoDB AUTO_INCREMENT = 294 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci ROW_FORMAT = DYNAMIC;
+) ENGINE = InnoDB AUTO_INCREMENT = 320 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci ROW_FORMAT = DYNAMIC;
 
 -- ----------------------------
 -- Records of tb_resource
@@ -672,6 +760,32 @@ INSERT INTO `tb_resource` VALUES (284, '保存或修改说说', '/admin/talks',
 INSERT INTO `tb_resource` VALUES (285, '删除说说', '/admin/talks', 'DELETE', 278, 0, '2022-01-24 01:31:22', NULL);
 INSERT INTO `tb_resource` VALUES (286, '查看后台说说', '/admin/talks', 'GET', 278, 0, '2022-01-24 01:31:38', NULL);
 INSERT INTO `tb_resource` VALUES (287, '根据id查看后台说说', '/admin/talks/*', 'GET', 278, 0, '2022-01-24 01:31:53', '2022-01-24 01:33:14');
+INSERT INTO `tb_resource` VALUES (294, '系列模块', NULL, NULL, NULL, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (295, '敏感词模块', NULL, NULL, NULL, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (296, '邮件模块', NULL, NULL, NULL, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (297, '导入文章', '/admin/articles/import', 'POST', 168, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (298, '导出文章', '/admin/articles/export', 'POST', 168, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (299, '重建文章搜索索引', '/admin/articles/search/index', 'POST', 168, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (300, '取消文章定时发布', '/admin/articles/*/schedule', 'DELETE', 168, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (301, '查看文章历史版本', '/admin/articles/*/revisions', 'GET', 168, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (302, '对比文章历史版本', '/admin/articles/*/revisions/diff', 'GET', 168, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (303, '恢复文章历史版本', '/admin/articles/*/revisions/*/restore', 'POST', 168, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (304, '查看后台系列列表', '/admin/series', 'GET', 294, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (305, '根据id查看后台系列', '/admin/series/*', 'GET', 294, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (306, '保存或修改系列', '/admin/series', 'POST', 294, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (307, '删除系列', '/admin/series', 'DELETE', 294, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (308, '查看敏感词列表', '/admin/sensitive-words', 'GET', 295, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (309, '保存或修改敏感词', '/admin/sensitive-words', 'POST', 295, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (310, '删除敏感词', '/admin/sensitive-words', 'DELETE', 295, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (311, '导入敏感词', '/admin/sensitive-words/import', 'POST', 295, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (312, '查看邮件模板列表', '/admin/email-templates', 'GET', 296, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (313, '保存邮件模板', '/admin/email-templates', 'POST', 296, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (314, '删除邮件模板', '/admin/email-templates/*', 'DELETE', 296, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (315, '查看发送失败的邮件', '/admin/emails/dead-letters', 'GET', 296, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (316, '重新发送失败的邮件', '/admin/emails/dead-letters/retry', 'POST', 296, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (317, '清空发送失败的邮件', '/admin/emails/dead-letters', 'DELETE', 296, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (318, '取消说说定时发布', '/admin/talks/*/schedule', 'DELETE', 278, 0, '2026-10-18 00:00:00', NULL);
+INSERT INTO `tb_resource` VALUES (319, '强制用户下线', '/admin/users/*/sessions', 'DELETE', 172, 0, '2026-10-18 00:00:00', NULL);
 
 -- ----------------------------
 -- Table structure for tb_role
@@ -844,7 +958,7 @@ CREATE TABLE `tb_role_resource`  (
   `role_id` int NULL DEFAULT NULL COMMENT '角色id',
   `resource_id` int NULL DEFAULT NULL COMMENT '权限id',
   PRIMARY KEY (`id`) USING BTREE
-) ENGINE = InnoDB AUTO_INCREMENT = 4886 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci ROW_FORMAT = DYNAMIC;
+) ENGINE = InnoDB AUTO_INCREMENT = 4916 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci ROW_FORMAT = DYNAMIC;
 
 -- ----------------------------
 -- Records of tb_role_resource
@@ -985,6 +1099,78 @@ INSERT INTO `tb_role_resource` VALUES (4882, 3, 210);
 INSERT INTO `tb_role_resource` VALUES (4883, 3, 282);
 INSERT INTO `tb_role_resource` VALUES (4884, 3, 286);
 INSERT INTO `tb_role_resource` VALUES (4885, 3, 287);
+INSERT INTO `tb_role_resource` VALUES (4886, 1, 297);
+INSERT INTO `tb_role_resource` VALUES (4887, 1, 298);
+INSERT INTO `tb_role_resource` VALUES (4888, 1, 299);
+INSERT INTO `tb_role_resource` VALUES (4889, 1, 300);
+INSERT INTO `tb_role_resource` VALUES (4890, 1, 301);
+INSERT INTO `tb_role_resource` VALUES (4891, 1, 302);
+INSERT INTO `tb_role_resource` VALUES (4892, 1, 303);
+INSERT INTO `tb_role_resource` VALUES (4893, 1, 304);
+INSERT INTO `tb_role_resource` VALUES (4894, 1, 305);
+INSERT INTO `tb_role_resource` VALUES (4895, 1, 306);
+INSERT INTO `tb_role_resource` VALUES (4896, 1, 307);
+INSERT INTO `tb_role_resource` VALUES (4897, 1, 308);
+INSERT INTO `tb_role_resource` VALUES (4898, 1, 309);
+INSERT INTO `tb_role_resource` VALUES (4899, 1, 310);
+INSERT INTO `tb_role_resource` VALUES (4900, 1, 311);
+INSERT INTO `tb_role_resource` VALUES (4901, 1, 312);
+INSERT INTO `tb_role_resource` VALUES (4902, 1, 313);
+INSERT INTO `tb_role_resource` VALUES (4903, 1, 314);
+INSERT INTO `tb_role_resource` VALUES (4904, 1, 315);
+INSERT INTO `tb_role_resource` VALUES (4905, 1, 316);
+INSERT INTO `tb_role_resource` VALUES (4906, 1, 317);
+INSERT INTO `tb_role_resource` VALUES (4907, 1, 318);
+INSERT INTO `tb_role_resource` VALUES (4908, 1, 319);
+INSERT INTO `tb_role_resource` VALUES (4909, 3, 301);
+INSERT INTO `tb_role_resource` VALUES (4910, 3, 302);
+INSERT INTO `tb_role_resource` VALUES (4911, 3, 304);
+INSERT INTO `tb_role_resource` VALUES (4912, 3, 305);
+INSERT INTO `tb_role_resource` VALUES (4913, 3, 308);
+INSERT INTO `tb_role_resource` VALUES (4914, 3, 312);
+INSERT INTO `tb_role_resource` VALUES (4915, 3, 315);
+
+-- ----------------------------
+-- Table structure for tb_sensitive_word
+-- ----------------------------
+DROP TABLE IF EXISTS `tb_sensitive_word`;
+CREATE TABLE `tb_sensitive_word`  (
+  `id` int NOT NULL AUTO_INCREMENT COMMENT '敏感词id',
+  `word` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT '敏感词',
+  `action` tinyint NOT NULL DEFAULT 1 COMMENT '处理方式 1.替换 2.拒绝 3.审核',
+  `create_time` datetime NOT NULL COMMENT '创建时间',
+  `update_time` datetime NULL DEFAULT NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_word`(`word`) USING BTREE
+) ENGINE = InnoDB AUTO_INCREMENT = 1 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci COMMENT = '敏感词' ROW_FORMAT = DYNAMIC;
+
+-- ----------------------------
+-- Table structure for tb_series
+-- ----------------------------
+DROP TABLE IF EXISTS `tb_series`;
+CREATE TABLE `tb_series`  (
+  `id` int NOT NULL AUTO_INCREMENT COMMENT '系列id',
+  `series_title` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT '系列标题',
+  `series_desc` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NULL DEFAULT NULL COMMENT '系列描述',
+  `series_cover` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NULL DEFAULT NULL COMMENT '系列封面',
+  `create_time` datetime NOT NULL COMMENT '创建时间',
+  `update_time` datetime NULL DEFAULT NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`) USING BTREE
+) ENGINE = InnoDB AUTO_INCREMENT = 1 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci COMMENT = '文章系列' ROW_FORMAT = DYNAMIC;
+
+-- ----------------------------
+-- Table structure for tb_series_article
+-- ----------------------------
+DROP TABLE IF EXISTS `tb_series_article`;
+CREATE TABLE `tb_series_article`  (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `series_id` int NOT NULL COMMENT '系列id',
+  `article_id` int NOT NULL COMMENT '文章id',
+  `sort` int NOT NULL COMMENT '文章在系列中的顺序',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_article_id`(`article_id`) USING BTREE,
+  INDEX `idx_series_id`(`series_id`) USING BTREE
+) ENGINE = InnoDB AUTO_INCREMENT = 1 CHARACTER SET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci COMMENT = '系列文章关联' ROW_FORMAT = DYNAMIC;
 
 -- ----------------------------
 -- Table structure for tb_tag
@@ -1019,7 +1205,7 @@ CREATE TABLE `tb_talk`  (
   `content` varchar(2000) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL COMMENT '说说内容',
   `images` varchar(2500) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NULL DEFAULT NULL COMMENT '图片',
   `is_top` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否置顶',
-  `status` tinyint(1) NOT NULL DEFAULT 1 COMMENT '状态 1.公开 2.私密',
+  `status` tinyint(1) NOT NULL DEFAULT 1 COMMENT '状态 1.公开 2.私密 3.草稿',
   `create_time` datetime NOT NULL COMMENT '创建时间',
   `update_time` datetime NULL DEFAULT NULL COMMENT '更新时间',
   PRIMARY KEY (`id`) USING BTREE
@@ -1029,6 +1215,7 @@ CREATE TABLE `tb_talk`  (
 -- Records of tb_talk
 -- ----------------------------
 INSERT INTO `tb_talk` VALUES (49, 1, '迷恋技术，保持对代码的热情<img src=\"https://blog-felix.oss-cn-beijing.aliyuncs.com/articles/goutou.jpeg\" width=\"24\" height=\"24\" alt=\"[狗头]\" style=\"margin: 0 1px;vertical-align: text-bottom\">', NULL, 0, 1, '2022-01-24 23:34:59', NULL);
+ALTER TABLE `tb_talk` ADD COLUMN `publish_time` datetime NULL DEFAULT NULL COMMENT '定时发布时间';
 
 -- ----------------------------
 -- Table structure for tb_unique_view
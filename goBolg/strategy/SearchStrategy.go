@@ -7,5 +7,23 @@ import (
 
 // SearchStrategy 搜索策略接口
 type SearchStrategy interface {
-	SearchArticle(ctx context.Context, keywords string) ([]dto.ArticleSearchDTO, error)
+	// 分页搜索文章，current 从 1 开始
+	SearchArticle(ctx context.Context, keywords string, current int, size int) ([]dto.ArticleSearchDTO, error)
+}
+
+// SearchIndexStrategy 需要自行维护索引的搜索策略，文章变更后要同步更新索引
+type SearchIndexStrategy interface {
+	SearchStrategy
+
+	// 重新索引文章，已删除或不存在的文章从索引中移除
+	IndexArticles(ctx context.Context, articleIdList []int) error
+
+	// 从索引中移除文章
+	DeleteArticles(ctx context.Context, articleIdList []int) error
+
+	// 重建全部索引，返回索引的文章数量
+	RebuildIndex(ctx context.Context) (int, error)
+
+	// 关闭索引
+	Close() error
 }
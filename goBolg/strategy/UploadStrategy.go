@@ -2,10 +2,12 @@ package strategy
 
 import (
 	"goBolg/enums"
+	"io"
 	"mime/multipart"
 )
 
 type UploadStrategy interface {
 	UploadFile(fileHeader *multipart.FileHeader, pathEnum enums.FilePathEnum) (string, error)
+	UploadFileStream(fileName string, inputStream io.Reader, pathEnum enums.FilePathEnum) (string, error)
 	Aliyun_Oss_GetFileURL(fileKey string) (string, error)
 }
@@ -22,10 +22,52 @@ func NewSearchStrategyContext(searchMode string, searchStrategyMap map[string]st
 }
 
 // ExecuteSearchStrategy 执行搜索策略
-func (c *SearchStrategyContext) ExecuteSearchStrategy(ctx context.Context, keywords string) ([]dto.ArticleSearchDTO, error) {
+func (c *SearchStrategyContext) ExecuteSearchStrategy(ctx context.Context, keywords string, current int, size int) ([]dto.ArticleSearchDTO, error) {
 	strategy, exists := c.searchStrategyMap[c.searchMode]
 	if !exists {
 		return nil, fmt.Errorf("search strategy not found for mode: %s", c.searchMode)
 	}
-	return strategy.SearchArticle(ctx, keywords)
+	return strategy.SearchArticle(ctx, keywords, current, size)
+}
+
+// UpdateArticleIndex 同步文章索引，当前策略不需要索引时直接返回
+func (c *SearchStrategyContext) UpdateArticleIndex(ctx context.Context, articleIdList []int) error {
+	indexStrategy, ok := c.searchStrategyMap[c.searchMode].(strategy.SearchIndexStrategy)
+	if !ok || len(articleIdList) == 0 {
+		return nil
+	}
+	return indexStrategy.IndexArticles(ctx, articleIdList)
+}
+
+// DeleteArticleIndex 从索引中移除文章，当前策略不需要索引时直接返回
+func (c *SearchStrategyContext) DeleteArticleIndex(ctx context.Context, articleIdList []int) error {
+	indexStrategy, ok := c.searchStrategyMap[c.searchMode].(strategy.SearchIndexStrategy)
+	if !ok || len(articleIdList) == 0 {
+		return nil
+	}
+	return indexStrategy.DeleteArticles(ctx, articleIdList)
+}
+
+// RebuildArticleIndex 重建当前策略的索引，返回索引的文章数量
+func (c *SearchStrategyContext) RebuildArticleIndex(ctx context.Context) (int, error) {
+	indexStrategy, ok := c.searchStrategyMap[c.searchMode].(strategy.SearchIndexStrategy)
+	if !ok {
+		return 0, fmt.Errorf("search strategy %s does not maintain an index", c.searchMode)
+	}
+	return indexStrategy.RebuildIndex(ctx)
+}
+
+// Close 关闭所有维护索引的搜索策略
+func (c *SearchStrategyContext) Close() error {
+	var firstErr error
+	for mode, searchStrategy := range c.searchStrategyMap {
+		indexStrategy, ok := searchStrategy.(strategy.SearchIndexStrategy)
+		if !ok {
+			continue
+		}
+		if err := indexStrategy.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close %s search index: %w", mode, err)
+		}
+	}
+	return firstErr
 }
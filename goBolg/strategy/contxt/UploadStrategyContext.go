@@ -6,6 +6,7 @@ import (
 	"goBolg/enums" // Ensure enums package is imported
 	"goBolg/strategy"
 	"goBolg/strategy/strategyImpl"
+	"io"
 	"log"
 	"mime/multipart"
 )
@@ -88,3 +89,32 @@ func (c *UploadStrategyContext) ExecuteUploadStrategy(fileHeader *multipart.File
 	log.Printf("File uploaded successfully: %s", fileUrl)
 	return fileUrl, nil
 }
+
+// ExecuteUploadStrategyStream 上传文件流并返回访问地址
+func (c *UploadStrategyContext) ExecuteUploadStrategyStream(fileName string, inputStream io.Reader, pathEnum enums.FilePathEnum) (string, error) {
+	if c == nil || c.Config == nil || c.Config.Upload == nil {
+		return "", fmt.Errorf("upload strategy context is not initialized")
+	}
+
+	strategy, ok := c.StrategyMap[c.Config.Upload.Mode]
+	if !ok {
+		err := fmt.Errorf("unsupported upload mode: %s", c.Config.Upload.Mode)
+		log.Printf("Error: %v", err)
+		return "", err
+	}
+
+	fileKey, err := strategy.UploadFileStream(fileName, inputStream, pathEnum)
+	if err != nil {
+		log.Printf("Error uploading file stream: %v", err)
+		return "", err
+	}
+
+	fileUrl, err := strategy.Aliyun_Oss_GetFileURL(fileKey)
+	if err != nil {
+		log.Printf("Error getting file URL: %v", err)
+		return "", err
+	}
+
+	log.Printf("File stream uploaded successfully: %s", fileUrl)
+	return fileUrl, nil
+}
@@ -0,0 +1,339 @@
+package strategyImpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"goBolg/constant"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/model"
+	"goBolg/utils"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// bleve 搜索配置
+const (
+	bleveSearchBatchSize = 100 // 每次从索引读取的命中数量
+	bleveIndexBatchSize  = 100 // 批量索引的文章数量
+	bleveTitleBoost      = 2.0 // 标题命中的权重
+	defaultBleveIndex    = "data/article.bleve"
+)
+
+// bleveArticle 索引中的文章文档
+type bleveArticle struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// BleveSearchStrategyImpl 基于 bleve 本地索引的搜索策略实现，使用 CJK 分词并按相关度排序
+type BleveSearchStrategyImpl struct {
+	ArticleDao dao.ArticleDao
+	IndexPath  string // 索引目录
+
+	mu    sync.RWMutex
+	index bleve.Index
+}
+
+// SearchArticle 按相关度分页搜索文章，标题或正文需要包含全部关键词
+func (s *BleveSearchStrategyImpl) SearchArticle(ctx context.Context, keywords string, current int, size int) ([]dto.ArticleSearchDTO, error) {
+	keywords = strings.TrimSpace(keywords)
+	if keywords == "" {
+		return []dto.ArticleSearchDTO{}, nil
+	}
+
+	titleQuery := bleve.NewMatchQuery(keywords)
+	titleQuery.SetField("title")
+	titleQuery.SetOperator(query.MatchQueryOperatorAnd)
+	titleQuery.SetBoost(bleveTitleBoost)
+	contentQuery := bleve.NewMatchQuery(keywords)
+	contentQuery.SetField("content")
+	contentQuery.SetOperator(query.MatchQueryOperatorAnd)
+	searchQuery := bleve.NewDisjunctionQuery(titleQuery, contentQuery)
+
+	// 高亮使用与索引相同的分词结果，中文按双字切分，相邻的命中在高亮时合并
+	var terms []string
+	err := s.withIndex(ctx, func(index bleve.Index) error {
+		terms = bleveSearchTerms(index.Mapping(), keywords)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search index: %w", err)
+	}
+
+	// 索引中包含草稿和私密文章，按数据库中的状态过滤后再分页，因此分批读取命中结果直到凑满当前页
+	offset := (current - 1) * size
+	var articles []dto.ArticleSearchDTO
+	for from := 0; len(articles) < offset+size; from += bleveSearchBatchSize {
+		request := bleve.NewSearchRequestOptions(searchQuery, bleveSearchBatchSize, from, false)
+		request.Fields = []string{"title", "content"}
+
+		var result *bleve.SearchResult
+		err := s.withIndex(ctx, func(index bleve.Index) error {
+			var err error
+			result, err = index.SearchInContext(ctx, request)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search index: %w", err)
+		}
+
+		publicArticles, err := s.filterPublicHits(ctx, result.Hits, terms)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, publicArticles...)
+		if len(result.Hits) < bleveSearchBatchSize {
+			break
+		}
+	}
+
+	if offset >= len(articles) {
+		return []dto.ArticleSearchDTO{}, nil
+	}
+	return articles[offset:min(len(articles), offset+size)], nil
+}
+
+// filterPublicHits 保留已发布的公开文章，并保持相关度顺序
+func (s *BleveSearchStrategyImpl) filterPublicHits(ctx context.Context, hits search.DocumentMatchCollection, terms []string) ([]dto.ArticleSearchDTO, error) {
+	articleIdList := make([]int, 0, len(hits))
+	hitMap := make(map[int]*search.DocumentMatch, len(hits))
+	for _, hit := range hits {
+		articleId, err := strconv.Atoi(hit.ID)
+		if err != nil {
+			continue
+		}
+		articleIdList = append(articleIdList, articleId)
+		hitMap[articleId] = hit
+	}
+	publicIdList, err := s.ArticleDao.FilterPublicArticleIds(ctx, articleIdList)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]dto.ArticleSearchDTO, 0, len(publicIdList))
+	for _, articleId := range publicIdList {
+		hit := hitMap[articleId]
+		title, _ := hit.Fields["title"].(string)
+		content, _ := hit.Fields["content"].(string)
+		articles = append(articles, dto.ArticleSearchDTO{
+			ID:             articleId,
+			ArticleTitle:   utils.HighlightSearchText(title, terms),
+			ArticleContent: utils.HighlightSnippet(content, terms, searchSnippetLength),
+			IsDelete:       int(constants.False),
+			Status:         enums.PUBLIC.Status,
+		})
+	}
+	return articles, nil
+}
+
+// IndexArticles 重新索引文章，已删除或不存在的文章从索引中移除
+func (s *BleveSearchStrategyImpl) IndexArticles(ctx context.Context, articleIdList []int) error {
+	articles, err := s.ArticleDao.ListArticlesByIds(ctx, articleIdList)
+	if err != nil {
+		return err
+	}
+
+	return s.withIndex(ctx, func(index bleve.Index) error {
+		batch := index.NewBatch()
+		found := make(map[int]bool, len(articles))
+		for _, article := range articles {
+			found[article.ID] = true
+			if article.IsDelete != nil && *article.IsDelete != int(constants.False) {
+				batch.Delete(strconv.Itoa(article.ID))
+				continue
+			}
+			if err := batch.Index(strconv.Itoa(article.ID), toBleveArticle(article)); err != nil {
+				return err
+			}
+		}
+		for _, articleId := range articleIdList {
+			if !found[articleId] {
+				batch.Delete(strconv.Itoa(articleId))
+			}
+		}
+		return index.Batch(batch)
+	})
+}
+
+// DeleteArticles 从索引中移除文章
+func (s *BleveSearchStrategyImpl) DeleteArticles(ctx context.Context, articleIdList []int) error {
+	return s.withIndex(ctx, func(index bleve.Index) error {
+		batch := index.NewBatch()
+		for _, articleId := range articleIdList {
+			batch.Delete(strconv.Itoa(articleId))
+		}
+		return index.Batch(batch)
+	})
+}
+
+// RebuildIndex 删除现有索引并从数据库重建，重建期间的搜索和更新会等待完成
+func (s *BleveSearchStrategyImpl) RebuildIndex(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.index != nil {
+		if err := s.index.Close(); err != nil {
+			return 0, fmt.Errorf("failed to close index: %w", err)
+		}
+		s.index = nil
+	}
+	if err := os.RemoveAll(s.indexPath()); err != nil {
+		return 0, fmt.Errorf("failed to remove index: %w", err)
+	}
+
+	index, count, err := s.createIndex(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.index = index
+	return count, nil
+}
+
+// Close 关闭索引
+func (s *BleveSearchStrategyImpl) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.index == nil {
+		return nil
+	}
+	err := s.index.Close()
+	s.index = nil
+	return err
+}
+
+// indexPath 索引目录，未配置时使用默认目录
+func (s *BleveSearchStrategyImpl) indexPath() string {
+	if s.IndexPath == "" {
+		return defaultBleveIndex
+	}
+	return s.IndexPath
+}
+
+// withIndex 在读锁保护下使用索引，索引不存在时先创建并导入全部文章
+func (s *BleveSearchStrategyImpl) withIndex(ctx context.Context, fn func(index bleve.Index) error) error {
+	if err := s.openIndex(ctx); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.index == nil {
+		return errors.New("search index is closed")
+	}
+	return fn(s.index)
+}
+
+// openIndex 打开已有索引，首次使用时创建索引
+func (s *BleveSearchStrategyImpl) openIndex(ctx context.Context) error {
+	s.mu.RLock()
+	opened := s.index != nil
+	s.mu.RUnlock()
+	if opened {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.index != nil {
+		return nil
+	}
+
+	index, err := bleve.Open(s.indexPath())
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, _, err = s.createIndex(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open index %s: %w", s.indexPath(), err)
+	}
+	s.index = index
+	return nil
+}
+
+// createIndex 创建索引并导入全部未删除的文章，返回索引的文章数量
+func (s *BleveSearchStrategyImpl) createIndex(ctx context.Context) (bleve.Index, int, error) {
+	if err := os.MkdirAll(filepath.Dir(s.indexPath()), 0755); err != nil {
+		return nil, 0, fmt.Errorf("failed to create index directory: %w", err)
+	}
+	index, err := bleve.New(s.indexPath(), newBleveIndexMapping())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create index: %w", err)
+	}
+
+	count := 0
+	lastId := 0
+	for {
+		articles, err := s.ArticleDao.ListIndexArticles(ctx, lastId, bleveIndexBatchSize)
+		if err == nil && len(articles) > 0 {
+			batch := index.NewBatch()
+			for _, article := range articles {
+				if err = batch.Index(strconv.Itoa(article.ID), toBleveArticle(article)); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = index.Batch(batch)
+			}
+		}
+		if err != nil {
+			index.Close()
+			os.RemoveAll(s.indexPath())
+			return nil, 0, fmt.Errorf("failed to index articles: %w", err)
+		}
+		if len(articles) == 0 {
+			break
+		}
+		count += len(articles)
+		lastId = articles[len(articles)-1].ID
+	}
+	return index, count, nil
+}
+
+// newBleveIndexMapping 标题和正文使用 CJK 分词，并保存原文用于高亮
+func newBleveIndexMapping() mapping.IndexMapping {
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.Analyzer = cjk.AnalyzerName
+	textFieldMapping.Store = true
+	textFieldMapping.IncludeTermVectors = true
+
+	articleMapping := bleve.NewDocumentMapping()
+	articleMapping.AddFieldMappingsAt("title", textFieldMapping)
+	articleMapping.AddFieldMappingsAt("content", textFieldMapping)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = articleMapping
+	indexMapping.DefaultAnalyzer = cjk.AnalyzerName
+	return indexMapping
+}
+
+// toBleveArticle 正文去掉 markdown 标记后再索引
+func toBleveArticle(article model.Article) bleveArticle {
+	return bleveArticle{
+		Title:   article.ArticleTitle,
+		Content: utils.MarkdownToText(article.ArticleContent, 0),
+	}
+}
+
+// bleveSearchTerms 使用索引的分词器拆分关键词，没有找到分词器时按空白拆分
+func bleveSearchTerms(indexMapping mapping.IndexMapping, keywords string) []string {
+	analyzer := indexMapping.AnalyzerNamed(cjk.AnalyzerName)
+	if analyzer == nil {
+		return utils.SplitSearchTerms(keywords)
+	}
+	var terms []string
+	for _, token := range analyzer.Analyze([]byte(keywords)) {
+		terms = append(terms, string(token.Term))
+	}
+	return terms
+}
@@ -0,0 +1,76 @@
+package strategyImpl
+
+import (
+	"context"
+	"goBolg/dao"
+	"goBolg/dto"
+	"goBolg/enums"
+	"goBolg/utils"
+	"strings"
+	"time"
+)
+
+// fullTextTitleWeight 标题命中的相关度权重
+const fullTextTitleWeight = 2
+
+// fullTextOperators 布尔模式中有特殊含义的字符
+const fullTextOperators = `+-<>()~*"@`
+
+// MySqlFullTextSearchStrategyImpl 基于 ngram 全文索引的 MySQL 搜索策略实现，按相关度排序
+type MySqlFullTextSearchStrategyImpl struct {
+	ArticleDao dao.ArticleDao
+}
+
+// SearchArticle 分页搜索文章，每个关键词都必须出现在标题或正文中，标题命中的权重更高
+func (s *MySqlFullTextSearchStrategyImpl) SearchArticle(ctx context.Context, keywords string, current int, size int) ([]dto.ArticleSearchDTO, error) {
+	words := fullTextWords(keywords)
+	if len(words) == 0 {
+		return []dto.ArticleSearchDTO{}, nil
+	}
+
+	against := buildFullTextAgainst(words)
+	var articles []dto.ArticleSearchDTO
+	err := s.ArticleDao.GetDb().WithContext(ctx).Table("tb_article").
+		Select("id, article_title, article_content, "+
+			"MATCH(article_title, article_content) AGAINST(? IN BOOLEAN MODE) + MATCH(article_title) AGAINST(? IN BOOLEAN MODE) * ? AS score",
+			against, against, fullTextTitleWeight).
+		Where("MATCH(article_title, article_content) AGAINST(? IN BOOLEAN MODE)", against).
+		Where("is_delete = ? AND status = ?", 0, enums.PUBLIC.Status).
+		Where("publish_time IS NULL OR publish_time <= ?", time.Now()).
+		Order("score DESC, id DESC").
+		Offset((current - 1) * size).
+		Limit(size).
+		Scan(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	highlightSearchArticles(articles, utils.SplitSearchTerms(strings.Join(words, " ")))
+	return articles, nil
+}
+
+// fullTextWords 按空白拆分关键词，去掉用户输入中布尔模式的运算符
+func fullTextWords(keywords string) []string {
+	var words []string
+	for _, word := range strings.Fields(keywords) {
+		word = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(fullTextOperators, r) {
+				return -1
+			}
+			return r
+		}, word)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// buildFullTextAgainst 将关键词转换为布尔模式查询，每个词作为必须出现的短语
+func buildFullTextAgainst(words []string) string {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, `+"`+word+`"`)
+	}
+	return strings.Join(terms, " ")
+}
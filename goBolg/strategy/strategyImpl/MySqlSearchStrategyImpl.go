@@ -3,52 +3,54 @@ package strategyImpl
 import (
 	"context"
 	"fmt"
-	"goBolg/constant"
 	"goBolg/dao"
 	"goBolg/dto"
 	"goBolg/enums"
-	"strings"
+	"goBolg/utils"
 )
 
+// searchSnippetLength 搜索结果中正文片段的长度，按字符计算
+const searchSnippetLength = 200
+
 // MySqlSearchStrategyImpl MySQL搜索策略实现
 type MySqlSearchStrategyImpl struct {
 	ArticleDao dao.ArticleDao
 }
 
-// SearchArticle 搜索文章
-func (s *MySqlSearchStrategyImpl) SearchArticle(ctx context.Context, keywords string) ([]dto.ArticleSearchDTO, error) {
-	if keywords == "" {
+// SearchArticle 搜索文章，标题或正文需要包含每个关键词
+func (s *MySqlSearchStrategyImpl) SearchArticle(ctx context.Context, keywords string, current int, size int) ([]dto.ArticleSearchDTO, error) {
+	terms := utils.SplitSearchTerms(keywords)
+	if len(terms) == 0 {
 		return []dto.ArticleSearchDTO{}, nil
 	}
 
 	var articles []dto.ArticleSearchDTO
 
 	// 查询文章
-	err := s.ArticleDao.GetDb().WithContext(ctx).Table("tb_article").
+	db := s.ArticleDao.GetDb().WithContext(ctx).Table("tb_article").
 		Select("id, article_title, article_content").
-		Where("is_delete = ? AND status = ? AND (article_title LIKE ? OR article_content LIKE ?)",
-			false, enums.PUBLIC.Status, fmt.Sprintf("%%%s%%", keywords), fmt.Sprintf("%%%s%%", keywords)).
+		Where("is_delete = ? AND status = ?", false, enums.PUBLIC.Status)
+	for _, term := range terms {
+		db = db.Where("article_title LIKE ? OR article_content LIKE ?", fmt.Sprintf("%%%s%%", term), fmt.Sprintf("%%%s%%", term))
+	}
+	err := db.Order("id DESC").
+		Offset((current - 1) * size).
+		Limit(size).
 		Find(&articles).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// 高亮处理
+	highlightSearchArticles(articles, terms)
+	return articles, nil
+}
+
+// highlightSearchArticles 高亮文章标题中的关键词，正文去掉 markdown 标记后只保留关键词最密集的片段
+func highlightSearchArticles(articles []dto.ArticleSearchDTO, terms []string) {
 	for i, article := range articles {
-		// 文章内容高亮
-		articleContent := article.ArticleContent
-		index := strings.Index(articleContent, keywords)
-		if index != -1 {
-			preIndex := max(0, index-25)
-			postIndex := min(len(articleContent), index+len(keywords)+175)
-			highlightedContent := articleContent[preIndex:postIndex]
-			articles[i].ArticleContent = strings.ReplaceAll(highlightedContent, keywords, fmt.Sprintf("%s%s%s", constants.PreTag, keywords, constants.PostTag))
-		}
-		// 文章标题高亮
-		articles[i].ArticleTitle = strings.ReplaceAll(article.ArticleTitle, keywords, fmt.Sprintf("%s%s%s", constants.PreTag, keywords, constants.PostTag))
+		articles[i].ArticleTitle = utils.HighlightSearchText(article.ArticleTitle, terms)
+		articles[i].ArticleContent = utils.BuildSearchSnippet(article.ArticleContent, terms, searchSnippetLength)
 	}
-
-	return articles, nil
 }
 
 // max 返回两个整数中的最大值
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"goBolg/enums"
+	"io"
 	"io/ioutil"
 	"log"
 	"mime/multipart"
@@ -85,6 +86,38 @@ func (o *osscurd) UploadFile(fileHeader *multipart.FileHeader, pathEnum enums.Fi
 	}
 }
 
+// UploadFileStream 上传文件流，文件名保持不变
+func (o *osscurd) UploadFileStream(fileName string, inputStream io.Reader, pathEnum enums.FilePathEnum) (string, error) {
+	log.Printf("Starting file stream upload: %s", fileName)
+	fileKey := pathEnum.Path + fileName
+
+	exist, err := o.client.IsBucketExist(o.BucketName)
+	if err != nil {
+		log.Printf("Error checking if bucket exists: %v", err)
+		return "", err
+	}
+
+	if !exist {
+		if err := o.client.CreateBucket(o.BucketName); err != nil {
+			log.Printf("Error creating bucket: %v", err)
+			return "", err
+		}
+	}
+
+	bucketIns, err := o.client.Bucket(o.BucketName)
+	if err != nil {
+		log.Printf("Error getting bucket instance: %v", err)
+		return "", err
+	}
+
+	if err = bucketIns.PutObject(fileKey, inputStream); err != nil {
+		log.Printf("Error putting object in bucket: %v", err)
+		return "", err
+	}
+	log.Printf("File stream uploaded successfully with key: %s", fileKey)
+	return fileKey, nil
+}
+
 func (o *osscurd) Aliyun_Oss_GetFileURL(fileKey string) (string, error) {
 	log.Printf("Generating file URL for key: %s", fileKey)
 	// 2. bucket
@@ -0,0 +1,8 @@
+package templates
+
+import "embed"
+
+// EmailFS 内置的邮件模板，每个模板分为 HTML 正文 name.html 和纯文本正文 name.txt 两个文件
+//
+//go:embed email/*
+var EmailFS embed.FS
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cursor 游标分页的位置，记录上一页最后一条记录的创建时间和 id
+type Cursor struct {
+	CreateTime time.Time
+	ID         int
+}
+
+// EncodeCursor 将记录的创建时间和 id 编码为不透明的游标
+func EncodeCursor(createTime time.Time, id int) string {
+	value := strconv.FormatInt(createTime.UnixNano(), 10) + "_" + strconv.Itoa(id)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// DecodeCursor 解析游标，空字符串表示从第一条记录开始
+func DecodeCursor(cursor string) (*Cursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	nanos, id, found := strings.Cut(string(value), "_")
+	if !found {
+		return nil, errors.New("invalid cursor")
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	recordId, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &Cursor{CreateTime: time.Unix(0, unixNano), ID: recordId}, nil
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		createTime time.Time
+		id         int
+	}{
+		{"毫秒精度", time.Date(2024, 5, 1, 12, 30, 0, 123000000, time.UTC), 42},
+		{"纳秒精度", time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC), 1},
+		{"零值 id", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := DecodeCursor(EncodeCursor(tt.createTime, tt.id))
+			if err != nil {
+				t.Fatalf("DecodeCursor returned error: %v", err)
+			}
+			if !cursor.CreateTime.Equal(tt.createTime) || cursor.ID != tt.id {
+				t.Errorf("got (%v, %d), want (%v, %d)", cursor.CreateTime, cursor.ID, tt.createTime, tt.id)
+			}
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name    string
+		cursor  string
+		wantNil bool
+		wantErr bool
+	}{
+		{"空游标", "", true, false},
+		{"不是 base64", "!!!", true, true},
+		{"缺少分隔符", encode("123"), true, true},
+		{"时间不是数字", encode("abc_1"), true, true},
+		{"id 不是数字", encode("123_abc"), true, true},
+		{"合法游标", encode("123_4"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := DecodeCursor(tt.cursor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeCursor(%q) error = %v, wantErr %v", tt.cursor, err, tt.wantErr)
+			}
+			if (cursor == nil) != tt.wantNil {
+				t.Errorf("DecodeCursor(%q) = %+v, wantNil %v", tt.cursor, cursor, tt.wantNil)
+			}
+		})
+	}
+}
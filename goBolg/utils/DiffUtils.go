@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"goBolg/dto"
+	"strings"
+)
+
+// maxDiffEdits 最大编辑距离，超过后整体视为替换，避免大文本比较占用过多内存
+const maxDiffEdits = 2000
+
+// 差异类型
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffLines 逐行比较两段文本
+func DiffLines(oldText, newText string) []dto.DiffLineDTO {
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+
+	// 去掉相同的首尾行，缩小比较范围
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	lineList := make([]dto.DiffLineDTO, 0, len(oldLines)+len(newLines))
+	for i := 0; i < prefix; i++ {
+		lineList = append(lineList, dto.DiffLineDTO{Type: DiffEqual, OldLine: i + 1, NewLine: i + 1, Content: oldLines[i]})
+	}
+
+	for _, line := range myersDiff(oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix]) {
+		if line.OldLine != 0 {
+			line.OldLine += prefix
+		}
+		if line.NewLine != 0 {
+			line.NewLine += prefix
+		}
+		lineList = append(lineList, line)
+	}
+
+	for i := suffix; i > 0; i-- {
+		oldLine, newLine := len(oldLines)-i, len(newLines)-i
+		lineList = append(lineList, dto.DiffLineDTO{Type: DiffEqual, OldLine: oldLine + 1, NewLine: newLine + 1, Content: oldLines[oldLine]})
+	}
+	return lineList
+}
+
+// myersDiff 使用 Myers 算法求最短编辑脚本
+func myersDiff(a, b []string) []dto.DiffLineDTO {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	limit := n + m
+	if limit > maxDiffEdits {
+		limit = maxDiffEdits
+	}
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	// trace[d] 保存第 d 步开始前 k 属于 [-d, d] 的 v 值
+	var trace [][]int
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackDiff(a, b, trace, d)
+			}
+		}
+	}
+
+	// 差异过大时整体视为删除后插入
+	lineList := make([]dto.DiffLineDTO, 0, n+m)
+	for i, line := range a {
+		lineList = append(lineList, dto.DiffLineDTO{Type: DiffDelete, OldLine: i + 1, Content: line})
+	}
+	for i, line := range b {
+		lineList = append(lineList, dto.DiffLineDTO{Type: DiffInsert, NewLine: i + 1, Content: line})
+	}
+	return lineList
+}
+
+// backtrackDiff 根据搜索轨迹回溯出编辑脚本
+func backtrackDiff(a, b []string, trace [][]int, d int) []dto.DiffLineDTO {
+	x, y := len(a), len(b)
+	var reversed []dto.DiffLineDTO
+	for ; d > 0; d-- {
+		w := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && w[k-1+d] < w[k+1+d]) {
+			prevK = k + 1
+		}
+		prevX := w[prevK+d]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, dto.DiffLineDTO{Type: DiffEqual, OldLine: x, NewLine: y, Content: a[x-1]})
+			x--
+			y--
+		}
+		if prevK == k+1 {
+			reversed = append(reversed, dto.DiffLineDTO{Type: DiffInsert, NewLine: y, Content: b[y-1]})
+		} else {
+			reversed = append(reversed, dto.DiffLineDTO{Type: DiffDelete, OldLine: x, Content: a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, dto.DiffLineDTO{Type: DiffEqual, OldLine: x, NewLine: y, Content: a[x-1]})
+		x--
+		y--
+	}
+
+	lineList := make([]dto.DiffLineDTO, len(reversed))
+	for i, line := range reversed {
+		lineList[len(reversed)-1-i] = line
+	}
+	return lineList
+}
+
+// splitLines 按行拆分文本
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
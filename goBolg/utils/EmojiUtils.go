@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+)
+
+// emojiBaseURL 表情图片的地址前缀
+const emojiBaseURL = "https://blog-felix.oss-cn-beijing.aliyuncs.com/emoji/"
+
+// emojiList 表情短码与图片文件名，与前台表情面板保持一致
+var emojiList = map[string]string{
+	"[嘿嘿]":   "heihei.png",
+	"[抱抱]":   "baobao.png",
+	"[闭嘴]":   "bizui.png",
+	"[倒脸]":   "daolian.png",
+	"[大笑]":   "daxiao.png",
+	"[哈哈]":   "haha.png",
+	"[好吃]":   "haochi.png",
+	"[呵呵]":   "hehe.png",
+	"[花痴]":   "huachi.png",
+	"[苦笑]":   "kuxiao.png",
+	"[亲亲]":   "qinqin.png",
+	"[吐舌]":   "tushe.png",
+	"[微笑]":   "hah.png",
+	"[微笑天使]": "weixiaotianshi.png",
+	"[想一想]":  "xiangyixiang.png",
+	"[笑哭了]":  "xiaokule.png",
+	"[笑死俺了]": "xiaosianle.png",
+	"[斜眼笑]":  "xieyanxiao.png",
+	"[嘻嘻]":   "xixi.png",
+	"[飞吻]":   "feiwen.png",
+}
+
+// emojiPattern 匹配表情短码
+var emojiPattern = regexp.MustCompile(`\[[^\[\]]{1,8}\]`)
+
+// emojiSrcPattern 只允许表情图片地址
+var emojiSrcPattern = regexp.MustCompile(`^` + regexp.QuoteMeta(emojiBaseURL) + `[a-z]+\.png$`)
+
+// ReplaceEmoji 将已转义文本中的表情短码替换为表情图片，未知的短码原样保留
+func ReplaceEmoji(text string) string {
+	return emojiPattern.ReplaceAllStringFunc(text, func(code string) string {
+		fileName, ok := emojiList[code]
+		if !ok {
+			return code
+		}
+		return `<img class="emoji" src="` + emojiBaseURL + fileName + `" alt="` + html.EscapeString(code) + `" width="24" height="24">`
+	})
+}
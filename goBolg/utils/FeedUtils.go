@@ -0,0 +1,289 @@
+package utils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FeedChannel 订阅源的频道信息
+type FeedChannel struct {
+	Title       string    // 标题
+	Link        string    // 网站地址
+	FeedLink    string    // 订阅源地址
+	Description string    // 描述
+	Author      string    // 作者
+	Icon        string    // 图标
+	Updated     time.Time // 最后更新时间
+}
+
+// FeedItem 订阅源中的条目
+type FeedItem struct {
+	ID        int       // 文章id
+	Title     string    // 标题
+	Link      string    // 文章地址
+	Content   string    // HTML 全文，摘要模式下为空
+	Summary   string    // 纯文本摘要
+	Image     string    // 封面
+	Category  string    // 分类
+	Tags      []string  // 标签
+	Published time.Time // 发布时间
+	Updated   time.Time // 更新时间
+}
+
+// rss RSS 2.0 文档
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Image         *rssImage `xml:"image,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type rssItem struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	GUID        rssGUID   `xml:"guid"`
+	PubDate     string    `xml:"pubDate"`
+	Categories  []string  `xml:"category"`
+	Description string    `xml:"description"`
+	Content     *rssCDATA `xml:"content:encoded,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+// atomFeed Atom 文档
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Links    []atomLink  `xml:"link"`
+	Updated  string      `xml:"updated"`
+	Author   atomAuthor  `xml:"author"`
+	Icon     string      `xml:"icon,omitempty"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+	Summary    atomText       `xml:"summary"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// jsonFeed JSON Feed 1.1 文档
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url"`
+	FeedURL     string           `json:"feed_url"`
+	Description string           `json:"description,omitempty"`
+	Icon        string           `json:"icon,omitempty"`
+	Language    string           `json:"language"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name   string `json:"name"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type jsonFeedItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentHTML   string   `json:"content_html,omitempty"`
+	ContentText   string   `json:"content_text,omitempty"`
+	Summary       string   `json:"summary"`
+	Image         string   `json:"image,omitempty"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// feedLanguage 订阅源语言
+const feedLanguage = "zh-CN"
+
+// BuildRSS 生成 RSS 2.0 订阅源
+func BuildRSS(channel FeedChannel, items []FeedItem) ([]byte, error) {
+	doc := rss{
+		Version:   "2.0",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		Channel: rssChannel{
+			Title:         channel.Title,
+			Link:          channel.Link,
+			Description:   channel.Description,
+			Language:      feedLanguage,
+			LastBuildDate: channel.Updated.Format(time.RFC1123Z),
+			AtomLink:      atomLink{Href: channel.FeedLink, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	if channel.Icon != "" {
+		doc.Channel.Image = &rssImage{URL: channel.Icon, Title: channel.Title, Link: channel.Link}
+	}
+
+	for _, item := range items {
+		rssItem := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: true, Value: item.Link},
+			PubDate:     item.Published.Format(time.RFC1123Z),
+			Categories:  feedCategories(item),
+			Description: item.Summary,
+		}
+		if item.Content != "" {
+			rssItem.Content = &rssCDATA{Value: item.Content}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, rssItem)
+	}
+	return marshalFeedXML(doc)
+}
+
+// BuildAtom 生成 Atom 订阅源
+func BuildAtom(channel FeedChannel, items []FeedItem) ([]byte, error) {
+	doc := atomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		Title:    channel.Title,
+		Subtitle: channel.Description,
+		ID:       channel.FeedLink,
+		Links: []atomLink{
+			{Href: channel.Link, Rel: "alternate", Type: "text/html"},
+			{Href: channel.FeedLink, Rel: "self", Type: "application/atom+xml"},
+		},
+		Updated: channel.Updated.Format(time.RFC3339),
+		Author:  atomAuthor{Name: channel.Author},
+		Icon:    channel.Icon,
+	}
+
+	for _, item := range items {
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.Link,
+			Link:      atomLink{Href: item.Link, Rel: "alternate", Type: "text/html"},
+			Published: item.Published.Format(time.RFC3339),
+			Updated:   item.Updated.Format(time.RFC3339),
+			Summary:   atomText{Type: "text", Value: item.Summary},
+		}
+		for _, term := range feedCategories(item) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: term})
+		}
+		if item.Content != "" {
+			entry.Content = &atomText{Type: "html", Value: item.Content}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return marshalFeedXML(doc)
+}
+
+// BuildJSONFeed 生成 JSON Feed 1.1 订阅源
+func BuildJSONFeed(channel FeedChannel, items []FeedItem) ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       channel.Title,
+		HomePageURL: channel.Link,
+		FeedURL:     channel.FeedLink,
+		Description: channel.Description,
+		Icon:        channel.Icon,
+		Language:    feedLanguage,
+		Items:       make([]jsonFeedItem, 0, len(items)),
+	}
+	if channel.Author != "" {
+		doc.Authors = []jsonFeedAuthor{{Name: channel.Author, Avatar: channel.Icon}}
+	}
+
+	for _, item := range items {
+		jsonItem := jsonFeedItem{
+			ID:            strconv.Itoa(item.ID),
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentHTML:   item.Content,
+			Summary:       item.Summary,
+			Image:         item.Image,
+			DatePublished: item.Published.Format(time.RFC3339),
+			DateModified:  item.Updated.Format(time.RFC3339),
+			Tags:          item.Tags,
+		}
+		// 每个条目必须包含 content_html 或 content_text
+		if jsonItem.ContentHTML == "" {
+			jsonItem.ContentText = item.Summary
+		}
+		doc.Items = append(doc.Items, jsonItem)
+	}
+
+	output, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build json feed: %w", err)
+	}
+	return output, nil
+}
+
+// feedCategories 分类和标签都作为条目的分类输出
+func feedCategories(item FeedItem) []string {
+	categories := make([]string, 0, len(item.Tags)+1)
+	if item.Category != "" {
+		categories = append(categories, item.Category)
+	}
+	return append(categories, item.Tags...)
+}
+
+// marshalFeedXML 序列化 XML 订阅源并加上声明
+func marshalFeedXML(doc interface{}) ([]byte, error) {
+	output, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build xml feed: %w", err)
+	}
+	return append([]byte(xml.Header), output...), nil
+}
@@ -1,26 +1,15 @@
 package utils
 
 import (
-	"github.com/importcjj/sensitive"
 	"github.com/microcosm-cc/bluemonday"
 	"regexp"
 )
 
-// 初始化敏感词过滤器
-var filter = sensitive.New()
-
-func init() {
-	filter.LoadWordDict("path/to/sensitive-words.txt") // 设置敏感词字典路径
-}
-
-// HTMLUtils 处理HTML标签和敏感词
+// HTMLUtils 处理HTML标签，敏感词由 SensitiveWordService 按处理方式检查
 type HTMLUtils struct{}
 
-// Filter 删除标签和敏感词过滤
+// Filter 删除标签
 func (HTMLUtils) Filter(source string) string {
-	// 敏感词过滤
-	source = filter.Replace(source, '*')
-
 	// 保留图片标签
 	re := regexp.MustCompile(`<[^>]*>`)
 	source = re.ReplaceAllStringFunc(source, func(tag string) string {
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"goBolg/constant"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// searchSnippetLead 片段中第一个命中之前保留的上下文比例
+const searchSnippetLead = 8
+
+// searchMatch 关键词命中的位置，按字符计算
+type searchMatch struct {
+	start int // 起始位置
+	end   int // 结束位置，不包含
+	term  int // 命中的关键词下标
+}
+
+// SplitSearchTerms 将搜索语句按空白拆分为小写关键词，去掉重复的关键词
+func SplitSearchTerms(keywords string) []string {
+	terms := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, word := range strings.Fields(keywords) {
+		word = lowerRunes(word)
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// HighlightSearchText 转义 HTML 后高亮文本中的全部关键词，忽略大小写，重叠或相邻的命中合并为一个高亮
+func HighlightSearchText(text string, terms []string) string {
+	runes := []rune(text)
+	return highlightRunes(runes, mergeSearchMatches(findSearchMatches(runes, terms)), 0, len(runes))
+}
+
+// BuildSearchSnippet 去掉 markdown 标记后截取关键词最密集的片段并高亮，length 按字符计算
+func BuildSearchSnippet(source string, terms []string, length int) string {
+	return HighlightSnippet(MarkdownToText(source, 0), terms, length)
+}
+
+// HighlightSnippet 从纯文本中截取包含关键词种类最多、命中次数最多的片段并高亮，length 按字符计算
+func HighlightSnippet(text string, terms []string, length int) string {
+	runes := []rune(text)
+	matches := findSearchMatches(runes, terms)
+	start, end := bestSnippetWindow(runes, matches, terms, length)
+
+	var builder strings.Builder
+	if start > 0 {
+		builder.WriteString("...")
+	}
+	builder.WriteString(highlightRunes(runes, mergeSearchMatches(matches), start, end))
+	if end < len(runes) {
+		builder.WriteString("...")
+	}
+	return builder.String()
+}
+
+// findSearchMatches 忽略大小写查找全部关键词的命中位置，结果按起始位置排序
+func findSearchMatches(runes []rune, terms []string) []searchMatch {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var matches []searchMatch
+	for termIndex, term := range terms {
+		termRunes := []rune(lowerRunes(term))
+		if len(termRunes) == 0 {
+			continue
+		}
+		for i := 0; i+len(termRunes) <= len(lower); i++ {
+			if equalRunes(lower[i:i+len(termRunes)], termRunes) {
+				matches = append(matches, searchMatch{start: i, end: i + len(termRunes), term: termIndex})
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].start == matches[j].start {
+			return matches[i].end > matches[j].end
+		}
+		return matches[i].start < matches[j].start
+	})
+	return matches
+}
+
+// mergeSearchMatches 合并重叠或相邻的命中，输入需要按起始位置排序
+func mergeSearchMatches(matches []searchMatch) []searchMatch {
+	merged := make([]searchMatch, 0, len(matches))
+	for _, match := range matches {
+		if last := len(merged) - 1; last >= 0 && match.start <= merged[last].end {
+			merged[last].end = max(merged[last].end, match.end)
+			continue
+		}
+		merged = append(merged, match)
+	}
+	return merged
+}
+
+// bestSnippetWindow 以每个命中为起点滑动窗口，选出包含关键词种类最多、其次命中次数最多的片段
+func bestSnippetWindow(runes []rune, matches []searchMatch, terms []string, length int) (int, int) {
+	if length <= 0 || len(runes) <= length {
+		return 0, len(runes)
+	}
+	if len(matches) == 0 {
+		return 0, length
+	}
+
+	lead := length / searchSnippetLead
+	termCounts := make([]int, len(terms))
+	bestStart, bestKinds, bestHits := 0, -1, -1
+	kinds, hits, left, right := 0, 0, 0, 0
+	for _, anchor := range matches {
+		start := min(max(0, anchor.start-lead), len(runes)-length)
+		end := start + length
+		// 窗口只会向右移动，移入完整落在窗口内的命中，移出起点之前的命中
+		for ; right < len(matches) && matches[right].end <= end; right++ {
+			if termCounts[matches[right].term] == 0 {
+				kinds++
+			}
+			termCounts[matches[right].term]++
+			hits++
+		}
+		for ; left < right && matches[left].start < start; left++ {
+			termCounts[matches[left].term]--
+			if termCounts[matches[left].term] == 0 {
+				kinds--
+			}
+			hits--
+		}
+		if kinds > bestKinds || (kinds == bestKinds && hits > bestHits) {
+			bestStart, bestKinds, bestHits = start, kinds, hits
+		}
+	}
+	return bestStart, bestStart + length
+}
+
+// highlightRunes 转义 [start, end) 范围内的文本，并用高亮标签包裹命中部分，跨越边界的命中只高亮窗口内的部分
+func highlightRunes(runes []rune, matches []searchMatch, start int, end int) string {
+	var builder strings.Builder
+	position := start
+	for _, match := range matches {
+		matchStart, matchEnd := max(match.start, start), min(match.end, end)
+		if matchStart >= matchEnd {
+			continue
+		}
+		builder.WriteString(html.EscapeString(string(runes[position:matchStart])))
+		builder.WriteString(constants.PreTag)
+		builder.WriteString(html.EscapeString(string(runes[matchStart:matchEnd])))
+		builder.WriteString(constants.PostTag)
+		position = matchEnd
+	}
+	builder.WriteString(html.EscapeString(string(runes[position:end])))
+	return builder.String()
+}
+
+// lowerRunes 逐个字符转换为小写，保证转换前后字符数量一致
+func lowerRunes(text string) string {
+	return strings.Map(unicode.ToLower, text)
+}
+
+// equalRunes 判断两个字符切片是否相同
+func equalRunes(a []rune, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
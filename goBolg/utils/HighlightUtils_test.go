@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"goBolg/constant"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitSearchTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		keywords string
+		want     []string
+	}{
+		{"空字符串", "", []string{}},
+		{"只有空白", "  \t ", []string{}},
+		{"转为小写", "Go MySQL", []string{"go", "mysql"}},
+		{"去掉重复", "go GO Go redis", []string{"go", "redis"}},
+		{"中文关键词", "博客  搜索", []string{"博客", "搜索"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitSearchTerms(tt.keywords); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitSearchTerms(%q) = %q, want %q", tt.keywords, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightSearchText(t *testing.T) {
+	mark := func(s string) string { return constants.PreTag + s + constants.PostTag }
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{"没有关键词", "hello world", nil, "hello world"},
+		{"没有命中", "hello world", []string{"go"}, "hello world"},
+		{"忽略大小写并保留原文", "Hello World", []string{"world"}, "Hello " + mark("World")},
+		{"多次命中", "go to go", []string{"go"}, mark("go") + " to " + mark("go")},
+		{"重叠命中合并", "golang", []string{"gol", "lang"}, mark("golang")},
+		{"相邻命中合并", "gopher", []string{"go", "pher"}, mark("gopher")},
+		{"转义 HTML", "<b>go</b>", []string{"go"}, "&lt;b&gt;" + mark("go") + "&lt;/b&gt;"},
+		{"中文", "使用博客搜索", []string{"博客"}, "使用" + mark("博客") + "搜索"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightSearchText(tt.text, tt.terms); got != tt.want {
+				t.Errorf("HighlightSearchText(%q, %q) = %q, want %q", tt.text, tt.terms, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	mark := func(s string) string { return constants.PreTag + s + constants.PostTag }
+	tests := []struct {
+		name   string
+		text   string
+		terms  []string
+		length int
+		want   string
+	}{
+		{"文本短于长度", "go redis", []string{"go"}, 20, mark("go") + " redis"},
+		{"不限制长度", "go redis", []string{"redis"}, 0, "go " + mark("redis")},
+		{"没有命中时截取开头", "abcdefghij", []string{"z"}, 4, "abcd..."},
+		{"截取命中所在片段", "aaaaaaaaaaaaaaaago", []string{"go"}, 8, "...aaaaaa" + mark("go")},
+		{"优先包含更多种类的关键词", "x go xxxxxxxx go redis x", []string{"go", "redis"}, 10, "... " + mark("go") + " " + mark("redis") + " ..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightSnippet(tt.text, tt.terms, tt.length); got != tt.want {
+				t.Errorf("HighlightSnippet(%q, %q, %d) = %q, want %q", tt.text, tt.terms, tt.length, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightSnippetKeepsLength(t *testing.T) {
+	text := strings.Repeat("博客", 50) + "搜索" + strings.Repeat("博客", 50)
+	got := HighlightSnippet(text, []string{"搜索"}, 20)
+	plain := strings.NewReplacer(constants.PreTag, "", constants.PostTag, "", "...", "").Replace(got)
+	if n := len([]rune(plain)); n != 20 {
+		t.Errorf("snippet length = %d, want 20: %q", n, got)
+	}
+	if !strings.Contains(got, constants.PreTag+"搜索"+constants.PostTag) {
+		t.Errorf("snippet %q does not highlight the match", got)
+	}
+}
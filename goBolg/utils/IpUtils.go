@@ -75,6 +75,12 @@ func GetUserAgent(r *http.Request) *user_agent.UserAgent {
 	return user_agent.New(ua)
 }
 
+// SetRequestInfo 将请求的 IP 地址和用户代理保存到上下文中
+func SetRequestInfo(ctx context.Context, r *http.Request) context.Context {
+	ctx = context.WithValue(ctx, "ipAddress", GetIPAddress(r))
+	return context.WithValue(ctx, "userAgent", r.Header.Get("User-Agent"))
+}
+
 // GetIPAddressFromContext 从上下文中获取 IP 地址
 func GetIPAddressFromContext(ctx context.Context) string {
 	if ip, ok := ctx.Value("ipAddress").(string); ok {
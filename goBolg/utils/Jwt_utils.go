@@ -1,47 +1,333 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
-// JWTSecret 密钥
-const JWTSecret = "FelixBlog"
+// 支持的签名算法
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// defaultTokenDuration 未配置有效期时访问令牌的过期时间
+const defaultTokenDuration = time.Minute * 15
+
+// minJWTSecretLength HS256 密钥的最小长度
+const minJWTSecretLength = 32
+
+// ErrJWTSecretEmpty HS256 密钥未配置
+var ErrJWTSecretEmpty = errors.New("secret is empty")
+
+// JWTKey JWT 密钥，RS256 和 EdDSA 只配置公钥时只用于验证
+type JWTKey struct {
+	ID             string // 密钥标识，写入 token 的 kid 头
+	Algorithm      string // 签名算法
+	Secret         string // HS256 使用的密钥
+	PrivateKeyFile string // PEM 格式的私钥文件
+	PublicKeyFile  string // PEM 格式的公钥文件，未配置时从私钥中获取
+}
 
-// TokenDuration 过期时间
-const TokenDuration = time.Hour * 24 * 3
+// JWTOptions JWT 签发和验证配置
+type JWTOptions struct {
+	Issuer        string        // 签发者，验证时要求一致
+	Audience      string        // 接收方，验证时要求一致
+	TokenDuration time.Duration // 有效期
+	SigningKeyID  string        // 签发新 token 使用的密钥标识
+	Keys          []JWTKey      // 全部密钥，轮换时保留旧密钥直到已签发的 token 过期
+}
 
 // Claims 自定义声明
 type Claims struct {
-	UserID   int    `json:"user_id"`
-	Username string `json:"username"`
+	UserID    int    `json:"user_id"`
+	Username  string `json:"username"`
+	SessionID string `json:"sid"` // 登录会话 id，注销会话后该会话签发的 token 全部失效
 	jwt.StandardClaims
 }
 
-// GenerateJWT 生成包含用户信息和过期时间的 JWT
-func GenerateJWT(userID int, username string) (string, error) {
+// jwtKey 解析后的密钥
+type jwtKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	canSign   bool
+}
+
+// jwtManager 当前使用的 JWT 配置
+type jwtManager struct {
+	issuer        string
+	audience      string
+	tokenDuration time.Duration
+	signingKey    *jwtKey
+	keys          map[string]*jwtKey
+	validMethods  []string
+}
+
+var (
+	jwtMutex   sync.RWMutex
+	jwtCurrent *jwtManager
+)
+
+// InitJWT 加载密钥并替换当前的 JWT 配置，签发用的密钥必须包含私钥或 HS256 密钥
+func InitJWT(options JWTOptions) error {
+	if len(options.Keys) == 0 {
+		return errors.New("jwt keys are not configured")
+	}
+	manager := &jwtManager{
+		issuer:        options.Issuer,
+		audience:      options.Audience,
+		tokenDuration: options.TokenDuration,
+		keys:          make(map[string]*jwtKey, len(options.Keys)),
+	}
+	if manager.tokenDuration <= 0 {
+		manager.tokenDuration = defaultTokenDuration
+	}
+
+	methods := make(map[string]bool)
+	for _, keyOption := range options.Keys {
+		if keyOption.ID == "" {
+			return errors.New("jwt key id is empty")
+		}
+		if _, exists := manager.keys[keyOption.ID]; exists {
+			return fmt.Errorf("duplicate jwt key id: %s", keyOption.ID)
+		}
+		key, err := loadJWTKey(keyOption)
+		if err != nil {
+			return fmt.Errorf("failed to load jwt key %s: %w", keyOption.ID, err)
+		}
+		manager.keys[key.id] = key
+		if !methods[key.method.Alg()] {
+			methods[key.method.Alg()] = true
+			manager.validMethods = append(manager.validMethods, key.method.Alg())
+		}
+	}
+
+	signingKeyID := options.SigningKeyID
+	if signingKeyID == "" {
+		signingKeyID = options.Keys[0].ID
+	}
+	manager.signingKey = manager.keys[signingKeyID]
+	if manager.signingKey == nil {
+		return fmt.Errorf("jwt signing key %s not found", signingKeyID)
+	}
+	if !manager.signingKey.canSign {
+		return fmt.Errorf("jwt signing key %s has no private key", signingKeyID)
+	}
+
+	jwtMutex.Lock()
+	jwtCurrent = manager
+	jwtMutex.Unlock()
+	return nil
+}
+
+// loadJWTKey 按算法读取密钥
+func loadJWTKey(option JWTKey) (*jwtKey, error) {
+	key := &jwtKey{id: option.ID}
+	switch option.Algorithm {
+	case JWTAlgorithmHS256:
+		if option.Secret == "" {
+			return nil, ErrJWTSecretEmpty
+		}
+		if len(option.Secret) < minJWTSecretLength {
+			return nil, fmt.Errorf("secret must be at least %d bytes", minJWTSecretLength)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(option.Secret)
+		key.verifyKey = key.signKey
+		key.canSign = true
+		return key, nil
+	case JWTAlgorithmRS256:
+		key.method = jwt.SigningMethodRS256
+	case JWTAlgorithmEdDSA:
+		key.method = SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", option.Algorithm)
+	}
+
+	if option.PrivateKeyFile != "" {
+		privateKey, err := readPEMPrivateKey(option.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok || !matchJWTAlgorithm(option.Algorithm, privateKey) {
+			return nil, fmt.Errorf("private key does not match algorithm %s", option.Algorithm)
+		}
+		key.signKey = privateKey
+		key.verifyKey = signer.Public()
+		key.canSign = true
+	}
+	if option.PublicKeyFile != "" {
+		publicKey, err := readPEMPublicKey(option.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if !matchJWTAlgorithm(option.Algorithm, publicKey) {
+			return nil, fmt.Errorf("public key does not match algorithm %s", option.Algorithm)
+		}
+		key.verifyKey = publicKey
+	}
+	if key.verifyKey == nil {
+		return nil, errors.New("private key or public key is required")
+	}
+	return key, nil
+}
+
+// readPEMPrivateKey 读取 PKCS#8 或 PKCS#1 格式的私钥
+func readPEMPrivateKey(fileName string) (interface{}, error) {
+	block, err := readPEMBlock(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", fileName, err)
+	}
+	return privateKey, nil
+}
+
+// readPEMPublicKey 读取 PKIX 格式的公钥
+func readPEMPublicKey(fileName string) (interface{}, error) {
+	block, err := readPEMBlock(fileName)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", fileName, err)
+	}
+	return publicKey, nil
+}
+
+// readPEMBlock 读取文件中的第一个 PEM 块
+func readPEMBlock(fileName string) (*pem.Block, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", fileName)
+	}
+	return block, nil
+}
+
+// matchJWTAlgorithm 检查密钥类型与算法是否一致
+func matchJWTAlgorithm(algorithm string, key interface{}) bool {
+	switch key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return algorithm == JWTAlgorithmRS256
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return algorithm == JWTAlgorithmEdDSA
+	}
+	return false
+}
+
+// currentJWT 获取当前的 JWT 配置
+func currentJWT() (*jwtManager, error) {
+	jwtMutex.RLock()
+	defer jwtMutex.RUnlock()
+	if jwtCurrent == nil {
+		return nil, errors.New("jwt is not initialized")
+	}
+	return jwtCurrent, nil
+}
+
+// JWTTokenDuration 当前配置的 token 有效期
+func JWTTokenDuration() time.Duration {
+	manager, err := currentJWT()
+	if err != nil {
+		return defaultTokenDuration
+	}
+	return manager.tokenDuration
+}
+
+// GenerateJWT 生成包含用户信息、登录会话和过期时间的 JWT，头部的 kid 标识签名使用的密钥
+func GenerateJWT(userID int, username string, sessionID string) (string, error) {
+	manager, err := currentJWT()
+	if err != nil {
+		return "", err
+	}
+	tokenId, err := NewTokenId()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
 	claims := &Claims{
-		UserID:   userID,
-		Username: username,
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(TokenDuration).Unix(),
+			Id:        tokenId,
+			Issuer:    manager.issuer,
+			Audience:  manager.audience,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(manager.tokenDuration).Unix(),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(JWTSecret))
+	token := jwt.NewWithClaims(manager.signingKey.method, claims)
+	token.Header["kid"] = manager.signingKey.id
+	return token.SignedString(manager.signingKey.signKey)
 }
 
-// ValidateJWT 验证 JWT 并返回其声明
+// ValidateJWT 按 kid 选择密钥验证 JWT，并校验签发者和接收方，返回其声明
 func ValidateJWT(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(JWTSecret), nil
+	manager, err := currentJWT()
+	if err != nil {
+		return nil, err
+	}
+	parser := &jwt.Parser{ValidMethods: manager.validMethods}
+	token, err := parser.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		keyId, _ := token.Header["kid"].(string)
+		key := manager.keys[keyId]
+		if key == nil {
+			return nil, fmt.Errorf("unknown jwt key id: %s", keyId)
+		}
+		// 防止使用其他算法伪造签名
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+		}
+		return key.verifyKey, nil
 	})
 	if err != nil {
 		return nil, err
 	}
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrSignatureInvalid // 返回具体的错误类型
+	}
+	if manager.issuer != "" && !claims.VerifyIssuer(manager.issuer, true) {
+		return nil, errors.New("invalid token issuer")
+	}
+	if manager.audience != "" && !claims.VerifyAudience(manager.audience, true) {
+		return nil, errors.New("invalid token audience")
+	}
+	return claims, nil
+}
+
+// NewTokenId 生成随机的 token id
+func NewTokenId() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
 	}
-	return nil, jwt.ErrSignatureInvalid // 返回具体的错误类型
+	return hex.EncodeToString(buf), nil
 }
@@ -0,0 +1,327 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/russross/blackfriday/v2"
+	"goBolg/enums"
+	"goBolg/vo"
+	"gopkg.in/yaml.v3"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// hexoFrontMatter Hexo 文章头部信息
+type hexoFrontMatter struct {
+	Title      string      `yaml:"title"`
+	Date       string      `yaml:"date"`
+	Updated    string      `yaml:"updated"`
+	Tags       interface{} `yaml:"tags"`
+	Categories interface{} `yaml:"categories"`
+	Cover      string      `yaml:"cover"`
+	Top        interface{} `yaml:"top"`
+	Password   string      `yaml:"password"`
+	Status     string      `yaml:"status"`
+}
+
+// hexoExportFrontMatter 导出时的 Hexo 文章头部信息
+type hexoExportFrontMatter struct {
+	Title      string   `yaml:"title"`
+	Date       string   `yaml:"date"`
+	Updated    string   `yaml:"updated,omitempty"`
+	Tags       []string `yaml:"tags,omitempty"`
+	Categories []string `yaml:"categories,omitempty"`
+	Cover      string   `yaml:"cover,omitempty"`
+	Status     string   `yaml:"status,omitempty"`
+	Top        bool     `yaml:"top,omitempty"`
+}
+
+// hexoTimeFormat 导出时使用的时间格式
+const hexoTimeFormat = "2006-01-02 15:04:05"
+
+// articleStatusNames 文章状态在 Front-matter 中的名称
+var articleStatusNames = map[int]string{
+	enums.PUBLIC.Status: "public",
+	enums.SECRET.Status: "secret",
+	enums.DRAFT.Status:  "draft",
+}
+
+// hexoTimeLayouts Hexo 常见的时间格式
+var hexoTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	time.RFC3339,
+}
+
+// HexoArticle 解析后的 Hexo 文章
+type HexoArticle struct {
+	vo.HexoArticleVO
+	UpdateTime *time.Time // 更新时间
+	Password   string     // 文章密码
+}
+
+// ParseHexoMarkdown 解析带有 Front-matter 的 Hexo 文章
+func ParseHexoMarkdown(source []byte) (*HexoArticle, error) {
+	source = bytes.TrimPrefix(source, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(source), "\r\n", "\n")
+
+	header, content, err := splitFrontMatter(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var frontMatter hexoFrontMatter
+	if err := yaml.Unmarshal([]byte(header), &frontMatter); err != nil {
+		return nil, fmt.Errorf("failed to parse front matter: %w", err)
+	}
+	if strings.TrimSpace(frontMatter.Title) == "" {
+		return nil, errors.New("front matter title is empty")
+	}
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, errors.New("article content is empty")
+	}
+
+	article := &HexoArticle{Password: frontMatter.Password}
+	article.ArticleTitle = strings.TrimSpace(frontMatter.Title)
+	article.ArticleContent = content
+	article.TagNameList = toStringList(frontMatter.Tags)
+
+	if categories := toStringList(frontMatter.Categories); len(categories) > 0 {
+		article.CategoryName = &categories[0]
+	}
+	if frontMatter.Cover != "" {
+		article.ArticleCover = &frontMatter.Cover
+	}
+	if isTop(frontMatter.Top) {
+		top := 1
+		article.IsTop = &top
+	}
+	for status, name := range articleStatusNames {
+		if strings.EqualFold(strings.TrimSpace(frontMatter.Status), name) {
+			articleStatus := status
+			article.Status = &articleStatus
+		}
+	}
+
+	if frontMatter.Date != "" {
+		createTime, err := parseHexoTime(frontMatter.Date)
+		if err != nil {
+			return nil, err
+		}
+		article.CreateTime = createTime
+	}
+	if frontMatter.Updated != "" {
+		updateTime, err := parseHexoTime(frontMatter.Updated)
+		if err != nil {
+			return nil, err
+		}
+		article.UpdateTime = &updateTime
+	}
+
+	return article, nil
+}
+
+// BuildHexoMarkdown 生成带有 Front-matter 的 Hexo 文章
+func BuildHexoMarkdown(article *HexoArticle) ([]byte, error) {
+	frontMatter := hexoExportFrontMatter{
+		Title: article.ArticleTitle,
+		Date:  article.CreateTime.Format(hexoTimeFormat),
+		Tags:  article.TagNameList,
+	}
+	if article.UpdateTime != nil {
+		frontMatter.Updated = article.UpdateTime.Format(hexoTimeFormat)
+	}
+	if article.CategoryName != nil && *article.CategoryName != "" {
+		frontMatter.Categories = []string{*article.CategoryName}
+	}
+	if article.ArticleCover != nil {
+		frontMatter.Cover = *article.ArticleCover
+	}
+	if article.Status != nil {
+		frontMatter.Status = articleStatusNames[*article.Status]
+	}
+	if article.IsTop != nil && *article.IsTop == 1 {
+		frontMatter.Top = true
+	}
+
+	header, err := yaml.Marshal(frontMatter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build front matter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n\n")
+	buf.WriteString(article.ArticleContent)
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// HexoFileName 根据文章标题生成可用的文件名
+func HexoFileName(articleId int, articleTitle string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < 32 {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(articleTitle))
+	return fmt.Sprintf("%d-%s.md", articleId, name)
+}
+
+// MarkdownToHTML 将 markdown 渲染为经过清理的 HTML
+func MarkdownToHTML(source string) string {
+	output := blackfriday.Run([]byte(strings.ReplaceAll(source, "\r\n", "\n")))
+	return string(bluemonday.UGCPolicy().SanitizeBytes(output))
+}
+
+// commentMarkdownExtensions 评论支持的 markdown 语法，不包含标题、表格等排版语法
+const commentMarkdownExtensions = blackfriday.FencedCode | blackfriday.Autolink | blackfriday.Strikethrough |
+	blackfriday.NoIntraEmphasis | blackfriday.NoEmptyLineBeforeBlock
+
+// commentPolicy 评论和留言允许的标签：行内代码、代码块、链接、引用、列表、强调和表情图片
+var commentPolicy = newCommentPolicy()
+
+// newCommentPolicy 创建评论和留言使用的 HTML 清理策略
+func newCommentPolicy() *bluemonday.Policy {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements("p", "br", "pre", "code", "blockquote", "ul", "ol", "li", "em", "strong", "del")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	policy.AllowAttrs("href").OnElements("a")
+	policy.AllowAttrs("rel").Matching(regexp.MustCompile(`^nofollow ugc$`)).OnElements("a")
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.AllowRelativeURLs(true)
+	policy.RequireParseableURLs(true)
+	policy.RequireNoFollowOnLinks(true)
+	policy.AllowAttrs("src").Matching(emojiSrcPattern).OnElements("img")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^emoji$`)).OnElements("img")
+	policy.AllowAttrs("width", "height").Matching(bluemonday.Number).OnElements("img")
+	policy.AllowAttrs("alt").OnElements("img")
+	return policy
+}
+
+// commentRenderer 渲染评论，链接统一标记为用户生成内容，文本中的表情短码替换为表情图片
+type commentRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+// RenderNode 渲染链接和文本节点，其余节点交给 HTMLRenderer
+func (r commentRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	switch node.Type {
+	case blackfriday.Link:
+		if entering {
+			io.WriteString(w, `<a href="`+html.EscapeString(string(node.LinkData.Destination))+`" rel="nofollow ugc">`)
+		} else {
+			io.WriteString(w, "</a>")
+		}
+		return blackfriday.GoToNext
+	case blackfriday.Text:
+		io.WriteString(w, ReplaceEmoji(html.EscapeString(string(node.Literal))))
+		return blackfriday.GoToNext
+	}
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
+
+// CommentMarkdownToHTML 将评论和留言的 markdown 渲染为 HTML，忽略原始 HTML 和图片，只保留允许的标签
+func CommentMarkdownToHTML(source string) string {
+	renderer := commentRenderer{blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.SkipHTML | blackfriday.SkipImages,
+	})}
+	output := blackfriday.Run([]byte(strings.ReplaceAll(source, "\r\n", "\n")),
+		blackfriday.WithExtensions(commentMarkdownExtensions),
+		blackfriday.WithRenderer(renderer))
+	return strings.TrimSpace(commentPolicy.Sanitize(string(output)))
+}
+
+// IsCommentHTMLEmpty 判断渲染后的评论是否没有可展示的内容，去除标签后没有文字且不包含表情图片时视为空
+func IsCommentHTMLEmpty(content string) bool {
+	text := html.UnescapeString(bluemonday.StrictPolicy().Sanitize(content))
+	return strings.TrimSpace(text) == "" && !strings.Contains(content, "<img")
+}
+
+// MarkdownToText 将 markdown 转换为纯文本摘要，maxLength 按字符计算，小于等于 0 时不截断
+func MarkdownToText(source string, maxLength int) string {
+	text := bluemonday.StrictPolicy().Sanitize(MarkdownToHTML(source))
+	text = strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+	if maxLength <= 0 || utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	return string([]rune(text)[:maxLength]) + "..."
+}
+
+// splitFrontMatter 拆分头部信息和正文，兼容省略开头 --- 的写法
+func splitFrontMatter(text string) (string, string, error) {
+	text = strings.TrimPrefix(text, "---\n")
+	if strings.HasPrefix(text, "---\n") {
+		return "", "", errors.New("front matter is empty")
+	}
+
+	index := strings.Index(text, "\n---\n")
+	if index == -1 {
+		return "", "", errors.New("front matter not found")
+	}
+	return text[:index], text[index+len("\n---\n"):], nil
+}
+
+// parseHexoTime 按照 Hexo 常见格式解析时间
+func parseHexoTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range hexoTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format: %s", value)
+}
+
+// toStringList 将标签或分类转换为字符串列表，多级分类按顺序展开
+func toStringList(value interface{}) []string {
+	var list []string
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) != "" {
+			list = append(list, strings.TrimSpace(v))
+		}
+	case []interface{}:
+		for _, item := range v {
+			switch i := item.(type) {
+			case []interface{}:
+				list = append(list, toStringList(i)...)
+			case nil:
+			default:
+				if name := strings.TrimSpace(fmt.Sprint(i)); name != "" {
+					list = append(list, name)
+				}
+			}
+		}
+	case nil:
+	default:
+		list = append(list, fmt.Sprint(v))
+	}
+	return list
+}
+
+// isTop 判断是否置顶，兼容 top: true 和 top: 数字 两种写法
+func isTop(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case int:
+		return v > 0
+	case float64:
+		return v > 0
+	case string:
+		return v == "true" || v == "1"
+	}
+	return false
+}
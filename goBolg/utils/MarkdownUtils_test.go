@@ -0,0 +1,53 @@
+package utils
+
+import "testing"
+
+func TestCommentMarkdownToHTML(t *testing.T) {
+	emoji := `<img class="emoji" src="` + emojiBaseURL + `haha.png" alt="[哈哈]" width="24" height="24">`
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"普通文本", "hello", "<p>hello</p>"},
+		{"强调和行内代码", "**b** `c`", "<p><strong>b</strong> <code>c</code></p>"},
+		{"链接标记为用户生成内容", "[x](https://a.com)", `<p><a href="https://a.com" rel="nofollow ugc">x</a></p>`},
+		{"javascript 链接去除地址", "[x](javascript:alert(1))", `<p><a rel="nofollow ugc">x</a>)</p>`},
+		{"链接地址中的引号不能注入属性", `[x](https://a.com" onclick="alert(1))`, `<p><a rel="nofollow ugc">x</a>)</p>`},
+		{"原始 HTML 被忽略", `<a href="x" onclick="alert(1)">z</a>`, "<p>z</p>"},
+		{"script 标签被忽略", "<script>alert(1)</script>", "<p>alert(1)</p>"},
+		{"表情短码替换为图片", "[哈哈]", "<p>" + emoji + "</p>"},
+		{"未知表情短码原样保留", "[不存在]", "<p>[不存在]</p>"},
+		{"图片被忽略", "![img](https://a.com/a.png)", "<p></p>"},
+		{"图片被忽略后保留表情", "![img](https://a.com/a.png) [哈哈]", "<p> " + emoji + "</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommentMarkdownToHTML(tt.source); got != tt.want {
+				t.Errorf("CommentMarkdownToHTML(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCommentHTMLEmpty(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   bool
+	}{
+		{"空内容", "", true},
+		{"只有空白", "   \n", true},
+		{"只有图片", "![img](https://a.com/a.png)", true},
+		{"只有表情", "[哈哈]", false},
+		{"只有链接", "[x](https://a.com)", false},
+		{"普通文本", "hello", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCommentHTMLEmpty(CommentMarkdownToHTML(tt.source)); got != tt.want {
+				t.Errorf("IsCommentHTMLEmpty(%q) = %v, want %v", tt.source, got, tt.want)
+			}
+		})
+	}
+}
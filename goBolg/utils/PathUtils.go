@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchPath 判断请求路径是否匹配资源路径，* 匹配一级路径中的任意字符，** 匹配任意多级路径
+func MatchPath(pattern string, requestPath string) bool {
+	return matchPathSegments(splitPath(pattern), splitPath(requestPath))
+}
+
+// matchPathSegments 逐级匹配路径
+func matchPathSegments(patterns []string, segments []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+	if patterns[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchPathSegments(patterns[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(patterns[0], segments[0]); err != nil || !matched {
+		return false
+	}
+	return matchPathSegments(patterns[1:], segments[1:])
+}
+
+// splitPath 按 / 拆分路径，忽略首尾的 /
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
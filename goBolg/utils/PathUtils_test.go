@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern     string
+		requestPath string
+		want        bool
+	}{
+		{"/admin/articles", "/admin/articles", true},
+		{"/admin/articles", "/admin/articles/", true},
+		{"/admin/articles", "/admin/articles/1", false},
+		{"/admin/articles", "/admin/tags", false},
+		{"/admin/articles/*", "/admin/articles/1", true},
+		{"/admin/articles/*", "/admin/articles", false},
+		{"/admin/articles/*", "/admin/articles/1/top", false},
+		{"/admin/articles/*/top", "/admin/articles/1/top", true},
+		{"/admin/articles/**", "/admin/articles", true},
+		{"/admin/articles/**", "/admin/articles/1/revisions/2", true},
+		{"/admin/**/top", "/admin/articles/1/top", true},
+		{"/admin/**/top", "/admin/articles/1/delete", false},
+		{"/admin/users/*/sessions", "/admin/users/3/sessions", true},
+		{"/admin/[", "/admin/[", false},
+		{"/", "/", true},
+		{"/**", "/anything/at/all", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.requestPath, func(t *testing.T) {
+			if got := MatchPath(tt.pattern, tt.requestPath); got != tt.want {
+				t.Errorf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.requestPath, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"github.com/importcjj/sensitive"
+	"goBolg/enums"
+	"sync"
+)
+
+// sensitiveMask 替换敏感词使用的字符
+const sensitiveMask = '*'
+
+// SensitiveResult 敏感词检查结果
+type SensitiveResult struct {
+	Text   string                     // 替换敏感词后的文本
+	Action *enums.SensitiveActionEnum // 命中的最严格的处理方式，未命中时为 nil
+	Words  []string                   // 命中的敏感词
+}
+
+// Rejected 是否需要拒绝提交
+func (r SensitiveResult) Rejected() bool {
+	return r.Action != nil && r.Action.Action == enums.REJECT.Action
+}
+
+// NeedReview 是否需要转为人工审核
+func (r SensitiveResult) NeedReview() bool {
+	return r.Action != nil && r.Action.Action == enums.REVIEW.Action
+}
+
+// SensitiveFilter 按处理方式分组的敏感词过滤器，词库整体替换，可以并发检查
+type SensitiveFilter struct {
+	mu      sync.RWMutex
+	filters map[int]*sensitive.Filter
+}
+
+// NewSensitiveFilter 创建空词库的敏感词过滤器
+func NewSensitiveFilter() *SensitiveFilter {
+	return &SensitiveFilter{filters: make(map[int]*sensitive.Filter)}
+}
+
+// Load 使用新的词库替换当前词库，wordActions 为敏感词到处理方式的映射
+func (f *SensitiveFilter) Load(wordActions map[string]int) {
+	filters := make(map[int]*sensitive.Filter)
+	for word, action := range wordActions {
+		if filters[action] == nil {
+			filters[action] = sensitive.New()
+		}
+		filters[action].AddWord(word)
+	}
+
+	f.mu.Lock()
+	f.filters = filters
+	f.mu.Unlock()
+}
+
+// Check 检查文本中的敏感词，按严格程度返回处理方式，并替换全部命中的敏感词
+func (f *SensitiveFilter) Check(text string) SensitiveResult {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	result := SensitiveResult{Text: text}
+	for _, actionEnum := range enums.GetSensitiveActionEnums() {
+		filter := f.filters[actionEnum.Action]
+		if filter == nil {
+			continue
+		}
+		words := filter.FindAll(text)
+		if len(words) == 0 {
+			continue
+		}
+		if result.Action == nil {
+			action := actionEnum
+			result.Action = &action
+		}
+		result.Words = append(result.Words, words...)
+		result.Text = filter.Replace(result.Text, sensitiveMask)
+	}
+	return result
+}
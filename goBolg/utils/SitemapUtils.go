@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// sitemapXmlns 站点地图协议命名空间
+const sitemapXmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapURL 站点地图中的一个地址
+type SitemapURL struct {
+	Loc     string    // 完整地址
+	LastMod time.Time // 最后修改时间
+}
+
+// sitemapURLSet 站点地图文档
+type sitemapURLSet struct {
+	XMLName xml.Name        `xml:"urlset"`
+	Xmlns   string          `xml:"xmlns,attr"`
+	URLs    []sitemapLocMod `xml:"url"`
+}
+
+// sitemapIndex 站点地图索引文档
+type sitemapIndex struct {
+	XMLName  xml.Name        `xml:"sitemapindex"`
+	Xmlns    string          `xml:"xmlns,attr"`
+	Sitemaps []sitemapLocMod `xml:"sitemap"`
+}
+
+type sitemapLocMod struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// BuildSitemap 生成站点地图
+func BuildSitemap(urls []SitemapURL) ([]byte, error) {
+	doc := sitemapURLSet{Xmlns: sitemapXmlns, URLs: toSitemapLocMods(urls)}
+	return marshalSitemapXML(doc)
+}
+
+// BuildSitemapIndex 生成站点地图索引，sitemaps 为各个子站点地图的地址
+func BuildSitemapIndex(sitemaps []SitemapURL) ([]byte, error) {
+	doc := sitemapIndex{Xmlns: sitemapXmlns, Sitemaps: toSitemapLocMods(sitemaps)}
+	return marshalSitemapXML(doc)
+}
+
+// toSitemapLocMods 转换为 XML 节点，最后修改时间使用 W3C Datetime 格式
+func toSitemapLocMods(urls []SitemapURL) []sitemapLocMod {
+	nodes := make([]sitemapLocMod, 0, len(urls))
+	for _, url := range urls {
+		node := sitemapLocMod{Loc: url.Loc}
+		if !url.LastMod.IsZero() {
+			node.LastMod = url.LastMod.Format(time.RFC3339)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// marshalSitemapXML 序列化站点地图并加上声明
+func marshalSitemapXML(doc interface{}) ([]byte, error) {
+	output, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sitemap: %w", err)
+	}
+	return append([]byte(xml.Header), output...), nil
+}
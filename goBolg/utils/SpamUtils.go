@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// maxSpamTokens 单条内容参与分类的最大词数
+const maxSpamTokens = 500
+
+var (
+	// spamLinkPattern 匹配文本和标签属性中的链接
+	spamLinkPattern = regexp.MustCompile(`(?i)(?:https?://|www\.)[^\s<>"']+`)
+	// spamHrefPattern 匹配超链接标签的地址
+	spamHrefPattern = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=\s*["']?([^\s"'>]+)`)
+	// spamTagPattern 匹配 HTML 标签
+	spamTagPattern = regexp.MustCompile(`<[^>]*>`)
+)
+
+// CountLinks 统计内容中的链接数量，表情等图片标签的地址不计入
+func CountLinks(content string) int {
+	return len(findSpamLinks(content))
+}
+
+// findSpamLinks 查找超链接标签的地址和正文中的链接
+func findSpamLinks(content string) []string {
+	var links []string
+	for _, match := range spamHrefPattern.FindAllStringSubmatch(content, -1) {
+		links = append(links, match[1])
+	}
+	text := spamTagPattern.ReplaceAllString(content, " ")
+	return append(links, spamLinkPattern.FindAllString(text, -1)...)
+}
+
+// ContentDigest 忽略大小写、空白和标点后计算内容摘要，用于识别重复提交的内容，没有文字时返回空字符串
+func ContentDigest(content string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, spamTagPattern.ReplaceAllString(content, ""))
+	if normalized == "" {
+		return ""
+	}
+	sum := sha1.Sum([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// SpamTokens 将内容切分为去重后的分类词，链接取域名，中文按相邻两个字切分，其他文字按单词切分
+func SpamTokens(content string) []string {
+	tokens := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(token string) {
+		if len(tokens) < maxSpamTokens && !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	for _, link := range findSpamLinks(content) {
+		if !strings.Contains(link, "://") {
+			link = "http://" + link
+		}
+		if parsed, err := url.Parse(link); err == nil && parsed.Hostname() != "" {
+			add("url:" + strings.ToLower(parsed.Hostname()))
+		}
+	}
+
+	text := spamLinkPattern.ReplaceAllString(spamTagPattern.ReplaceAllString(content, " "), " ")
+	var word, han []rune
+	flush := func() {
+		if len(word) > 1 {
+			add(string(word))
+		}
+		if len(han) == 1 {
+			add(string(han))
+		}
+		for i := 0; i+1 < len(han); i++ {
+			add(string(han[i : i+2]))
+		}
+		word, han = word[:0], han[:0]
+	}
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			if len(word) > 0 {
+				flush()
+			}
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if len(han) > 0 {
+				flush()
+			}
+			word = append(word, unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// BayesSpamProbability 根据每个词在垃圾和正常样本中出现的次数，用朴素贝叶斯计算内容是垃圾内容的概率
+// 词频使用拉普拉斯平滑，训练样本中从未出现过的词不参与计算
+func BayesSpamProbability(spamCounts []int, hamCounts []int, spamSamples int, hamSamples int) float64 {
+	if spamSamples <= 0 || hamSamples <= 0 {
+		return 0.5
+	}
+	logOdds := math.Log(float64(spamSamples) / float64(hamSamples))
+	for i := range spamCounts {
+		if spamCounts[i]+hamCounts[i] == 0 {
+			continue
+		}
+		spamRate := float64(spamCounts[i]+1) / float64(spamSamples+2)
+		hamRate := float64(hamCounts[i]+1) / float64(hamSamples+2)
+		logOdds += math.Log(spamRate / hamRate)
+	}
+	return 1 / (1 + math.Exp(-logOdds))
+}
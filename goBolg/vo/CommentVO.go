@@ -21,6 +21,17 @@ func ValidateCommentVO(comment CommentVO) error {
 	return validate.Struct(comment)
 }
 
+// CommentEditVO 代表编辑后的评论内容
+type CommentEditVO struct {
+	CommentContent string `json:"commentContent" validate:"required"` // 评论内容，必填
+}
+
+// ValidateCommentEditVO 验证 CommentEditVO 实例
+func ValidateCommentEditVO(comment CommentEditVO) error {
+	validate := validator.New()
+	return validate.Struct(comment)
+}
+
 // Custom Unmarshaler to handle string to int conversion for TopicID
 func (c *CommentVO) UnmarshalJSON(data []byte) error {
 	var aux struct {
@@ -16,4 +16,5 @@ type ConditionVO struct {
 	EndTime    *time.Time `form:"endTime"`    // 结束时间
 	IsDelete   *bool      `form:"isDelete"`   // 是否删除
 	IsReview   *int       `form:"isReview"`   // 是否审核
+	IsRead     *int       `form:"isRead"`     // 是否已读
 }
@@ -0,0 +1,24 @@
+package vo
+
+import "github.com/go-playground/validator/v10"
+
+// EmailTemplateVO 代表后台保存的邮件模板
+type EmailTemplateVO struct {
+	// 模板名称
+	Name string `json:"name" validate:"required,max=50"`
+
+	// 主题模板
+	Subject string `json:"subject" validate:"required,max=255"`
+
+	// HTML 正文模板
+	HTMLContent string `json:"htmlContent" validate:"required"`
+
+	// 纯文本正文模板
+	TextContent string `json:"textContent" validate:"required"`
+}
+
+// ValidateEmailTemplateVO 用于验证 EmailTemplateVO 结构体
+func ValidateEmailTemplateVO(emailTemplateVO EmailTemplateVO) error {
+	validate := validator.New()
+	return validate.Struct(emailTemplateVO)
+}
@@ -0,0 +1,21 @@
+package vo
+
+import "github.com/go-playground/validator/v10"
+
+// NotificationSettingVO 代表用户保存的通知接收设置
+type NotificationSettingVO struct {
+	// 通知类型 1.评论回复 2.评论点赞 3.文章点赞 4.说说点赞 5.评论审核结果
+	Type int `json:"type" validate:"required"`
+
+	// 是否发送邮件
+	IsEmail int `json:"isEmail" validate:"oneof=0 1"`
+
+	// 是否发送站内通知
+	IsInApp int `json:"isInApp" validate:"oneof=0 1"`
+}
+
+// ValidateNotificationSettingVO 用于验证 NotificationSettingVO 结构体
+func ValidateNotificationSettingVO(notificationSettingVO NotificationSettingVO) error {
+	validate := validator.New()
+	return validate.Struct(notificationSettingVO)
+}
@@ -0,0 +1,21 @@
+package vo
+
+import "github.com/go-playground/validator/v10"
+
+// SensitiveWordVO 代表后台保存的敏感词
+type SensitiveWordVO struct {
+	// 敏感词id
+	ID int `json:"id"`
+
+	// 敏感词
+	Word string `json:"word" validate:"required,max=50"` // 敏感词，必填
+
+	// 处理方式 1.替换 2.拒绝 3.审核
+	Action int `json:"action" validate:"oneof=1 2 3"`
+}
+
+// ValidateSensitiveWordVO 用于验证 SensitiveWordVO 结构体
+func ValidateSensitiveWordVO(sensitiveWordVO SensitiveWordVO) error {
+	validate := validator.New()
+	return validate.Struct(sensitiveWordVO)
+}
@@ -0,0 +1,27 @@
+package vo
+
+import "github.com/go-playground/validator/v10"
+
+// SeriesVO 代表后台保存的文章系列
+type SeriesVO struct {
+	// 系列id
+	ID int `json:"id"`
+
+	// 系列标题
+	SeriesTitle string `json:"seriesTitle" validate:"required,max=50"` // 系列标题，必填
+
+	// 系列描述
+	SeriesDesc string `json:"seriesDesc" validate:"max=255"`
+
+	// 系列封面
+	SeriesCover string `json:"seriesCover" validate:"max=255"`
+
+	// 按顺序排列的文章id
+	ArticleIdList []int `json:"articleIdList"`
+}
+
+// ValidateSeriesVO 用于验证 SeriesVO 结构体
+func ValidateSeriesVO(seriesVO SeriesVO) error {
+	validate := validator.New()
+	return validate.Struct(seriesVO)
+}
@@ -1,10 +1,22 @@
 package vo
 
+import (
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
 // TalkVO 代表说说对象
 type TalkVO struct {
-	ID      int    `json:"id"`                          // 说说id
-	Content string `json:"content" validate:"required"` // 说说内容，不能为空
-	Images  string `json:"images"`                      // 说说图片
-	IsTop   int    `json:"isTop" validate:"required"`   // 置顶状态，不能为空
-	Status  int    `json:"status" validate:"required"`  // 说说状态，不能为空
+	ID          int        `json:"id"`                            // 说说id
+	Content     string     `json:"content" validate:"required"`   // 说说内容，不能为空
+	Images      string     `json:"images"`                        // 说说图片
+	IsTop       int        `json:"isTop" validate:"oneof=0 1"`    // 置顶状态，0否 1是
+	Status      int        `json:"status" validate:"oneof=1 2 3"` // 说说状态，1公开 2私密 3草稿
+	PublishTime *time.Time `json:"publishTime,omitempty"`         // 定时发布时间
+}
+
+// ValidateTalkVO 校验说说参数
+func ValidateTalkVO(talkVO TalkVO) error {
+	validate := validator.New()
+	return validate.Struct(talkVO)
 }
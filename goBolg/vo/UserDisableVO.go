@@ -1,7 +1,15 @@
 package vo
 
+import "github.com/go-playground/validator/v10"
+
 // UserDisableVO 代表用户禁用状态
 type UserDisableVO struct {
-	ID        int `json:"id" validate:"required"`        // 用户id，不能为空
-	IsDisable int `json:"isDisable" validate:"required"` // 置顶状态，不能为空
+	ID        int `json:"id" validate:"required"`         // 用户信息id，不能为空
+	IsDisable int `json:"isDisable" validate:"oneof=0 1"` // 禁用状态，只能为 0 或 1
+}
+
+// ValidateUserDisableVO 用于验证 UserDisableVO 结构体
+func ValidateUserDisableVO(userDisableVO UserDisableVO) error {
+	validate := validator.New()
+	return validate.Struct(userDisableVO)
 }
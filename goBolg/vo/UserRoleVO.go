@@ -1,8 +1,16 @@
 package vo
 
+import "github.com/go-playground/validator/v10"
+
 // UserRoleVO 代表用户角色对象
 type UserRoleVO struct {
-	UserInfoId int    `json:"userInfoId" validate:"required"` // 用户id，不能为空
-	Nickname   string `json:"nickname" validate:"required"`   // 用户昵称，不能为空
-	RoleIdList []int  `json:"roleIdList" validate:"required"` // 角色id集合，不能为空
+	UserInfoId int    `json:"userInfoId" validate:"required"`      // 用户id，不能为空
+	Nickname   string `json:"nickname" validate:"required,max=50"` // 用户昵称，不能为空
+	RoleIdList []int  `json:"roleIdList" validate:"required"`      // 角色id集合，不能为空
+}
+
+// ValidateUserRoleVO 用于验证 UserRoleVO 结构体
+func ValidateUserRoleVO(userRoleVO UserRoleVO) error {
+	validate := validator.New()
+	return validate.Struct(userRoleVO)
 }
@@ -4,6 +4,7 @@ package vo
 type WebsiteConfigVO struct {
 	WebsiteAvatar     string   `json:"websiteAvatar"`     // Website Avatar
 	WebsiteName       string   `json:"websiteName"`       // Website Name
+	WebsiteUrl        string   `json:"websiteUrl"`        // Website URL，为空时使用配置文件中的地址
 	WebsiteAuthor     string   `json:"websiteAuthor"`     // Website Author
 	WebsiteIntro      string   `json:"websiteIntro"`      // Website Introduction
 	WebsiteNotice     string   `json:"websiteNotice"`     // Website Notice